@@ -15,21 +15,40 @@ import (
 	"github.com/mjiee/world-news/backend/pkg/databasex"
 	"github.com/mjiee/world-news/backend/pkg/httpx"
 	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/pathx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
 	"github.com/mjiee/world-news/backend/pkg/tracex"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
 	"github.com/mjiee/world-news/backend/repository"
 	"github.com/mjiee/world-news/backend/repository/model"
 	"github.com/mjiee/world-news/backend/service"
+	"github.com/mjiee/world-news/backend/task"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
 type App struct {
-	ctx    context.Context
-	cancel context.CancelFunc
+	ctx       context.Context
+	cancel    context.CancelFunc
+	scheduler gocron.Scheduler
+	jobWorker *task.JobWorker
 
 	crawlingSvc     service.CrawlingService
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	analyticsSvc    service.AnalyticsService
+	entitySvc       service.EntityService
+	digestSvc       service.DigestService
+	embeddingSvc    service.EmbeddingService
+	analysisSvc     service.NewsAnalysisService
+	chatSvc         service.ChatService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
+	podcastSvc      service.PodcastService
 }
 
 // NewApp creates a new App application struct
@@ -60,6 +79,16 @@ func NewApp() *App {
 	app.newsSvc = service.NewNewsService(c)
 	app.systemConfigSvc = service.NewSystemConfigService()
 	app.taskSvc = service.NewPodcastTaskService()
+	app.analyticsSvc = service.NewAnalyticsService()
+	app.entitySvc = service.NewEntityService()
+	app.digestSvc = service.NewDigestService()
+	app.embeddingSvc = service.NewEmbeddingService()
+	app.analysisSvc = service.NewNewsAnalysisService()
+	app.chatSvc = service.NewChatService()
+	app.promptSvc = service.NewPromptService()
+	app.usageSvc = service.NewUsageService()
+	app.jobSvc = service.NewPodcastJobService()
+	app.podcastSvc = service.NewPodcastService()
 
 	return app
 }
@@ -72,12 +101,51 @@ func (a *App) Startup(ctx context.Context) {
 	if err := a.systemConfigSvc.SystemConfigInit(a.ctx); err != nil {
 		logx.Fatal("SystemConfigInit", err)
 	}
+
+	// init scheduler
+	scheduler, err := task.NewScheduler(a.crawlingSvc, a.newsSvc, a.systemConfigSvc, a.entitySvc, a.digestSvc,
+		a.embeddingSvc, a.taskSvc, a.promptSvc, a.usageSvc, a.jobSvc)
+	if err != nil {
+		logx.Fatal("NewScheduler", err)
+	}
+
+	a.scheduler = scheduler
+
+	// run the podcast jobs
+	jobWorker, err := task.NewJobWorker(a.systemConfigSvc, a.taskSvc, a.promptSvc, a.jobSvc)
+	if err != nil {
+		logx.Fatal("NewJobWorker", err)
+	}
+
+	a.jobWorker = jobWorker
+
+	// forward the ai response streams to the frontend
+	streamx.Listen(func(event *streamx.Event) {
+		runtime.EventsEmit(a.ctx, streamx.EventName, event)
+	})
+
+	// record the ai usage
+	usagex.Listen(func(ctx context.Context, record *usagex.Record) {
+		if err := command.NewRecordUsageCommand(record, a.systemConfigSvc, a.usageSvc).Execute(ctx); err != nil {
+			logx.WithContext(ctx).Error("RecordUsage", err)
+		}
+	})
 }
 
 // Shutdown is called at application termination.
 func (a *App) Shutdown(ctx context.Context) {
 	a.cancel()
 
+	if a.scheduler != nil {
+		if err := a.scheduler.Shutdown(); err != nil {
+			logx.Error("SchedulerShutdown", err)
+		}
+	}
+
+	if a.jobWorker != nil {
+		a.jobWorker.Shutdown()
+	}
+
 	if err := a.crawlingSvc.PauseAllTasks(ctx); err != nil {
 		logx.Fatal("PauseAllTasks", err)
 	}
@@ -103,11 +171,19 @@ func (a *App) SaveSystemConfig(req *dto.SystemConfig) *httpx.Response {
 	return httpx.AppResp(ctx, "SaveSystemConfig", req, nil, a.systemConfigSvc.SaveSystemConfig(ctx, config))
 }
 
+// ApplyRetention handles the request to apply the retention policy.
+func (a *App) ApplyRetention(req *dto.ApplyRetentionRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := command.NewApplyRetentionCommand(req.DryRun, false, a.crawlingSvc, a.systemConfigSvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "ApplyRetention", req, data, err)
+}
+
 // CrawlingNews handles the request to crawl news.
 func (a *App) CrawlingNews(req *dto.CrawlingNewsRequest) *httpx.Response {
 	ctx := tracex.InjectTraceInContext(a.ctx)
 	cmd := command.NewCrawlingNewsCommand(a.ctx, req.StartTime, req.Sources, req.Topics,
-		a.crawlingSvc, a.newsSvc, a.systemConfigSvc)
+		a.crawlingSvc, a.newsSvc, a.systemConfigSvc, a.entitySvc)
 
 	return httpx.AppResp(ctx, "CrawlingNews", req, nil, cmd.Execute(ctx))
 }
@@ -171,11 +247,43 @@ func (a *App) QueryNews(req *dto.QueryNewsRequest) *httpx.Response {
 // GetNewsDetail handles the request to retrieve a news detail.
 func (a *App) GetNewsDetail(req *dto.GetNewsDetailRequest) *httpx.Response {
 	ctx := tracex.InjectTraceInContext(a.ctx)
-	news, err := a.newsSvc.GetNewsDetail(ctx, req.Id)
+	news, err := command.NewGetNewsDetailCommand(req.Id, a.newsSvc, a.entitySvc).Execute(ctx)
 
 	return httpx.AppResp(ctx, "GetNewsDetail", req, dto.NewNewsDetailFromEntity(news), err)
 }
 
+// GetRelatedNews handles the request to retrieve the articles related to a news detail.
+func (a *App) GetRelatedNews(req *dto.RelatedNewsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := command.NewGetRelatedNewsCommand(req.Id, req.Limit, a.newsSvc, a.entitySvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "GetRelatedNews", req, dto.NewRelatedNewsFromEntity(data), err)
+}
+
+// RefreshNewsDetail handles the request to scrape a news detail again.
+func (a *App) RefreshNewsDetail(req *dto.GetNewsDetailRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	news, err := a.newsSvc.RefreshNewsDetail(ctx, req.Id)
+
+	return httpx.AppResp(ctx, "RefreshNewsDetail", req, dto.NewNewsDetailFromEntity(news), err)
+}
+
+// QueryNewsRevisions handles the request to retrieve the revisions of a news detail.
+func (a *App) QueryNewsRevisions(req *dto.QueryNewsRevisionsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.newsSvc.QueryNewsRevisions(ctx, req.NewsId)
+
+	return httpx.AppResp(ctx, "QueryNewsRevisions", req, dto.NewNewsRevisions(data), err)
+}
+
+// DiffNewsRevisions handles the request to compare two revisions of a news detail.
+func (a *App) DiffNewsRevisions(req *dto.DiffNewsRevisionsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.newsSvc.DiffNewsRevisions(ctx, req.NewsId, req.From, req.To)
+
+	return httpx.AppResp(ctx, "DiffNewsRevisions", req, dto.NewNewsRevisionDiffFromEntity(data), err)
+}
+
 // DeleteNews handles the request to delete a news detail.
 func (a *App) DeleteNews(req *dto.DeleteNewsRequest) *httpx.Response {
 	ctx := tracex.InjectTraceInContext(a.ctx)
@@ -187,7 +295,8 @@ func (a *App) DeleteNews(req *dto.DeleteNewsRequest) *httpx.Response {
 func (a *App) CritiqueNews(req *dto.CritiqueNewsRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
-		cmd = command.NewCritiqueNewsCommand(req.Contents, a.newsSvc, a.systemConfigSvc)
+		cmd = command.NewCritiqueNewsCommand(req.Contents, streamx.NewStream(req.StreamId, streamx.Publish), a.newsSvc,
+			a.systemConfigSvc, a.promptSvc)
 	)
 
 	data, err := cmd.Execute(ctx)
@@ -207,6 +316,101 @@ func (a *App) TranslateNews(req *dto.TranslateNewsRequest) *httpx.Response {
 	return httpx.AppResp(ctx, "TranslateNews", req, data, err)
 }
 
+// ExportNews handles the request to export news to the download directory.
+func (a *App) ExportNews(req *dto.ExportNewsRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewExportNewsCommand(req.Ids, req.QueryParams(), req.Format, req.Title, req.Language,
+			req.GroupBy, a.newsSvc)
+	)
+
+	file, err := cmd.Execute(ctx)
+	if err != nil {
+		return httpx.AppResp(ctx, "ExportNews", req, nil, err)
+	}
+
+	filePath, err := file.Save(pathx.GetDownloadPath())
+
+	return httpx.AppResp(ctx, "ExportNews", req, filePath, err)
+}
+
+// SummarizeNews handles the request to summarize a news.
+func (a *App) SummarizeNews(req *dto.SummarizeNewsRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewSummarizeNewsCommand(req.Id, req.Language, req.Refresh, a.newsSvc, a.systemConfigSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "SummarizeNews", req, dto.NewNewsSummaryFromEntity(data), err)
+}
+
+// SummarizeNewsBatch handles the request to summarize the news of a query result in the background.
+func (a *App) SummarizeNewsBatch(req *dto.SummarizeNewsBatchRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewSummarizeNewsBatchCommand(a.ctx, req.QueryParams(), req.Language, a.crawlingSvc, a.newsSvc,
+			a.systemConfigSvc)
+	)
+
+	return httpx.AppResp(ctx, "SummarizeNewsBatch", req, nil, cmd.Execute(ctx))
+}
+
+// SearchNews handles the request to search the news by meaning.
+func (a *App) SearchNews(req *dto.SearchNewsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	data, err := command.NewSearchNewsCommand(req.ToValueobject(), a.embeddingSvc, a.systemConfigSvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "SearchNews", req, dto.NewNewsMatchesFromEntity(data), err)
+}
+
+// EmbedNews handles the request to embed the pending news in the background.
+func (a *App) EmbedNews() *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewEmbedNewsCommand(a.ctx, false, a.crawlingSvc, a.embeddingSvc, a.systemConfigSvc)
+	)
+
+	return httpx.AppResp(ctx, "EmbedNews", nil, nil, cmd.Execute(ctx))
+}
+
+// CompareNews handles the request to critique several news covering the same event side by side.
+func (a *App) CompareNews(req *dto.CompareNewsRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewCompareNewsCommand(req.NewsIds, req.Language, a.newsSvc, a.analysisSvc, a.systemConfigSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "CompareNews", req, dto.NewNewsAnalysisFromEntity(data), err)
+}
+
+// QueryNewsAnalyses handles the request to retrieve the news analyses.
+func (a *App) QueryNewsAnalyses(req *dto.QueryAnalysesRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, total, err := a.analysisSvc.QueryAnalyses(ctx, req.ToValueobject())
+
+	return httpx.AppResp(ctx, "QueryNewsAnalyses", req, dto.NewQueryAnalysesResult(data, total), err)
+}
+
+// GetNewsAnalysis handles the request to retrieve a news analysis.
+func (a *App) GetNewsAnalysis(req *dto.AnalysisRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.analysisSvc.GetAnalysis(ctx, req.Id)
+
+	return httpx.AppResp(ctx, "GetNewsAnalysis", req, dto.NewNewsAnalysisFromEntity(data), err)
+}
+
+// DeleteNewsAnalysis handles the request to delete a news analysis.
+func (a *App) DeleteNewsAnalysis(req *dto.AnalysisRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	return httpx.AppResp(ctx, "DeleteNewsAnalysis", req, nil, a.analysisSvc.DeleteAnalysis(ctx, req.Id))
+}
+
 // SaveNewsFavorite handles the request to save a news favorite.
 func (a *App) SaveNewsFavorite(req *dto.SaveNewsFavoriteRequest) *httpx.Response {
 	ctx := tracex.InjectTraceInContext(a.ctx)
@@ -227,7 +431,7 @@ func (a *App) CreateTask(req *dto.CreateTaskRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
 		cmd = command.NewCreateTaskCommand(ctx, req.Language, req.News.ToEntity(), req.VoiceIds, a.newsSvc,
-			a.systemConfigSvc, a.taskSvc)
+			a.systemConfigSvc, a.taskSvc, a.promptSvc, a.usageSvc, a.jobSvc)
 	)
 
 	batchNo, err := cmd.Execute(ctx)
@@ -240,7 +444,7 @@ func (a *App) AutoTask(req *dto.CreateTaskRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
 		cmd = command.NewAutoPodcastTaskCommand(ctx, req.Language, req.News.ToEntity(), a.newsSvc,
-			a.systemConfigSvc, a.taskSvc)
+			a.systemConfigSvc, a.taskSvc, a.promptSvc, a.usageSvc, a.jobSvc)
 	)
 
 	batchNo, err := cmd.Execute(ctx)
@@ -248,6 +452,19 @@ func (a *App) AutoTask(req *dto.CreateTaskRequest) *httpx.Response {
 	return httpx.AppResp(ctx, "AutoTask", req, &dto.CreateTaskResult{BatchNo: batchNo}, err)
 }
 
+// CreateDailyShow handles the request to create the daily show of the top news.
+func (a *App) CreateDailyShow() *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewDailyShowCommand(ctx, false, a.crawlingSvc, a.newsSvc, a.systemConfigSvc, a.taskSvc,
+			a.promptSvc, a.usageSvc, a.jobSvc)
+	)
+
+	batchNo, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "CreateDailyShow", nil, &dto.CreateTaskResult{BatchNo: batchNo}, err)
+}
+
 // DeleteTask handles the request to delete a podcast task.
 func (a *App) DeleteTask(req *dto.DeleteTaskRequest) *httpx.Response {
 	ctx := tracex.InjectTraceInContext(a.ctx)
@@ -275,7 +492,8 @@ func (a *App) GetTask(req *dto.GetTaskRequest) *httpx.Response {
 func (a *App) RestyleArticle(req *dto.RestyleArticleRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
-		cmd = command.NewRestyleArticleCommand(ctx, req.StageId, req.Prompt, a.systemConfigSvc, a.taskSvc)
+		cmd = command.NewRestyleArticleCommand(ctx, req.StageId, req.Prompt, a.systemConfigSvc, a.taskSvc,
+			a.promptSvc, a.jobSvc)
 	)
 
 	return httpx.AppResp(ctx, "RestyleArticle", req, nil, cmd.Execute(ctx))
@@ -286,7 +504,7 @@ func (a *App) MergeArticle(req *dto.MergeArticleRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
 		cmd = command.NewMergeArticleCommand(ctx, req.Language, req.Title, req.StageIds, req.VoiceIds,
-			a.systemConfigSvc, a.taskSvc)
+			a.systemConfigSvc, a.taskSvc, a.promptSvc, a.usageSvc, a.jobSvc)
 	)
 
 	batchNo, err := cmd.Execute(ctx)
@@ -298,7 +516,8 @@ func (a *App) MergeArticle(req *dto.MergeArticleRequest) *httpx.Response {
 func (a *App) CreateScript(req *dto.CreateScriptRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
-		cmd = command.NewCreateScriptCommand(ctx, req.StageId, req.VoiceIds, a.systemConfigSvc, a.taskSvc)
+		cmd = command.NewCreateScriptCommand(ctx, req.StageId, req.VoiceIds, a.systemConfigSvc, a.taskSvc,
+			a.promptSvc, a.jobSvc)
 	)
 
 	return httpx.AppResp(ctx, "CreateScript", req, nil, cmd.Execute(ctx))
@@ -318,11 +537,50 @@ func (a *App) UpdateTaskOutput(req *dto.UpdateTaskOutputRequest) *httpx.Response
 	return httpx.AppResp(ctx, "UpdateTaskOutput", req, nil, a.taskSvc.UpdateTaskOutput(ctx, req.StageId, req.Output))
 }
 
+// ReviewTaskStage handles the request to review a podcast task stage paused for human review.
+func (a *App) ReviewTaskStage(req *dto.ReviewTaskStageRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewReviewStageCommand(ctx, req.StageId, req.Approved, req.Index, a.systemConfigSvc, a.taskSvc,
+			a.promptSvc, a.jobSvc)
+	)
+
+	return httpx.AppResp(ctx, "ReviewTaskStage", req, nil, cmd.Execute(ctx))
+}
+
+// CancelTask handles the request to cancel a running podcast task.
+func (a *App) CancelTask(req *dto.CancelTaskRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewCancelTaskCommand(req.BatchNo, req.StageId, a.taskSvc, a.jobSvc)
+	)
+
+	return httpx.AppResp(ctx, "CancelTask", req, nil, cmd.Execute(ctx))
+}
+
+// RetryTaskStage handles the request to rerun a failed or cancelled task stage.
+func (a *App) RetryTaskStage(req *dto.RetryTaskStageRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewRetryStageCommand(req.StageId, a.taskSvc, a.jobSvc)
+	)
+
+	return httpx.AppResp(ctx, "RetryTaskStage", req, nil, cmd.Execute(ctx))
+}
+
+// QueryJobs handles the request to retrieve the background jobs of the podcast tasks.
+func (a *App) QueryJobs(req *dto.QueryJobRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	jobs, total, err := a.jobSvc.QueryJobs(ctx, req.ToValueObject())
+
+	return httpx.AppResp(ctx, "QueryJobs", req, dto.NewQueryJobResult(jobs, total), err)
+}
+
 // CreateAudio handles the request to generate podcast audio.
 func (a *App) CreateAudio(req *dto.CreateAudioRequest) *httpx.Response {
 	var (
 		ctx = tracex.InjectTraceInContext(a.ctx)
-		cmd = command.NewCreateAudioCommand(ctx, req.StageId, a.systemConfigSvc, a.taskSvc)
+		cmd = command.NewCreateAudioCommand(ctx, req.StageId, a.systemConfigSvc, a.taskSvc, a.jobSvc)
 	)
 
 	return httpx.AppResp(ctx, "CreateAudio", req, nil, cmd.Execute(ctx))
@@ -377,12 +635,308 @@ func (a *App) GetAudioData(req string) *httpx.Response {
 	return httpx.AppResp(ctx, "GetAudioData", req, audioData, err)
 }
 
+// PublishPodcast handles the request to publish a completed podcast task into the podcast library.
+func (a *App) PublishPodcast(req *dto.PublishPodcastRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewPublishPodcastCommand(req.BatchNo, req.Title, req.Description, a.systemConfigSvc,
+			a.newsSvc, a.taskSvc, a.podcastSvc)
+	)
+
+	podcast, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "PublishPodcast", req, dto.NewPodcast(podcast), err)
+}
+
 // QueryPodcasts handles the request to retrieve podcast list.
-func (a *App) QueryPodcasts() *httpx.Response {
-	return nil
+func (a *App) QueryPodcasts(req *dto.QueryPodcastRequest) *httpx.Response {
+	var (
+		ctx                  = tracex.InjectTraceInContext(a.ctx)
+		podcasts, total, err = a.podcastSvc.QueryPodcasts(ctx, req.ToValueObject())
+	)
+
+	return httpx.AppResp(ctx, "QueryPodcasts", req, dto.NewQueryPodcastResult(podcasts, total), err)
 }
 
 // GetPodcast handles the request to retrieve a podcast.
-func (a *App) GetPodcast() *httpx.Response {
-	return nil
+func (a *App) GetPodcast(req *dto.PodcastRequest) *httpx.Response {
+	var (
+		ctx          = tracex.InjectTraceInContext(a.ctx)
+		podcast, err = a.podcastSvc.GetPodcast(ctx, req.Id)
+	)
+
+	return httpx.AppResp(ctx, "GetPodcast", req, dto.NewPodcast(podcast), err)
+}
+
+// UpdatePodcast handles the request to update the title and the show notes of a podcast.
+func (a *App) UpdatePodcast(req *dto.UpdatePodcastRequest) *httpx.Response {
+	var (
+		ctx          = tracex.InjectTraceInContext(a.ctx)
+		cmd          = command.NewUpdatePodcastCommand(req.Id, req.Title, req.Description, a.podcastSvc)
+		podcast, err = cmd.Execute(ctx)
+	)
+
+	return httpx.AppResp(ctx, "UpdatePodcast", req, dto.NewPodcast(podcast), err)
+}
+
+// UnpublishPodcast handles the request to remove a podcast from the podcast library.
+func (a *App) UnpublishPodcast(req *dto.PodcastRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	return httpx.AppResp(ctx, "UnpublishPodcast", req, nil, a.podcastSvc.DeletePodcast(ctx, req.Id))
+}
+
+// QueryTermFrequencies handles the request to count the terms of the news titles.
+func (a *App) QueryTermFrequencies(req *dto.NewsAnalyticsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		return httpx.AppResp(ctx, "QueryTermFrequencies", req, nil, err)
+	}
+
+	data, err := a.analyticsSvc.TermFrequencies(ctx, params)
+
+	return httpx.AppResp(ctx, "QueryTermFrequencies", req, data, err)
+}
+
+// QueryTrendingTerms handles the request to find the trending terms.
+func (a *App) QueryTrendingTerms(req *dto.NewsAnalyticsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		return httpx.AppResp(ctx, "QueryTrendingTerms", req, nil, err)
+	}
+
+	data, err := a.analyticsSvc.TrendingTerms(ctx, params)
+
+	return httpx.AppResp(ctx, "QueryTrendingTerms", req, data, err)
+}
+
+// QueryNewsTimeSeries handles the request to count the news per day.
+func (a *App) QueryNewsTimeSeries(req *dto.NewsAnalyticsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		return httpx.AppResp(ctx, "QueryNewsTimeSeries", req, nil, err)
+	}
+
+	data, err := command.NewQueryNewsTimeSeriesCommand(params, a.analyticsSvc, a.systemConfigSvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "QueryNewsTimeSeries", req, data, err)
+}
+
+// ExtractEntities handles the request to extract the named entities of the news in the background.
+func (a *App) ExtractEntities() *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	cmd := command.NewExtractEntitiesCommand(a.ctx, false, a.crawlingSvc, a.entitySvc, a.systemConfigSvc)
+
+	return httpx.AppResp(ctx, "ExtractEntities", nil, nil, cmd.Execute(ctx))
+}
+
+// QueryEntities handles the request to retrieve the named entities.
+func (a *App) QueryEntities(req *dto.QueryEntitiesRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, total, err := a.entitySvc.QueryEntities(ctx, req.ToValueobject())
+
+	return httpx.AppResp(ctx, "QueryEntities", req, dto.NewQueryEntitiesResult(data, total), err)
+}
+
+// QueryEntityNews handles the request to retrieve the news mentioning a named entity.
+func (a *App) QueryEntityNews(req *dto.EntityNewsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, total, err := a.entitySvc.QueryEntityNews(ctx, req.ToValueobject())
+
+	return httpx.AppResp(ctx, "QueryEntityNews", req, dto.NewQueryNewsResult(data, total), err)
+}
+
+// QueryCooccurringEntities handles the request to retrieve the entities mentioned together with a named entity.
+func (a *App) QueryCooccurringEntities(req *dto.EntityNewsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.entitySvc.QueryCooccurringEntities(ctx, req.ToValueobject())
+
+	return httpx.AppResp(ctx, "QueryCooccurringEntities", req, dto.NewNamedEntities(data), err)
+}
+
+// GenerateDigest handles the request to generate the news digest of a day in the background.
+func (a *App) GenerateDigest(req *dto.GenerateDigestRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewGenerateDigestCommand(a.ctx, req.Date, false, a.newsSvc, a.digestSvc, a.systemConfigSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "GenerateDigest", req, dto.NewNewsDigestFromEntity(data), err)
+}
+
+// QueryDigests handles the request to retrieve the news digests.
+func (a *App) QueryDigests(req *dto.QueryDigestsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, total, err := a.digestSvc.QueryDigests(ctx, req.GetPage())
+
+	return httpx.AppResp(ctx, "QueryDigests", req, dto.NewQueryDigestsResult(data, total), err)
+}
+
+// GetDigest handles the request to retrieve a news digest.
+func (a *App) GetDigest(req *dto.DigestRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.digestSvc.GetDigest(ctx, req.Id)
+
+	return httpx.AppResp(ctx, "GetDigest", req, dto.NewNewsDigestFromEntity(data), err)
+}
+
+// DeleteDigest handles the request to delete a news digest.
+func (a *App) DeleteDigest(req *dto.DigestRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	return httpx.AppResp(ctx, "DeleteDigest", req, nil, a.digestSvc.DeleteDigest(ctx, req.Id))
+}
+
+// ExportDigest handles the request to export a news digest to the download directory.
+func (a *App) ExportDigest(req *dto.ExportDigestRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	file, err := command.NewExportDigestCommand(req.Id, req.Format, a.digestSvc).Execute(ctx)
+	if err != nil {
+		return httpx.AppResp(ctx, "ExportDigest", req, nil, err)
+	}
+
+	filePath, err := file.Save(pathx.GetDownloadPath())
+
+	return httpx.AppResp(ctx, "ExportDigest", req, filePath, err)
+}
+
+// CreateChat handles the request to start a chat session about the news.
+func (a *App) CreateChat(req *dto.CreateChatRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := command.NewCreateChatCommand(req.NewsIds, req.Title, a.chatSvc, a.newsSvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "CreateChat", req, dto.NewChatSessionFromEntity(data), err)
+}
+
+// AskChat handles the request to ask a question in a chat session.
+func (a *App) AskChat(req *dto.AskChatRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewAskChatCommand(req.SessionId, req.Question, streamx.NewStream(req.StreamId, streamx.Publish),
+			a.chatSvc, a.newsSvc, a.systemConfigSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "AskChat", req, dto.NewChatMessageFromEntity(data), err)
+}
+
+// QueryChats handles the request to retrieve the chat sessions.
+func (a *App) QueryChats(req *dto.QueryChatsRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, total, err := a.chatSvc.QuerySessions(ctx, req.GetPage())
+
+	return httpx.AppResp(ctx, "QueryChats", req, dto.NewQueryChatsResult(data, total), err)
+}
+
+// GetChat handles the request to retrieve a chat session with its messages.
+func (a *App) GetChat(req *dto.ChatRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.chatSvc.GetSession(ctx, req.Id)
+
+	return httpx.AppResp(ctx, "GetChat", req, dto.NewChatSessionFromEntity(data), err)
+}
+
+// DeleteChat handles the request to delete a chat session.
+func (a *App) DeleteChat(req *dto.ChatRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	return httpx.AppResp(ctx, "DeleteChat", req, nil, a.chatSvc.DeleteSession(ctx, req.Id))
+}
+
+// CancelStream handles the request to cancel an ai response stream.
+func (a *App) CancelStream(req *dto.CancelStreamRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	streamx.Cancel(req.StreamId)
+
+	return httpx.AppResp(ctx, "CancelStream", req, nil, nil)
+}
+
+// SavePrompt handles the request to save a new version of a prompt template.
+func (a *App) SavePrompt(req *dto.SavePromptRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewSavePromptCommand(req.Name, req.Language, req.Content, req.Note, a.promptSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "SavePrompt", req, dto.NewPromptTemplateFromEntity(data), err)
+}
+
+// QueryPrompts handles the request to retrieve the latest version of the prompt templates.
+func (a *App) QueryPrompts() *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.promptSvc.QueryPrompts(ctx)
+
+	return httpx.AppResp(ctx, "QueryPrompts", nil, dto.NewPromptTemplates(data), err)
+}
+
+// QueryPromptVersions handles the request to retrieve the version history of a prompt template.
+func (a *App) QueryPromptVersions(req *dto.PromptRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.promptSvc.QueryPromptVersions(ctx, valueobject.PromptName(req.Name), req.Language)
+
+	return httpx.AppResp(ctx, "QueryPromptVersions", req, dto.NewPromptTemplates(data), err)
+}
+
+// RollbackPrompt handles the request to restore a previous version of a prompt template.
+func (a *App) RollbackPrompt(req *dto.RollbackPromptRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := a.promptSvc.RollbackPrompt(ctx, valueobject.PromptName(req.Name), req.Language, req.Version)
+
+	return httpx.AppResp(ctx, "RollbackPrompt", req, dto.NewPromptTemplateFromEntity(data), err)
+}
+
+// DeletePrompt handles the request to delete a prompt template with its versions.
+func (a *App) DeletePrompt(req *dto.PromptRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	err := a.promptSvc.DeletePrompt(ctx, valueobject.PromptName(req.Name), req.Language)
+
+	return httpx.AppResp(ctx, "DeletePrompt", req, nil, err)
+}
+
+// PreviewPrompt handles the request to render a prompt template.
+func (a *App) PreviewPrompt(req *dto.PreviewPromptRequest) *httpx.Response {
+	var (
+		ctx = tracex.InjectTraceInContext(a.ctx)
+		cmd = command.NewPreviewPromptCommand(req.Name, req.Content, req.NewsId, req.ToValueobject(), a.promptSvc,
+			a.newsSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	return httpx.AppResp(ctx, "PreviewPrompt", req, &dto.PreviewPromptResult{Prompt: data}, err)
+}
+
+// QueryUsageStats handles the request to aggregate the ai usage.
+func (a *App) QueryUsageStats(req *dto.QueryUsageRequest) *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		return httpx.AppResp(ctx, "QueryUsageStats", req, nil, err)
+	}
+
+	data, err := a.usageSvc.QueryUsageStats(ctx, params)
+
+	return httpx.AppResp(ctx, "QueryUsageStats", req, data, err)
+}
+
+// GetAiBudget handles the request to retrieve the ai cost of the current month against the budget.
+func (a *App) GetAiBudget() *httpx.Response {
+	ctx := tracex.InjectTraceInContext(a.ctx)
+	data, err := command.NewGetAiBudgetCommand(a.systemConfigSvc, a.usageSvc).Execute(ctx)
+
+	return httpx.AppResp(ctx, "GetAiBudget", nil, data, err)
 }
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+// CompareNewsRequest comparative critique of several news request
+type CompareNewsRequest struct {
+	NewsIds  []uint `json:"newsIds" binding:"min=2,max=6,dive,required"`
+	Language string `json:"language,omitempty"`
+}
+
+// QueryAnalysesRequest query news analyses request
+type QueryAnalysesRequest struct {
+	NewsId     uint              `json:"newsId,omitempty"`
+	Type       string            `json:"type,omitempty" binding:"omitempty,oneof=comparativeCritique"`
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// ToValueobject query news analyses params
+func (q *QueryAnalysesRequest) ToValueobject() *valueobject.QueryAnalysisParams {
+	params := &valueobject.QueryAnalysisParams{
+		NewsId: q.NewsId,
+		Type:   valueobject.AnalysisType(q.Type),
+		Page:   q.Pagination,
+	}
+
+	if params.Page == nil {
+		params.Page = &httpx.Pagination{}
+	}
+
+	return params
+}
+
+// AnalysisRequest news analysis id request
+type AnalysisRequest struct {
+	Id uint `json:"id" binding:"required"`
+}
+
+// NewsAnalysis news analysis
+type NewsAnalysis struct {
+	Id        uint                 `json:"id"`
+	Type      string               `json:"type"`
+	Title     string               `json:"title"`
+	Language  string               `json:"language,omitempty"`
+	NewsIds   []uint               `json:"newsIds"`
+	Critique  *ComparativeCritique `json:"critique,omitempty"`
+	CreatedAt string               `json:"createdAt"`
+}
+
+// ComparativeCritique comparative critique of several news
+type ComparativeCritique struct {
+	Event          string            `json:"event"`
+	Summary        string            `json:"summary"`
+	CommonFacts    []string          `json:"commonFacts"`
+	ContestedFacts []string          `json:"contestedFacts"`
+	Sources        []*SourceCritique `json:"sources"`
+}
+
+// SourceCritique critique of a news in the comparative critique
+type SourceCritique struct {
+	NewsId       uint     `json:"newsId"`
+	Source       string   `json:"source"`
+	Framing      string   `json:"framing"`
+	Tone         string   `json:"tone"`
+	ClaimedFacts []string `json:"claimedFacts"`
+	Omissions    []string `json:"omissions"`
+}
+
+// NewNewsAnalysisFromEntity news analysis
+func NewNewsAnalysisFromEntity(data *entity.NewsAnalysis) *NewsAnalysis {
+	if data == nil {
+		return nil
+	}
+
+	return &NewsAnalysis{
+		Id:        data.Id,
+		Type:      string(data.Type),
+		Title:     data.Title,
+		Language:  data.Language,
+		NewsIds:   data.NewsIds,
+		Critique:  newComparativeCritique(data.Critique),
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+	}
+}
+
+// newComparativeCritique comparative critique of several news
+func newComparativeCritique(data *valueobject.ComparativeCritique) *ComparativeCritique {
+	if data == nil {
+		return nil
+	}
+
+	return &ComparativeCritique{
+		Event:          data.Event,
+		Summary:        data.Summary,
+		CommonFacts:    data.CommonFacts,
+		ContestedFacts: data.ContestedFacts,
+		Sources: gokit.SliceMap(data.Sources, func(item *valueobject.SourceCritique) *SourceCritique {
+			return &SourceCritique{
+				NewsId:       item.NewsId,
+				Source:       item.Source,
+				Framing:      item.Framing,
+				Tone:         item.Tone,
+				ClaimedFacts: item.ClaimedFacts,
+				Omissions:    item.Omissions,
+			}
+		}),
+	}
+}
+
+// QueryAnalysesResult query news analyses result
+type QueryAnalysesResult struct {
+	Data  []*NewsAnalysis `json:"data"`
+	Total int64           `json:"total"`
+}
+
+// NewQueryAnalysesResult query news analyses result
+func NewQueryAnalysesResult(data []*entity.NewsAnalysis, total int64) *QueryAnalysesResult {
+	return &QueryAnalysesResult{
+		Data:  gokit.SliceMap(data, NewNewsAnalysisFromEntity),
+		Total: total,
+	}
+}
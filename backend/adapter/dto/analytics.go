@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+)
+
+// NewsAnalyticsRequest news analytics request
+type NewsAnalyticsRequest struct {
+	StartDate    string   `json:"startDate,omitempty"`
+	EndDate      string   `json:"endDate,omitempty"`
+	Source       string   `json:"source,omitempty"`
+	Topic        string   `json:"topic,omitempty"`
+	Dimension    string   `json:"dimension,omitempty" binding:"omitempty,oneof=day source topic keyword"`
+	Keywords     []string `json:"keywords,omitempty"`
+	Limit        int      `json:"limit,omitempty"`
+	WindowDays   int      `json:"windowDays,omitempty"`
+	BaselineDays int      `json:"baselineDays,omitempty"`
+}
+
+// ToValueobject news analytics params
+func (n *NewsAnalyticsRequest) ToValueobject() (*valueobject.NewsAnalyticsParams, error) {
+	params := &valueobject.NewsAnalyticsParams{
+		Source:       n.Source,
+		Topic:        n.Topic,
+		Dimension:    valueobject.AnalyticsDimension(n.Dimension),
+		Keywords:     n.Keywords,
+		Limit:        n.Limit,
+		WindowDays:   n.WindowDays,
+		BaselineDays: n.BaselineDays,
+	}
+
+	for _, item := range []struct {
+		value  string
+		target *time.Time
+	}{{n.StartDate, &params.StartDate}, {n.EndDate, &params.EndDate}} {
+		if item.value == "" {
+			continue
+		}
+
+		date, err := time.ParseInLocation(time.DateOnly, item.value, time.Local)
+		if err != nil {
+			return nil, errorx.ParamsError.SetErr(errors.WithStack(err))
+		}
+
+		*item.target = date
+	}
+
+	return params.Normalize(), nil
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+// CreateChatRequest create chat session request
+type CreateChatRequest struct {
+	NewsIds []uint `json:"newsIds" binding:"min=1,max=5,dive,required"`
+	Title   string `json:"title,omitempty"`
+}
+
+// AskChatRequest ask a question in the chat session request
+type AskChatRequest struct {
+	SessionId uint   `json:"sessionId" binding:"required"`
+	Question  string `json:"question" binding:"required"`
+	StreamId  string `json:"streamId,omitempty"` // streams the answer when set
+}
+
+// QueryChatsRequest query chat sessions request
+type QueryChatsRequest struct {
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// GetPage returns the pagination of the request
+func (q *QueryChatsRequest) GetPage() *httpx.Pagination {
+	if q.Pagination == nil {
+		return &httpx.Pagination{}
+	}
+
+	return q.Pagination
+}
+
+// ChatRequest chat session id request
+type ChatRequest struct {
+	Id uint `json:"id" binding:"required"`
+}
+
+// ChatSession chat session
+type ChatSession struct {
+	Id        uint           `json:"id"`
+	Title     string         `json:"title"`
+	NewsIds   []uint         `json:"newsIds"`
+	Messages  []*ChatMessage `json:"messages,omitempty"`
+	CreatedAt string         `json:"createdAt"`
+	UpdatedAt string         `json:"updatedAt"`
+}
+
+// ChatMessage chat message
+type ChatMessage struct {
+	Id        uint   `json:"id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// NewChatSessionFromEntity chat session
+func NewChatSessionFromEntity(data *entity.ChatSession) *ChatSession {
+	if data == nil {
+		return nil
+	}
+
+	return &ChatSession{
+		Id:        data.Id,
+		Title:     data.Title,
+		NewsIds:   data.NewsIds,
+		Messages:  gokit.SliceMap(data.Messages, NewChatMessageFromEntity),
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+		UpdatedAt: data.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+// NewChatMessageFromEntity chat message
+func NewChatMessageFromEntity(data *entity.ChatMessage) *ChatMessage {
+	if data == nil {
+		return nil
+	}
+
+	return &ChatMessage{
+		Id:        data.Id,
+		Role:      string(data.Role),
+		Content:   data.Content,
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+	}
+}
+
+// QueryChatsResult query chat sessions result
+type QueryChatsResult struct {
+	Data  []*ChatSession `json:"data"`
+	Total int64          `json:"total"`
+}
+
+// NewQueryChatsResult query chat sessions result
+func NewQueryChatsResult(data []*entity.ChatSession, total int64) *QueryChatsResult {
+	return &QueryChatsResult{
+		Data:  gokit.SliceMap(data, NewChatSessionFromEntity),
+		Total: total,
+	}
+}
@@ -58,6 +58,7 @@ type CrawlingRecord struct {
 	Id         uint                  `json:"id"`
 	RecordType string                `json:"recordType"`
 	Quantity   int64                 `json:"quantity"`
+	Total      int64                 `json:"total,omitempty"` // number of news to process by a background job
 	Status     string                `json:"status"`
 	Config     *CrawlingRecordConfig `json:"config,omitempty"`
 	StartTime  string                `json:"startTime"`
@@ -82,7 +83,7 @@ func mappingCrawlingRecord(record *entity.CrawlingRecord) *CrawlingRecord {
 		return nil
 	}
 
-	return &CrawlingRecord{
+	data := &CrawlingRecord{
 		Id:         record.Id,
 		RecordType: string(record.RecordType),
 		Quantity:   record.Quantity,
@@ -90,6 +91,12 @@ func mappingCrawlingRecord(record *entity.CrawlingRecord) *CrawlingRecord {
 		StartTime:  record.CreatedAt.Format(time.DateTime),
 		EndTime:    record.UpdatedAt.Format(time.DateTime),
 	}
+
+	if record.Config != nil {
+		data.Total = record.Config.Total
+	}
+
+	return data
 }
 
 // CrawlingRecordConfig represents the configuration of a crawling record.
@@ -0,0 +1,124 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+// GenerateDigestRequest generate news digest request
+type GenerateDigestRequest struct {
+	Date string `json:"date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// QueryDigestsRequest query news digests request
+type QueryDigestsRequest struct {
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// GetPage returns the pagination of the request
+func (q *QueryDigestsRequest) GetPage() *httpx.Pagination {
+	if q.Pagination == nil {
+		return &httpx.Pagination{}
+	}
+
+	return q.Pagination
+}
+
+// DigestRequest news digest id request
+type DigestRequest struct {
+	Id uint `json:"id" binding:"required"`
+}
+
+// ExportDigestRequest export news digest request
+type ExportDigestRequest struct {
+	Id     uint   `json:"id" binding:"required"`
+	Format string `json:"format" binding:"oneof=markdown html epub"`
+}
+
+// NewsDigest news digest
+type NewsDigest struct {
+	Id        uint             `json:"id"`
+	Date      string           `json:"date"`
+	Title     string           `json:"title"`
+	Language  string           `json:"language,omitempty"`
+	Status    string           `json:"status"`
+	Sections  []*DigestSection `json:"sections,omitempty"`
+	Reason    string           `json:"reason,omitempty"`
+	CreatedAt string           `json:"createdAt"`
+	UpdatedAt string           `json:"updatedAt"`
+}
+
+// DigestSection news digest section of a topic
+type DigestSection struct {
+	Topic string        `json:"topic"`
+	Items []*DigestItem `json:"items"`
+}
+
+// DigestItem news digest article
+type DigestItem struct {
+	NewsId      uint   `json:"newsId"`
+	Title       string `json:"title"`
+	Source      string `json:"source"`
+	Link        string `json:"link"`
+	PublishedAt string `json:"publishedAt"`
+	Summary     string `json:"summary"`
+	ClusterSize int    `json:"clusterSize"`
+	RelatedIds  []uint `json:"relatedIds,omitempty"`
+}
+
+// NewNewsDigestFromEntity news digest
+func NewNewsDigestFromEntity(data *entity.NewsDigest) *NewsDigest {
+	if data == nil {
+		return nil
+	}
+
+	return &NewsDigest{
+		Id:        data.Id,
+		Date:      data.Date,
+		Title:     data.Title,
+		Language:  data.Language,
+		Status:    string(data.Status),
+		Sections:  gokit.SliceMap(data.Sections, newDigestSection),
+		Reason:    data.Reason,
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+		UpdatedAt: data.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+// newDigestSection news digest section
+func newDigestSection(data *valueobject.DigestSection) *DigestSection {
+	return &DigestSection{
+		Topic: data.Topic,
+		Items: gokit.SliceMap(data.Items, func(item *valueobject.DigestItem) *DigestItem {
+			return &DigestItem{
+				NewsId:      item.NewsId,
+				Title:       item.Title,
+				Source:      item.Source,
+				Link:        item.Link,
+				PublishedAt: item.PublishedAt.Format(time.DateTime),
+				Summary:     item.Summary,
+				ClusterSize: item.ClusterSize,
+				RelatedIds:  item.RelatedIds,
+			}
+		}),
+	}
+}
+
+// QueryDigestsResult query news digests result
+type QueryDigestsResult struct {
+	Data  []*NewsDigest `json:"data"`
+	Total int64         `json:"total"`
+}
+
+// NewQueryDigestsResult query news digests result
+func NewQueryDigestsResult(data []*entity.NewsDigest, total int64) *QueryDigestsResult {
+	return &QueryDigestsResult{
+		Data:  gokit.SliceMap(data, NewNewsDigestFromEntity),
+		Total: total,
+	}
+}
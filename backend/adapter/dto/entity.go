@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/ner"
+)
+
+// QueryEntitiesRequest query named entities request
+type QueryEntitiesRequest struct {
+	Name       string            `json:"name,omitempty"`
+	Type       string            `json:"type,omitempty" binding:"omitempty,oneof=person organization location"`
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// ToValueobject query named entities params
+func (q *QueryEntitiesRequest) ToValueobject() *valueobject.QueryEntityParams {
+	params := &valueobject.QueryEntityParams{
+		Name: q.Name,
+		Type: ner.EntityType(q.Type),
+		Page: q.Pagination,
+	}
+
+	if params.Page == nil {
+		params.Page = &httpx.Pagination{}
+	}
+
+	return params
+}
+
+// EntityNewsRequest query the news mentioning a named entity request
+type EntityNewsRequest struct {
+	Name       string            `json:"name" binding:"required"`
+	Type       string            `json:"type,omitempty" binding:"omitempty,oneof=person organization location"`
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// ToValueobject query named entities params
+func (e *EntityNewsRequest) ToValueobject() *valueobject.QueryEntityParams {
+	return (&QueryEntitiesRequest{Name: e.Name, Type: e.Type, Pagination: e.Pagination}).ToValueobject()
+}
+
+// NamedEntity named entity
+type NamedEntity struct {
+	Id       uint   `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Mentions int64  `json:"mentions"`
+}
+
+// NewNamedEntityFromEntity named entity
+func NewNamedEntityFromEntity(data *entity.NamedEntity) *NamedEntity {
+	return &NamedEntity{
+		Id:       data.Id,
+		Name:     data.Name,
+		Type:     string(data.Type),
+		Mentions: data.Mentions,
+	}
+}
+
+// NewNamedEntities named entity list
+func NewNamedEntities(data []*entity.NamedEntity) []*NamedEntity {
+	return gokit.SliceMap(data, NewNamedEntityFromEntity)
+}
+
+// QueryEntitiesResult query named entities result
+type QueryEntitiesResult struct {
+	Data  []*NamedEntity `json:"data"`
+	Total int64          `json:"total"`
+}
+
+// NewQueryEntitiesResult query named entities result
+func NewQueryEntitiesResult(data []*entity.NamedEntity, total int64) *QueryEntitiesResult {
+	return &QueryEntitiesResult{
+		Data:  NewNamedEntities(data),
+		Total: total,
+	}
+}
@@ -5,10 +5,13 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/mjiee/gokit"
+
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/httpx"
 	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/textx"
 )
 
 // QueryNewsRequest get news detail list request
@@ -19,6 +22,8 @@ type QueryNewsRequest struct {
 	PublishDate string            `json:"publishDate,omitempty"`
 	Favorited   bool              `json:"favorited,omitempty"`
 	Pagination  *httpx.Pagination `json:"pagination"`
+
+	SummaryLanguage string `json:"summaryLanguage,omitempty"`
 }
 
 // ToValueobject query news params
@@ -29,6 +34,8 @@ func (q *QueryNewsRequest) ToValueobject() *valueobject.QueryNewsParams {
 		Topic:     q.Topic,
 		Favorited: q.Favorited,
 		Page:      q.Pagination,
+
+		SummaryLanguage: q.SummaryLanguage,
 	}
 
 	if q.PublishDate == "" {
@@ -94,6 +101,14 @@ type NewsDetail struct {
 	Images      []string `json:"images,omitempty"`
 	PublishedAt string   `json:"publishedAt,omitempty"`
 	Favorited   bool     `json:"favorited,omitempty"`
+
+	Scraped        bool   `json:"scraped,omitempty"`
+	ScrapeFailed   bool   `json:"scrapeFailed,omitempty"` // the contents are the summary of the list page
+	ScrapeAttempts int    `json:"scrapeAttempts,omitempty"`
+	NextScrapeAt   string `json:"nextScrapeAt,omitempty"`
+
+	Summary *NewsSummary   `json:"summary,omitempty"`
+	Related []*RelatedNews `json:"related,omitempty"`
 }
 
 // ToEntity create news detail
@@ -135,12 +150,16 @@ func NewNewsDetailFromEntity(data *entity.NewsDetail) *NewsDetail {
 		return nil
 	}
 
-	publishedAt := ""
+	publishedAt, nextScrapeAt := "", ""
 
 	if !data.PublishedAt.IsZero() {
 		publishedAt = data.PublishedAt.Format(time.DateOnly)
 	}
 
+	if data.ScrapeFailing() && data.ScrapeAttempts < valueobject.MaxScrapeAttempts {
+		nextScrapeAt = data.NextScrapeAt.Format(time.DateTime)
+	}
+
 	return &NewsDetail{
 		Id:          data.Id,
 		Title:       data.Title,
@@ -151,6 +170,14 @@ func NewNewsDetailFromEntity(data *entity.NewsDetail) *NewsDetail {
 		Images:      data.Images,
 		PublishedAt: publishedAt,
 		Favorited:   data.Favorited,
+
+		Scraped:        data.Scraped,
+		ScrapeFailed:   data.ScrapeFailing(),
+		ScrapeAttempts: data.ScrapeAttempts,
+		NextScrapeAt:   nextScrapeAt,
+
+		Summary: NewNewsSummaryFromEntity(data.Summary),
+		Related: NewRelatedNewsFromEntity(data.Related),
 	}
 }
 
@@ -173,6 +200,7 @@ type DeleteNewsRequest struct {
 // CritiqueNewsRequest critique news detail request
 type CritiqueNewsRequest struct {
 	Contents []string `json:"contents"`
+	StreamId string   `json:"streamId,omitempty"` // streams the critique when set
 }
 
 // TranslateNewsRequest translate news detail request
@@ -186,3 +214,210 @@ type SaveNewsFavoriteRequest struct {
 	Id        uint `json:"id"`
 	Favorited bool `json:"favorited"`
 }
+
+// ExportNewsRequest export news request
+type ExportNewsRequest struct {
+	Ids      []uint            `json:"ids,omitempty"`
+	Query    *QueryNewsRequest `json:"query,omitempty"` // the favorites are exported with query.favorited
+	Format   string            `json:"format" binding:"oneof=markdown html epub"`
+	Title    string            `json:"title,omitempty"`
+	Language string            `json:"language,omitempty"`
+	GroupBy  string            `json:"groupBy,omitempty" binding:"omitempty,oneof=source topic"`
+}
+
+// QueryParams export news query params
+func (e *ExportNewsRequest) QueryParams() *valueobject.QueryNewsParams {
+	if e.Query == nil {
+		return nil
+	}
+
+	return e.Query.ToValueobject()
+}
+
+// QueryNewsRevisionsRequest query news revisions request
+type QueryNewsRevisionsRequest struct {
+	NewsId uint `json:"newsId" binding:"required"`
+}
+
+// NewsRevision news revision
+type NewsRevision struct {
+	Id        uint     `json:"id"`
+	NewsId    uint     `json:"newsId"`
+	Revision  int      `json:"revision"`
+	Title     string   `json:"title"`
+	Author    string   `json:"author,omitempty"`
+	Contents  []string `json:"contents,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// NewNewsRevisionFromEntity news revision
+func NewNewsRevisionFromEntity(data *entity.NewsRevision) *NewsRevision {
+	if data == nil {
+		return nil
+	}
+
+	return &NewsRevision{
+		Id:        data.Id,
+		NewsId:    data.NewsId,
+		Revision:  data.Revision,
+		Title:     data.Title,
+		Author:    data.Author,
+		Contents:  data.Contents,
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+	}
+}
+
+// NewNewsRevisions news revision list
+func NewNewsRevisions(data []*entity.NewsRevision) []*NewsRevision {
+	return gokit.SliceMap(data, NewNewsRevisionFromEntity)
+}
+
+// DiffNewsRevisionsRequest diff news revisions request
+type DiffNewsRevisionsRequest struct {
+	NewsId uint `json:"newsId" binding:"required"`
+	From   int  `json:"from" binding:"required"`
+	To     int  `json:"to" binding:"required"`
+}
+
+// NewsRevisionDiff news revision diff
+type NewsRevisionDiff struct {
+	From     *NewsRevision     `json:"from"`
+	To       *NewsRevision     `json:"to"`
+	Title    []*textx.DiffLine `json:"title"`
+	Author   []*textx.DiffLine `json:"author"`
+	Contents []*textx.DiffLine `json:"contents"`
+}
+
+// NewNewsRevisionDiffFromEntity news revision diff
+func NewNewsRevisionDiffFromEntity(data *entity.NewsRevisionDiff) *NewsRevisionDiff {
+	if data == nil {
+		return nil
+	}
+
+	return &NewsRevisionDiff{
+		From:     NewNewsRevisionFromEntity(data.From),
+		To:       NewNewsRevisionFromEntity(data.To),
+		Title:    data.Title,
+		Author:   data.Author,
+		Contents: data.Contents,
+	}
+}
+
+// SummarizeNewsRequest summarize news request
+type SummarizeNewsRequest struct {
+	Id       uint   `json:"id" binding:"required"`
+	Language string `json:"language,omitempty"`
+	Refresh  bool   `json:"refresh,omitempty"`
+}
+
+// SummarizeNewsBatchRequest summarize the news of a query result request
+type SummarizeNewsBatchRequest struct {
+	Query    *QueryNewsRequest `json:"query" binding:"required"`
+	Language string            `json:"language,omitempty"`
+}
+
+// QueryParams summarize news query params
+func (s *SummarizeNewsBatchRequest) QueryParams() *valueobject.QueryNewsParams {
+	if s.Query == nil {
+		return nil
+	}
+
+	return s.Query.ToValueobject()
+}
+
+// NewsSummary news summary
+type NewsSummary struct {
+	Language string `json:"language"`
+	Short    string `json:"short"`
+	Long     string `json:"long"`
+}
+
+// NewNewsSummaryFromEntity news summary
+func NewNewsSummaryFromEntity(data *entity.NewsSummary) *NewsSummary {
+	if data == nil {
+		return nil
+	}
+
+	return &NewsSummary{
+		Language: data.Language,
+		Short:    data.Short,
+		Long:     data.Long,
+	}
+}
+
+// SearchNewsRequest search news by meaning request
+type SearchNewsRequest struct {
+	Query    string  `json:"query" binding:"required"`
+	Source   string  `json:"source,omitempty"`
+	Topic    string  `json:"topic,omitempty"`
+	Limit    int     `json:"limit,omitempty" binding:"omitempty,max=100"`
+	MinScore float64 `json:"minScore,omitempty" binding:"omitempty,min=-1,max=1"`
+}
+
+// ToValueobject search news params
+func (s *SearchNewsRequest) ToValueobject() *valueobject.SearchNewsParams {
+	return &valueobject.SearchNewsParams{
+		Query:    s.Query,
+		Source:   s.Source,
+		Topic:    s.Topic,
+		Limit:    s.Limit,
+		MinScore: s.MinScore,
+	}
+}
+
+// NewsMatch news matched by the semantic search
+type NewsMatch struct {
+	News  *NewsDetail `json:"news"`
+	Score float64     `json:"score"`
+}
+
+// NewNewsMatchesFromEntity news matched by the semantic search
+func NewNewsMatchesFromEntity(data []*entity.NewsMatch) []*NewsMatch {
+	return gokit.SliceMap(data, func(item *entity.NewsMatch) *NewsMatch {
+		return &NewsMatch{News: NewNewsDetailFromEntity(item.News), Score: item.Score}
+	})
+}
+
+// RelatedNewsRequest related news request
+type RelatedNewsRequest struct {
+	Id    uint `json:"id" binding:"required"`
+	Limit int  `json:"limit,omitempty" binding:"omitempty,max=20"`
+}
+
+// RelatedNews news related to a news detail, without the contents
+type RelatedNews struct {
+	Id             uint     `json:"id"`
+	Title          string   `json:"title"`
+	Source         string   `json:"source"`
+	Topic          string   `json:"topic,omitempty"`
+	Link           string   `json:"link,omitempty"`
+	PublishedAt    string   `json:"publishedAt,omitempty"`
+	Score          float64  `json:"score"`
+	SharedEntities int      `json:"sharedEntities,omitempty"`
+	Reasons        []string `json:"reasons"`
+}
+
+// NewRelatedNewsFromEntity news related to a news detail
+func NewRelatedNewsFromEntity(data []*entity.RelatedNews) []*RelatedNews {
+	return gokit.SliceMap(data, func(item *entity.RelatedNews) *RelatedNews {
+		publishedAt := ""
+
+		if !item.News.PublishedAt.IsZero() {
+			publishedAt = item.News.PublishedAt.Format(time.DateOnly)
+		}
+
+		return &RelatedNews{
+			Id:             item.News.Id,
+			Title:          item.News.Title,
+			Source:         item.News.Source,
+			Topic:          item.News.Topic,
+			Link:           item.News.Link,
+			PublishedAt:    publishedAt,
+			Score:          item.Score,
+			SharedEntities: item.SharedEntities,
+			Reasons: gokit.SliceMap(item.Reasons, func(reason valueobject.RelatedReason) string {
+				return string(reason)
+			}),
+		}
+	})
+}
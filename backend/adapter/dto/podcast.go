@@ -1 +1,104 @@
 package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+// PublishPodcastRequest is the request for publishing a completed podcast task
+type PublishPodcastRequest struct {
+	BatchNo     string `json:"batchNo" binding:"required"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"` // show notes, built from the source news if empty
+}
+
+// UpdatePodcastRequest is the request for updating a published podcast
+type UpdatePodcastRequest struct {
+	Id          uint   `json:"id" binding:"required"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PodcastRequest is the published podcast id request
+type PodcastRequest struct {
+	Id uint `json:"id" binding:"required"`
+}
+
+// QueryPodcastRequest is the request for querying the published podcasts
+type QueryPodcastRequest struct {
+	Language   string            `json:"language,omitempty"`
+	Style      string            `json:"style,omitempty"`
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// ToValueObject converts the request to a value object
+func (r *QueryPodcastRequest) ToValueObject() *valueobject.QueryPodcastParams {
+	params := &valueobject.QueryPodcastParams{
+		Language: r.Language,
+		Style:    r.Style,
+		Page:     r.Pagination,
+	}
+
+	if params.Page == nil {
+		params.Page = &httpx.Pagination{}
+	}
+
+	return params
+}
+
+// QueryPodcastResult is the result for querying the published podcasts
+type QueryPodcastResult struct {
+	Data  []*Podcast `json:"data"`
+	Total int64      `json:"total"`
+}
+
+func NewQueryPodcastResult(podcasts []*entity.Podcast, total int64) *QueryPodcastResult {
+	return &QueryPodcastResult{
+		Data:  gokit.SliceMap(podcasts, NewPodcast),
+		Total: total,
+	}
+}
+
+// Podcast is the published podcast episode
+type Podcast struct {
+	Id          uint                      `json:"id"`
+	BatchNo     string                    `json:"batchNo"`
+	NewsIds     []uint                    `json:"newsIds,omitempty"`
+	Title       string                    `json:"title"`
+	Description string                    `json:"description"`
+	Script      string                    `json:"script"`
+	Language    string                    `json:"language"`
+	Audio       *valueobject.PodcastAudio `json:"audio"`
+	Duration    int                       `json:"duration"`
+	Style       string                    `json:"style,omitempty"`
+	TtsAi       *valueobject.TaskAi       `json:"ttsAi,omitempty"`
+	CreatedAt   string                    `json:"createdAt"`
+	UpdatedAt   string                    `json:"updatedAt"`
+}
+
+func NewPodcast(podcast *entity.Podcast) *Podcast {
+	if podcast == nil {
+		return nil
+	}
+
+	return &Podcast{
+		Id:          podcast.Id,
+		BatchNo:     podcast.BatchNo,
+		NewsIds:     podcast.NewsIds,
+		Title:       podcast.Title,
+		Description: podcast.Description,
+		Script:      podcast.Script,
+		Language:    podcast.Language,
+		Audio:       podcast.Audio,
+		Duration:    podcast.Duration,
+		Style:       podcast.Style,
+		TtsAi:       podcast.TtsAi,
+		CreatedAt:   podcast.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   podcast.UpdatedAt.Format(time.DateTime),
+	}
+}
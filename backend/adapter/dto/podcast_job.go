@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+// QueryJobRequest is the request for querying the podcast jobs
+type QueryJobRequest struct {
+	BatchNo    string            `json:"batchNo,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Pagination *httpx.Pagination `json:"pagination"`
+}
+
+// ToValueObject converts the request to a value object
+func (r *QueryJobRequest) ToValueObject() *valueobject.QueryJobParams {
+	return &valueobject.QueryJobParams{
+		BatchNo: r.BatchNo,
+		Status:  valueobject.JobStatus(r.Status),
+		Page:    r.Pagination,
+	}
+}
+
+// QueryJobResult is the result for querying the podcast jobs
+type QueryJobResult struct {
+	Data  []*PodcastJob `json:"data"`
+	Total int64         `json:"total"`
+}
+
+func NewQueryJobResult(jobs []*entity.PodcastJob, total int64) *QueryJobResult {
+	return &QueryJobResult{
+		Data:  gokit.SliceMap(jobs, NewPodcastJob),
+		Total: total,
+	}
+}
+
+// PodcastJob is the background job of a podcast task
+type PodcastJob struct {
+	Id        uint   `json:"id"`
+	Kind      string `json:"kind"`
+	BatchNo   string `json:"batchNo"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func NewPodcastJob(job *entity.PodcastJob) *PodcastJob {
+	return &PodcastJob{
+		Id:        job.Id,
+		Kind:      string(job.Kind),
+		BatchNo:   job.BatchNo,
+		Status:    string(job.Status),
+		Attempts:  job.Attempts,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format(time.DateTime),
+		UpdatedAt: job.UpdatedAt.Format(time.DateTime),
+	}
+}
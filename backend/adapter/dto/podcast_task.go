@@ -55,6 +55,24 @@ type UpdateTaskOutputRequest struct {
 	Output  string `json:"output"`
 }
 
+// ReviewTaskStageRequest is the request for reviewing a podcast task stage paused for human review
+type ReviewTaskStageRequest struct {
+	StageId  uint `json:"stageId" binding:"required"`
+	Approved bool `json:"approved"`
+	Index    int  `json:"index,omitempty"` // the style index of the classify stage
+}
+
+// CancelTaskRequest is the request for cancelling a podcast task by its batch no or the id of a stage
+type CancelTaskRequest struct {
+	BatchNo string `json:"batchNo,omitempty"`
+	StageId uint   `json:"stageId,omitempty"`
+}
+
+// RetryTaskStageRequest is the request for retrying a failed or cancelled task stage
+type RetryTaskStageRequest struct {
+	StageId uint `json:"stageId" binding:"required"`
+}
+
 // CreateAudioRequest is the request for generating a podcast audio
 type CreateAudioRequest struct {
 	StageId uint `json:"stageId" binding:"required"`
@@ -166,17 +184,21 @@ func NewPodcastTask(task *entity.PodcastTask) *PodcastTask {
 
 // TaskStage is the task stage
 type TaskStage struct {
-	Id        uint                      `json:"id"`
-	BatchNo   string                    `json:"batchNo"`
-	Stage     string                    `json:"stage"`
-	Status    string                    `json:"status"`
-	Prompt    string                    `json:"prompt"`
-	Output    string                    `json:"output"`
-	Reason    string                    `json:"reason"`
-	Audio     *valueobject.PodcastAudio `json:"audio"`
-	TaskAi    *valueobject.TaskAi       `json:"taskAi"`
-	CreatedAt string                    `json:"createdAt"`
-	UpdatedAt string                    `json:"updatedAt"`
+	Id        uint                        `json:"id"`
+	BatchNo   string                      `json:"batchNo"`
+	Stage     string                      `json:"stage"`
+	Status    string                      `json:"status"`
+	Prompt    string                      `json:"prompt"`
+	Output    string                      `json:"output"`
+	Reason    string                      `json:"reason"`
+	Audio     *valueobject.PodcastAudio   `json:"audio"`
+	TaskAi    *valueobject.TaskAi         `json:"taskAi"`
+	Approval  *valueobject.ApprovalResult `json:"approval,omitempty"`
+	Classify  *valueobject.ClassifyResult `json:"classify,omitempty"`
+	Attempts  int                         `json:"attempts"`
+	Failures  []*valueobject.StageFailure `json:"failures,omitempty"`
+	CreatedAt string                      `json:"createdAt"`
+	UpdatedAt string                      `json:"updatedAt"`
 }
 
 // NewTaskStage converts the entity to a task stage
@@ -193,6 +215,10 @@ func NewTaskStage(stage *valueobject.TaskStage) *TaskStage {
 		Prompt:    stage.Prompt,
 		Output:    stage.Output,
 		Reason:    stage.Reason,
+		Approval:  stage.Approval,
+		Classify:  stage.Classify,
+		Attempts:  stage.Attempts,
+		Failures:  stage.Failures,
 		CreatedAt: stage.CreatedAt.Format(time.DateTime),
 		UpdatedAt: stage.UpdatedAt.Format(time.DateTime),
 	}
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+)
+
+// SavePromptRequest save a new version of the prompt template request
+type SavePromptRequest struct {
+	Name     string `json:"name" binding:"required,max=64"`
+	Language string `json:"language,omitempty"`
+	Content  string `json:"content" binding:"required"`
+	Note     string `json:"note,omitempty"`
+}
+
+// PromptRequest prompt template variant request
+type PromptRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Language string `json:"language,omitempty"`
+}
+
+// RollbackPromptRequest rollback the prompt template to a version request
+type RollbackPromptRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Language string `json:"language,omitempty"`
+	Version  int    `json:"version" binding:"required,min=1"`
+}
+
+// PreviewPromptRequest render the prompt template request
+type PreviewPromptRequest struct {
+	Name     string         `json:"name" binding:"required"`
+	Language string         `json:"language,omitempty"`
+	Content  string         `json:"content,omitempty"` // the unsaved content, the saved template by default
+	NewsId   uint           `json:"newsId,omitempty"`  // fills the title, source and contents
+	Title    string         `json:"title,omitempty"`
+	Source   string         `json:"source,omitempty"`
+	Contents []string       `json:"contents,omitempty"`
+	Voices   []*ttsai.Voice `json:"voices,omitempty"`
+	Styles   []string       `json:"styles,omitempty"`
+}
+
+// ToValueobject prompt template variables
+func (p *PreviewPromptRequest) ToValueobject() *valueobject.PromptVars {
+	return &valueobject.PromptVars{
+		Title:    p.Title,
+		Source:   p.Source,
+		Language: p.Language,
+		Contents: p.Contents,
+		Voices:   p.Voices,
+		Styles:   p.Styles,
+	}
+}
+
+// PreviewPromptResult render the prompt template result
+type PreviewPromptResult struct {
+	Prompt string `json:"prompt"`
+}
+
+// PromptTemplate prompt template version
+type PromptTemplate struct {
+	Id        uint   `json:"id"`
+	Name      string `json:"name"`
+	Language  string `json:"language,omitempty"`
+	Version   int    `json:"version"`
+	Content   string `json:"content"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// NewPromptTemplateFromEntity prompt template version
+func NewPromptTemplateFromEntity(data *entity.PromptTemplate) *PromptTemplate {
+	if data == nil {
+		return nil
+	}
+
+	return &PromptTemplate{
+		Id:        data.Id,
+		Name:      string(data.Name),
+		Language:  data.Language,
+		Version:   data.Version,
+		Content:   data.Content,
+		Note:      data.Note,
+		CreatedAt: data.CreatedAt.Format(time.DateTime),
+	}
+}
+
+// NewPromptTemplates prompt template versions
+func NewPromptTemplates(data []*entity.PromptTemplate) []*PromptTemplate {
+	return gokit.SliceMap(data, NewPromptTemplateFromEntity)
+}
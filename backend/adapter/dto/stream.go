@@ -0,0 +1,6 @@
+package dto
+
+// CancelStreamRequest cancel ai response stream request
+type CancelStreamRequest struct {
+	StreamId string `json:"streamId" binding:"required"`
+}
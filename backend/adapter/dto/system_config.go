@@ -51,3 +51,8 @@ type SaveWebsiteWeightRequest struct {
 	Website string `json:"website" binding:"required"`
 	Step    int    `json:"step" binding:"required"` // -1, 1
 }
+
+// ApplyRetentionRequest apply retention policy request
+type ApplyRetentionRequest struct {
+	DryRun bool `json:"dryRun"`
+}
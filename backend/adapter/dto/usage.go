@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+)
+
+// QueryUsageRequest ai usage statistics request
+type QueryUsageRequest struct {
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	Dimension string `json:"dimension,omitempty" binding:"omitempty,oneof=day model feature"`
+	Feature   string `json:"feature,omitempty"`
+	BatchNo   string `json:"batchNo,omitempty"`
+}
+
+// ToValueobject ai usage statistics params
+func (q *QueryUsageRequest) ToValueobject() (*valueobject.QueryUsageParams, error) {
+	params := &valueobject.QueryUsageParams{
+		Dimension: valueobject.UsageDimension(q.Dimension),
+		Feature:   valueobject.AiFeature(q.Feature),
+		BatchNo:   q.BatchNo,
+	}
+
+	for _, item := range []struct {
+		value  string
+		target *time.Time
+	}{{q.StartDate, &params.StartDate}, {q.EndDate, &params.EndDate}} {
+		if item.value == "" {
+			continue
+		}
+
+		date, err := time.ParseInLocation(time.DateOnly, item.value, time.Local)
+		if err != nil {
+			return nil, errorx.ParamsError.SetErr(errors.WithStack(err))
+		}
+
+		*item.target = date
+	}
+
+	return params.Normalize(), nil
+}
@@ -14,8 +14,12 @@ import (
 	"github.com/mjiee/world-news/backend/pkg/collector"
 	"github.com/mjiee/world-news/backend/pkg/config"
 	"github.com/mjiee/world-news/backend/pkg/databasex"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
 	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
 	"github.com/mjiee/world-news/backend/pkg/tracex"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
 	"github.com/mjiee/world-news/backend/repository"
 	"github.com/mjiee/world-news/backend/repository/model"
 	"github.com/mjiee/world-news/backend/service"
@@ -30,6 +34,16 @@ type WebAadapter struct {
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	analyticsSvc    service.AnalyticsService
+	entitySvc       service.EntityService
+	digestSvc       service.DigestService
+	embeddingSvc    service.EmbeddingService
+	analysisSvc     service.NewsAnalysisService
+	chatSvc         service.ChatService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
+	podcastSvc      service.PodcastService
 }
 
 // SetWebAdapter create a new WebAadapter
@@ -56,6 +70,16 @@ func SetWebAdapter(conf *config.WebConfig) (*WebAadapter, error) {
 	web.newsSvc = service.NewNewsService(c)
 	web.systemConfigSvc = service.NewSystemConfigService()
 	web.taskSvc = service.NewPodcastTaskService()
+	web.analyticsSvc = service.NewAnalyticsService()
+	web.entitySvc = service.NewEntityService()
+	web.digestSvc = service.NewDigestService()
+	web.embeddingSvc = service.NewEmbeddingService()
+	web.analysisSvc = service.NewNewsAnalysisService()
+	web.chatSvc = service.NewChatService()
+	web.promptSvc = service.NewPromptService()
+	web.usageSvc = service.NewUsageService()
+	web.jobSvc = service.NewPodcastJobService()
+	web.podcastSvc = service.NewPodcastService()
 
 	// init system config
 	if err := web.systemConfigSvc.SystemConfigInit(context.Background()); err != nil {
@@ -63,10 +87,23 @@ func SetWebAdapter(conf *config.WebConfig) (*WebAadapter, error) {
 	}
 
 	// init scheduler
-	if err := task.NewScheduler(web.crawlingSvc, web.newsSvc, web.systemConfigSvc); err != nil {
+	if _, err := task.NewScheduler(web.crawlingSvc, web.newsSvc, web.systemConfigSvc, web.entitySvc,
+		web.digestSvc, web.embeddingSvc, web.taskSvc, web.promptSvc, web.usageSvc, web.jobSvc); err != nil {
 		return nil, err
 	}
 
+	// run the podcast jobs
+	if _, err := task.NewJobWorker(web.systemConfigSvc, web.taskSvc, web.promptSvc, web.jobSvc); err != nil {
+		return nil, err
+	}
+
+	// record the ai usage
+	usagex.Listen(func(ctx context.Context, record *usagex.Record) {
+		if err := command.NewRecordUsageCommand(record, web.systemConfigSvc, web.usageSvc).Execute(ctx); err != nil {
+			logx.WithContext(ctx).Error("RecordUsage", err)
+		}
+	})
+
 	return web, nil
 }
 
@@ -100,6 +137,19 @@ func (a *WebAadapter) SaveSystemConfig(c *gin.Context) {
 	httpx.WebResp(c, nil, a.systemConfigSvc.SaveSystemConfig(ctx, config))
 }
 
+// ApplyRetention handles the request to apply the retention policy.
+func (a *WebAadapter) ApplyRetention(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ApplyRetentionRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewApplyRetentionCommand(req.DryRun, false, a.crawlingSvc, a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, data, err)
+}
+
 // CrawlingNews handles the request to crawling news.
 func (a *WebAadapter) CrawlingNews(c *gin.Context) {
 	ctx, req, err := httpx.ParseRequest[dto.CrawlingNewsRequest](c)
@@ -111,7 +161,7 @@ func (a *WebAadapter) CrawlingNews(c *gin.Context) {
 	cmdCtx := tracex.CopyTraceContext(ctx, context.Background())
 
 	cmd := command.NewCrawlingNewsCommand(cmdCtx, req.StartTime, req.Sources, req.Topics,
-		a.crawlingSvc, a.newsSvc, a.systemConfigSvc)
+		a.crawlingSvc, a.newsSvc, a.systemConfigSvc, a.entitySvc)
 
 	httpx.WebResp(c, nil, cmd.Execute(ctx))
 }
@@ -207,11 +257,63 @@ func (a *WebAadapter) GetNewsDetail(c *gin.Context) {
 		return
 	}
 
-	news, err := a.newsSvc.GetNewsDetail(ctx, req.Id)
+	news, err := command.NewGetNewsDetailCommand(req.Id, a.newsSvc, a.entitySvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewNewsDetailFromEntity(news), err)
+}
+
+// GetRelatedNews handles the request to retrieve the articles related to a news detail.
+func (a *WebAadapter) GetRelatedNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.RelatedNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewGetRelatedNewsCommand(req.Id, req.Limit, a.newsSvc, a.entitySvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewRelatedNewsFromEntity(data), err)
+}
+
+// RefreshNewsDetail handles the request to scrape a news detail again.
+func (a *WebAadapter) RefreshNewsDetail(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.GetNewsDetailRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	news, err := a.newsSvc.RefreshNewsDetail(ctx, req.Id)
 
 	httpx.WebResp(c, dto.NewNewsDetailFromEntity(news), err)
 }
 
+// QueryNewsRevisions handles the request to retrieve the revisions of a news detail.
+func (a *WebAadapter) QueryNewsRevisions(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryNewsRevisionsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.newsSvc.QueryNewsRevisions(ctx, req.NewsId)
+
+	httpx.WebResp(c, dto.NewNewsRevisions(data), err)
+}
+
+// DiffNewsRevisions handles the request to compare two revisions of a news detail.
+func (a *WebAadapter) DiffNewsRevisions(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.DiffNewsRevisionsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.newsSvc.DiffNewsRevisions(ctx, req.NewsId, req.From, req.To)
+
+	httpx.WebResp(c, dto.NewNewsRevisionDiffFromEntity(data), err)
+}
+
 // DeleteNews handles the request to delete a news detail.
 func (a *WebAadapter) DeleteNews(c *gin.Context) {
 	ctx, req, err := httpx.ParseRequest[dto.DeleteNewsRequest](c)
@@ -231,12 +333,32 @@ func (a *WebAadapter) CritiqueNews(c *gin.Context) {
 		return
 	}
 
-	cmd := command.NewCritiqueNewsCommand(req.Contents, a.newsSvc, a.systemConfigSvc)
+	cmd := command.NewCritiqueNewsCommand(req.Contents, nil, a.newsSvc, a.systemConfigSvc, a.promptSvc)
 	data, err := cmd.Execute(ctx)
 
 	httpx.WebResp(c, data, err)
 }
 
+// CritiqueNewsStream handles the request to critique a news detail with server-sent events.
+func (a *WebAadapter) CritiqueNewsStream(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.CritiqueNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	if req.StreamId == "" {
+		httpx.WebResp(c, nil, errorx.ParamsError)
+		return
+	}
+
+	cmd := command.NewCritiqueNewsCommand(req.Contents, webStream(c, req.StreamId), a.newsSvc, a.systemConfigSvc,
+		a.promptSvc)
+	data, err := cmd.Execute(ctx)
+
+	httpx.WebStreamResp(c, data, err)
+}
+
 // TranslateNews handles the request to translate a news detail.
 func (a *WebAadapter) TranslateNews(c *gin.Context) {
 	ctx, req, err := httpx.ParseRequest[dto.TranslateNewsRequest](c)
@@ -251,6 +373,133 @@ func (a *WebAadapter) TranslateNews(c *gin.Context) {
 	httpx.WebResp(c, data, err)
 }
 
+// ExportNews handles the request to export news as a file.
+func (a *WebAadapter) ExportNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ExportNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	cmd := command.NewExportNewsCommand(req.Ids, req.QueryParams(), req.Format, req.Title, req.Language,
+		req.GroupBy, a.newsSvc)
+
+	file, err := cmd.Execute(ctx)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebFile(c, file.Name, file.ContentType, file.Data)
+}
+
+// SummarizeNews handles the request to summarize a news.
+func (a *WebAadapter) SummarizeNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.SummarizeNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewSummarizeNewsCommand(req.Id, req.Language, req.Refresh, a.newsSvc,
+		a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewNewsSummaryFromEntity(data), err)
+}
+
+// SummarizeNewsBatch handles the request to summarize the news of a query result in the background.
+func (a *WebAadapter) SummarizeNewsBatch(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.SummarizeNewsBatchRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	var (
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+		cmd    = command.NewSummarizeNewsBatchCommand(cmdCtx, req.QueryParams(), req.Language, a.crawlingSvc,
+			a.newsSvc, a.systemConfigSvc)
+	)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// SearchNews handles the request to search the news by meaning.
+func (a *WebAadapter) SearchNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.SearchNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewSearchNewsCommand(req.ToValueobject(), a.embeddingSvc, a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewNewsMatchesFromEntity(data), err)
+}
+
+// EmbedNews handles the request to embed the pending news in the background.
+func (a *WebAadapter) EmbedNews(c *gin.Context) {
+	var (
+		ctx    = c.Request.Context()
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+	)
+
+	cmd := command.NewEmbedNewsCommand(cmdCtx, false, a.crawlingSvc, a.embeddingSvc, a.systemConfigSvc)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// CompareNews handles the request to critique several news covering the same event side by side.
+func (a *WebAadapter) CompareNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.CompareNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewCompareNewsCommand(req.NewsIds, req.Language, a.newsSvc, a.analysisSvc,
+		a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewNewsAnalysisFromEntity(data), err)
+}
+
+// QueryNewsAnalyses handles the request to retrieve the news analyses.
+func (a *WebAadapter) QueryNewsAnalyses(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryAnalysesRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, total, err := a.analysisSvc.QueryAnalyses(ctx, req.ToValueobject())
+
+	httpx.WebResp(c, dto.NewQueryAnalysesResult(data, total), err)
+}
+
+// GetNewsAnalysis handles the request to retrieve a news analysis.
+func (a *WebAadapter) GetNewsAnalysis(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.AnalysisRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.analysisSvc.GetAnalysis(ctx, req.Id)
+
+	httpx.WebResp(c, dto.NewNewsAnalysisFromEntity(data), err)
+}
+
+// DeleteNewsAnalysis handles the request to delete a news analysis.
+func (a *WebAadapter) DeleteNewsAnalysis(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.AnalysisRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebResp(c, nil, a.analysisSvc.DeleteAnalysis(ctx, req.Id))
+}
+
 // SaveNewsFavorite handles the request to save a news favorite.
 func (a *WebAadapter) SaveNewsFavorite(c *gin.Context) {
 	ctx, req, err := httpx.ParseRequest[dto.SaveNewsFavoriteRequest](c)
@@ -284,7 +533,7 @@ func (a *WebAadapter) CreateTask(c *gin.Context) {
 	var (
 		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
 		cmd    = command.NewCreateTaskCommand(cmdCtx, req.Language, req.News.ToEntity(), req.VoiceIds, a.newsSvc,
-			a.systemConfigSvc, a.taskSvc)
+			a.systemConfigSvc, a.taskSvc, a.promptSvc, a.usageSvc, a.jobSvc)
 	)
 
 	batchNo, err := cmd.Execute(ctx)
@@ -292,6 +541,76 @@ func (a *WebAadapter) CreateTask(c *gin.Context) {
 	httpx.WebResp(c, &dto.CreateTaskResult{BatchNo: batchNo}, err)
 }
 
+// CreateDailyShow handles the request to create the daily show of the top news.
+func (a *WebAadapter) CreateDailyShow(c *gin.Context) {
+	var (
+		ctx    = c.Request.Context()
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+		cmd    = command.NewDailyShowCommand(cmdCtx, false, a.crawlingSvc, a.newsSvc, a.systemConfigSvc, a.taskSvc,
+			a.promptSvc, a.usageSvc, a.jobSvc)
+	)
+
+	batchNo, err := cmd.Execute(ctx)
+
+	httpx.WebResp(c, &dto.CreateTaskResult{BatchNo: batchNo}, err)
+}
+
+// ReviewTaskStage handles the request to review a podcast task stage paused for human review.
+func (a *WebAadapter) ReviewTaskStage(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ReviewTaskStageRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	var (
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+		cmd    = command.NewReviewStageCommand(cmdCtx, req.StageId, req.Approved, req.Index, a.systemConfigSvc,
+			a.taskSvc, a.promptSvc, a.jobSvc)
+	)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// CancelTask handles the request to cancel a running podcast task.
+func (a *WebAadapter) CancelTask(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.CancelTaskRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	cmd := command.NewCancelTaskCommand(req.BatchNo, req.StageId, a.taskSvc, a.jobSvc)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// RetryTaskStage handles the request to rerun a failed or cancelled task stage.
+func (a *WebAadapter) RetryTaskStage(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.RetryTaskStageRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	cmd := command.NewRetryStageCommand(req.StageId, a.taskSvc, a.jobSvc)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// QueryJobs handles the request to retrieve the background jobs of the podcast tasks.
+func (a *WebAadapter) QueryJobs(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryJobRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	jobs, total, err := a.jobSvc.QueryJobs(ctx, req.ToValueObject())
+
+	httpx.WebResp(c, dto.NewQueryJobResult(jobs, total), err)
+}
+
 // QueryTasks handles the request to retrieve podcast task list.
 func (a *WebAadapter) QueryTasks(c *gin.Context) {
 	ctx, req, err := httpx.ParseRequest[dto.QueryTaskRequest](c)
@@ -317,3 +636,471 @@ func (a *WebAadapter) GetTask(c *gin.Context) {
 
 	httpx.WebResp(c, dto.NewPodcastTask(task), err)
 }
+
+// PublishPodcast handles the request to publish a completed podcast task into the podcast library.
+func (a *WebAadapter) PublishPodcast(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PublishPodcastRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	cmd := command.NewPublishPodcastCommand(req.BatchNo, req.Title, req.Description, a.systemConfigSvc,
+		a.newsSvc, a.taskSvc, a.podcastSvc)
+
+	podcast, err := cmd.Execute(ctx)
+
+	httpx.WebResp(c, dto.NewPodcast(podcast), err)
+}
+
+// QueryPodcasts handles the request to retrieve podcast list.
+func (a *WebAadapter) QueryPodcasts(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryPodcastRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	podcasts, total, err := a.podcastSvc.QueryPodcasts(ctx, req.ToValueObject())
+
+	httpx.WebResp(c, dto.NewQueryPodcastResult(podcasts, total), err)
+}
+
+// GetPodcast handles the request to retrieve a podcast.
+func (a *WebAadapter) GetPodcast(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PodcastRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	podcast, err := a.podcastSvc.GetPodcast(ctx, req.Id)
+
+	httpx.WebResp(c, dto.NewPodcast(podcast), err)
+}
+
+// UpdatePodcast handles the request to update the title and the show notes of a podcast.
+func (a *WebAadapter) UpdatePodcast(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.UpdatePodcastRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	podcast, err := command.NewUpdatePodcastCommand(req.Id, req.Title, req.Description, a.podcastSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewPodcast(podcast), err)
+}
+
+// UnpublishPodcast handles the request to remove a podcast from the podcast library.
+func (a *WebAadapter) UnpublishPodcast(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PodcastRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebResp(c, nil, a.podcastSvc.DeletePodcast(ctx, req.Id))
+}
+
+// TaskStream handles the request to follow the generated text of a podcast task with server-sent events.
+func (a *WebAadapter) TaskStream(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.GetTaskRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	events, unsubscribe := streamx.Subscribe(req.BatchNo)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			httpx.WebEvent(c, streamx.EventName, event)
+		}
+	}
+}
+
+// QueryTermFrequencies handles the request to count the terms of the news titles.
+func (a *WebAadapter) QueryTermFrequencies(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.NewsAnalyticsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.analyticsSvc.TermFrequencies(ctx, params)
+
+	httpx.WebResp(c, data, err)
+}
+
+// QueryTrendingTerms handles the request to find the trending terms.
+func (a *WebAadapter) QueryTrendingTerms(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.NewsAnalyticsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.analyticsSvc.TrendingTerms(ctx, params)
+
+	httpx.WebResp(c, data, err)
+}
+
+// QueryNewsTimeSeries handles the request to count the news per day.
+func (a *WebAadapter) QueryNewsTimeSeries(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.NewsAnalyticsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewQueryNewsTimeSeriesCommand(params, a.analyticsSvc, a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, data, err)
+}
+
+// ExtractEntities handles the request to extract the named entities of the news in the background.
+func (a *WebAadapter) ExtractEntities(c *gin.Context) {
+	var (
+		ctx    = c.Request.Context()
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+	)
+
+	cmd := command.NewExtractEntitiesCommand(cmdCtx, false, a.crawlingSvc, a.entitySvc, a.systemConfigSvc)
+
+	httpx.WebResp(c, nil, cmd.Execute(ctx))
+}
+
+// QueryEntities handles the request to retrieve the named entities.
+func (a *WebAadapter) QueryEntities(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryEntitiesRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, total, err := a.entitySvc.QueryEntities(ctx, req.ToValueobject())
+
+	httpx.WebResp(c, dto.NewQueryEntitiesResult(data, total), err)
+}
+
+// QueryEntityNews handles the request to retrieve the news mentioning a named entity.
+func (a *WebAadapter) QueryEntityNews(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.EntityNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, total, err := a.entitySvc.QueryEntityNews(ctx, req.ToValueobject())
+
+	httpx.WebResp(c, dto.NewQueryNewsResult(data, total), err)
+}
+
+// QueryCooccurringEntities handles the request to retrieve the entities mentioned together with a named entity.
+func (a *WebAadapter) QueryCooccurringEntities(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.EntityNewsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.entitySvc.QueryCooccurringEntities(ctx, req.ToValueobject())
+
+	httpx.WebResp(c, dto.NewNamedEntities(data), err)
+}
+
+// GenerateDigest handles the request to generate the news digest of a day in the background.
+func (a *WebAadapter) GenerateDigest(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.GenerateDigestRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	var (
+		cmdCtx = tracex.CopyTraceContext(ctx, context.Background())
+		cmd    = command.NewGenerateDigestCommand(cmdCtx, req.Date, false, a.newsSvc, a.digestSvc, a.systemConfigSvc)
+	)
+
+	data, err := cmd.Execute(ctx)
+
+	httpx.WebResp(c, dto.NewNewsDigestFromEntity(data), err)
+}
+
+// QueryDigests handles the request to retrieve the news digests.
+func (a *WebAadapter) QueryDigests(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryDigestsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, total, err := a.digestSvc.QueryDigests(ctx, req.GetPage())
+
+	httpx.WebResp(c, dto.NewQueryDigestsResult(data, total), err)
+}
+
+// GetDigest handles the request to retrieve a news digest.
+func (a *WebAadapter) GetDigest(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.DigestRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.digestSvc.GetDigest(ctx, req.Id)
+
+	httpx.WebResp(c, dto.NewNewsDigestFromEntity(data), err)
+}
+
+// DeleteDigest handles the request to delete a news digest.
+func (a *WebAadapter) DeleteDigest(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.DigestRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebResp(c, nil, a.digestSvc.DeleteDigest(ctx, req.Id))
+}
+
+// ExportDigest handles the request to export a news digest as a file.
+func (a *WebAadapter) ExportDigest(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ExportDigestRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	file, err := command.NewExportDigestCommand(req.Id, req.Format, a.digestSvc).Execute(ctx)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebFile(c, file.Name, file.ContentType, file.Data)
+}
+
+// CreateChat handles the request to start a chat session about the news.
+func (a *WebAadapter) CreateChat(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.CreateChatRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewCreateChatCommand(req.NewsIds, req.Title, a.chatSvc, a.newsSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewChatSessionFromEntity(data), err)
+}
+
+// AskChat handles the request to ask a question in a chat session.
+func (a *WebAadapter) AskChat(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.AskChatRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewAskChatCommand(req.SessionId, req.Question, nil, a.chatSvc, a.newsSvc,
+		a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewChatMessageFromEntity(data), err)
+}
+
+// AskChatStream handles the request to ask a question in a chat session with server-sent events.
+func (a *WebAadapter) AskChatStream(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.AskChatRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	if req.StreamId == "" {
+		httpx.WebResp(c, nil, errorx.ParamsError)
+		return
+	}
+
+	data, err := command.NewAskChatCommand(req.SessionId, req.Question, webStream(c, req.StreamId), a.chatSvc,
+		a.newsSvc, a.systemConfigSvc).Execute(ctx)
+
+	httpx.WebStreamResp(c, dto.NewChatMessageFromEntity(data), err)
+}
+
+// QueryChats handles the request to retrieve the chat sessions.
+func (a *WebAadapter) QueryChats(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryChatsRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, total, err := a.chatSvc.QuerySessions(ctx, req.GetPage())
+
+	httpx.WebResp(c, dto.NewQueryChatsResult(data, total), err)
+}
+
+// GetChat handles the request to retrieve a chat session with its messages.
+func (a *WebAadapter) GetChat(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ChatRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.chatSvc.GetSession(ctx, req.Id)
+
+	httpx.WebResp(c, dto.NewChatSessionFromEntity(data), err)
+}
+
+// DeleteChat handles the request to delete a chat session.
+func (a *WebAadapter) DeleteChat(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.ChatRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebResp(c, nil, a.chatSvc.DeleteSession(ctx, req.Id))
+}
+
+// CancelStream handles the request to cancel an ai response stream.
+func (a *WebAadapter) CancelStream(c *gin.Context) {
+	_, req, err := httpx.ParseRequest[dto.CancelStreamRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	streamx.Cancel(req.StreamId)
+
+	httpx.WebResp(c, nil, nil)
+}
+
+// SavePrompt handles the request to save a new version of a prompt template.
+func (a *WebAadapter) SavePrompt(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.SavePromptRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewSavePromptCommand(req.Name, req.Language, req.Content, req.Note, a.promptSvc).Execute(ctx)
+
+	httpx.WebResp(c, dto.NewPromptTemplateFromEntity(data), err)
+}
+
+// QueryPrompts handles the request to retrieve the latest version of the prompt templates.
+func (a *WebAadapter) QueryPrompts(c *gin.Context) {
+	data, err := a.promptSvc.QueryPrompts(c.Request.Context())
+
+	httpx.WebResp(c, dto.NewPromptTemplates(data), err)
+}
+
+// QueryPromptVersions handles the request to retrieve the version history of a prompt template.
+func (a *WebAadapter) QueryPromptVersions(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PromptRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.promptSvc.QueryPromptVersions(ctx, valueobject.PromptName(req.Name), req.Language)
+
+	httpx.WebResp(c, dto.NewPromptTemplates(data), err)
+}
+
+// RollbackPrompt handles the request to restore a previous version of a prompt template.
+func (a *WebAadapter) RollbackPrompt(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.RollbackPromptRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.promptSvc.RollbackPrompt(ctx, valueobject.PromptName(req.Name), req.Language, req.Version)
+
+	httpx.WebResp(c, dto.NewPromptTemplateFromEntity(data), err)
+}
+
+// DeletePrompt handles the request to delete a prompt template with its versions.
+func (a *WebAadapter) DeletePrompt(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PromptRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	httpx.WebResp(c, nil, a.promptSvc.DeletePrompt(ctx, valueobject.PromptName(req.Name), req.Language))
+}
+
+// PreviewPrompt handles the request to render a prompt template.
+func (a *WebAadapter) PreviewPrompt(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.PreviewPromptRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := command.NewPreviewPromptCommand(req.Name, req.Content, req.NewsId, req.ToValueobject(), a.promptSvc,
+		a.newsSvc).Execute(ctx)
+
+	httpx.WebResp(c, &dto.PreviewPromptResult{Prompt: data}, err)
+}
+
+// QueryUsageStats handles the request to aggregate the ai usage.
+func (a *WebAadapter) QueryUsageStats(c *gin.Context) {
+	ctx, req, err := httpx.ParseRequest[dto.QueryUsageRequest](c)
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	params, err := req.ToValueobject()
+	if err != nil {
+		httpx.WebResp(c, nil, err)
+		return
+	}
+
+	data, err := a.usageSvc.QueryUsageStats(ctx, params)
+
+	httpx.WebResp(c, data, err)
+}
+
+// GetAiBudget handles the request to retrieve the ai cost of the current month against the budget.
+func (a *WebAadapter) GetAiBudget(c *gin.Context) {
+	ctx := c.Request.Context()
+	data, err := command.NewGetAiBudgetCommand(a.systemConfigSvc, a.usageSvc).Execute(ctx)
+
+	httpx.WebResp(c, data, err)
+}
+
+// webStream creates a stream writing the events to the client as server-sent events.
+func webStream(c *gin.Context, id string) *streamx.Stream {
+	return streamx.NewStream(id, func(event *streamx.Event) {
+		httpx.WebEvent(c, streamx.EventName, event)
+	})
+}
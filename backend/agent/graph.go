@@ -2,18 +2,347 @@ package agent
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 
-	"github.com/mjiee/world-news/backend/service"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/mjiee/world-news/backend/agent/stage"
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
 )
 
-// GraphService is a service for managing graph
-type GraphService struct {
-	crawlingSvc     service.CrawlingService
-	newsSvc         service.NewsService
-	systemConfigSvc service.SystemConfigService
+// maxScriptRepairs is the maximum number of round-trips asking the model to fix the invalid scripts
+const maxScriptRepairs = 2
+
+// Generator generates the output of the task stage from the conversation
+type Generator func(ctx context.Context, taskStage *valueobject.TaskStage, messages []*schema.Message,
+	opts ...model.Option) (string, error)
+
+// StageHandler executes the processing task stage, setting its output and status
+type StageHandler func(ctx context.Context, state *stage.Stage, taskStage *valueobject.TaskStage) error
+
+// StageNode a node of the news to podcast graph, executing the processing task stage of the name.
+// The node is skipped if the task has no processing stage of the name.
+type StageNode struct {
+	Name    valueobject.TaskStageName
+	Handler StageHandler
+}
+
+// TextStageNodes returns the nodes of the text stages in the pipeline order
+func TextStageNodes(generate Generator) []*StageNode {
+	return []*StageNode{
+		{Name: valueobject.TaskStageApproval, Handler: textStage(generate, nil, completeApproval,
+			openai.WithResponseSchema(&openai.ResponseSchema{Name: "approval", Schema: valueobject.ApprovalSchema()}))},
+		{Name: valueobject.TaskStageRewrite, Handler: textStage(generate, nil, nil)},
+		{Name: valueobject.TaskStageClassify, Handler: textStage(generate, nil, completeClassify,
+			openai.WithResponseSchema(&openai.ResponseSchema{Name: "classify", Schema: valueobject.ClassifySchema()}))},
+		{Name: valueobject.TaskStageStylize, Handler: textStage(generate, prepareStylize, nil)},
+		{Name: valueobject.TaskStageMerge, Handler: textStage(generate, nil, nil)},
+		{Name: valueobject.TaskStageScripted, Handler: scriptedStage(generate)},
+	}
+}
+
+// CreateNewsToPodcastGraph creates a graph for news to podcast, running the nodes in order.
+// The stylize node runs in the node of the style prompt picked by the classify stage.
+// The graph ends once the task fails, e.g. the news is rejected by the approval stage,
+// or a stage pauses for human review.
+func CreateNewsToPodcastGraph(ctx context.Context, prompt *valueobject.PodcastScriptPrompt, nodes ...*StageNode) (
+	compose.Runnable[*stage.Stage, *stage.Stage], error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("the graph has no stage node")
+	}
+
+	g := compose.NewGraph[*stage.Stage, *stage.Stage]()
+
+	// the keys of the graph nodes running each stage node
+	keys := make([][]string, len(nodes))
+
+	for idx, node := range nodes {
+		keys[idx] = []string{string(node.Name)}
+
+		if err := addStageNode(g, string(node.Name), node); err != nil {
+			return nil, err
+		}
+
+		if node.Name != valueobject.TaskStageStylize {
+			continue
+		}
+
+		for index, style := range prompt.StylizePrompts {
+			key := styleNodeKey(index + 1)
+
+			err := addStageNode(g, key, &StageNode{Name: node.Name, Handler: withStylePrompt(style, node.Handler)})
+			if err != nil {
+				return nil, err
+			}
+
+			keys[idx] = append(keys[idx], key)
+		}
+	}
+
+	from := []string{compose.START}
+
+	for idx, node := range nodes {
+		for _, key := range from {
+			if err := g.AddBranch(key, nextBranch(node, keys[idx])); err != nil {
+				return nil, err
+			}
+		}
+
+		from = keys[idx]
+	}
+
+	for _, key := range from {
+		if err := g.AddEdge(key, compose.END); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.Compile(ctx, compose.WithGraphName("NewsToPodcast"))
+}
+
+// addStageNode adds the lambda node running the stage node
+func addStageNode(g *compose.Graph[*stage.Stage, *stage.Stage], key string, node *StageNode) error {
+	return g.AddLambdaNode(key, compose.InvokableLambda(runStage(node)), compose.WithNodeName(key))
+}
+
+// nextBranch returns the branch to the graph nodes of the stage node, ending the graph once the task fails or pauses
+func nextBranch(node *StageNode, keys []string) *compose.GraphBranch {
+	endNodes := map[string]bool{compose.END: true}
+	for _, key := range keys {
+		endNodes[key] = true
+	}
+
+	return compose.NewGraphBranch(func(ctx context.Context, state *stage.Stage) (string, error) {
+		if state.Failed() || state.Paused() {
+			return compose.END, nil
+		}
+
+		if node.Name == valueobject.TaskStageStylize {
+			return styleNode(state), nil
+		}
+
+		return keys[0], nil
+	}, endNodes)
+}
+
+// styleNode returns the node of the style prompt picked by the classify stage. The stylize stage with its own prompt,
+// e.g. restyled by the user, or without a picked style prompt runs in the stylize node.
+func styleNode(state *stage.Stage) string {
+	stylize := state.Processing(valueobject.TaskStageStylize)
+	classify := state.Task.GetStage(valueobject.TaskStageClassify)
+
+	if stylize == nil || stylize.Prompt != "" || classify == nil ||
+		state.Prompt.GetStylePrompt(classify.Classify) == nil {
+		return string(valueobject.TaskStageStylize)
+	}
+
+	return styleNodeKey(classify.Classify.Index)
+}
+
+// styleNodeKey returns the key of the stylize node of the style prompt index
+func styleNodeKey(index int) string {
+	return fmt.Sprintf("%s_%d", valueobject.TaskStageStylize, index)
+}
+
+// withStylePrompt returns the stylize handler applying the style prompt
+func withStylePrompt(style *valueobject.StylePrompt, handler StageHandler) StageHandler {
+	return func(ctx context.Context, state *stage.Stage, taskStage *valueobject.TaskStage) error {
+		taskStage.Prompt = style.Prompt
+
+		return handler(ctx, state, taskStage)
+	}
 }
 
-// CreateNewsToPodcastGraph creates a graph for news to podcast
-func CreateNewsToPodcastGraph(ctx context.Context) {
-	// g := compose.NewGraph[*stage.Stage, *stage.Stage]()
+// stateKey the context key of the state the stage node runs with
+type stateKey struct{}
+
+// PersistStageCallback saves the task once a stage node executed its task stage, or failed it
+func PersistStageCallback(save func(ctx context.Context, task *entity.PodcastTask) error) callbacks.Handler {
+	return callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			if state, ok := input.(*stage.Stage); ok && info.Component == compose.ComponentOfLambda {
+				return context.WithValue(ctx, stateKey{}, state)
+			}
+
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			state, ok := output.(*stage.Stage)
+			if !ok || info.Component != compose.ComponentOfLambda || state.Current == nil {
+				return ctx
+			}
+
+			if err := save(ctx, state.Task); err != nil {
+				logx.WithContext(ctx).Error("PersistStageCallback", err)
+			}
+
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, _ error) context.Context {
+			state, ok := ctx.Value(stateKey{}).(*stage.Stage)
+			if !ok || info.Component != compose.ComponentOfLambda || state.Current == nil {
+				return ctx
+			}
+
+			// the stage fails with the cancelled context as well
+			if err := save(context.WithoutCancel(ctx), state.Task); err != nil {
+				logx.WithContext(ctx).Error("PersistStageCallback", err)
+			}
+
+			return ctx
+		}).Build()
+}
+
+// runStage runs the handler of the node with the processing task stage
+func runStage(node *StageNode) func(ctx context.Context, state *stage.Stage) (*stage.Stage, error) {
+	return func(ctx context.Context, state *stage.Stage) (*stage.Stage, error) {
+		state.Current = state.Processing(node.Name)
+		if state.Current == nil {
+			return state, nil
+		}
+
+		if err := node.Handler(ctx, state, state.Current); err != nil {
+			// cancelled by the user
+			if ctx.Err() != nil {
+				state.Task.Cancel()
+
+				return nil, err
+			}
+
+			state.Fail(state.Current, err.Error())
+
+			return nil, err
+		}
+
+		if state.Current.Status == valueobject.StageStatusFailed {
+			state.Task.Result = valueobject.TaskResultFailed
+		}
+
+		return state, nil
+	}
+}
+
+// textStage returns the handler generating the output of the text stage in the conversation.
+// The prepare hook may fail the stage before the generation, the complete hook sets the status from the output.
+func textStage(generate Generator, prepare func(*stage.Stage, *valueobject.TaskStage),
+	complete func(*stage.Stage, *valueobject.TaskStage), opts ...model.Option) StageHandler {
+	return func(ctx context.Context, state *stage.Stage, taskStage *valueobject.TaskStage) error {
+		if prepare != nil {
+			if prepare(state, taskStage); !taskStage.Status.IsProcessing() {
+				return nil
+			}
+		}
+
+		state.Messages = append(state.Messages, schema.UserMessage(taskStage.BuildPrompt()))
+
+		output, err := generate(ctx, taskStage, state.Messages, opts...)
+		if err != nil {
+			return err
+		}
+
+		taskStage.SetOutput(output)
+		state.Messages = append(state.Messages, schema.AssistantMessage(taskStage.Output, nil))
+
+		if complete != nil {
+			complete(state, taskStage)
+		}
+
+		return nil
+	}
+}
+
+// completeApproval rejects the news unless approved, the approval of low confidence waits for review
+func completeApproval(state *stage.Stage, taskStage *valueobject.TaskStage) {
+	result, err := state.Prompt.ParseApprovalResult(taskStage.Output)
+	if err != nil {
+		taskStage.Fail("invalid approval result: " + err.Error())
+
+		return
+	}
+
+	if taskStage.Approval = result; result.Verdict == valueobject.ApprovalVerdictRejected {
+		taskStage.Fail(result.Reason())
+
+		return
+	}
+
+	state.Review(taskStage, result.Confidence)
+}
+
+// completeClassify sets the classify result, the classification of low confidence waits for review.
+// The style prompt of the classified topic is picked by the branch to the stylize nodes.
+func completeClassify(state *stage.Stage, taskStage *valueobject.TaskStage) {
+	result, err := state.Prompt.ParseClassifyResult(taskStage.Output)
+	if err != nil {
+		taskStage.Fail("failed to classify news: " + err.Error())
+
+		return
+	}
+
+	taskStage.Classify = result
+	state.Review(taskStage, result.Confidence)
+}
+
+// prepareStylize fails the stylize stage without a prompt, i.e. no style prompt is picked by the classify stage
+func prepareStylize(_ *stage.Stage, taskStage *valueobject.TaskStage) {
+	if taskStage.Prompt == "" {
+		taskStage.Fail("failed to classify news")
+	}
+}
+
+// scriptedStage returns the handler generating the scripts of the voices, feeding the validation errors back to
+// the model until the scripts are valid
+func scriptedStage(generate Generator) StageHandler {
+	return func(ctx context.Context, state *stage.Stage, taskStage *valueobject.TaskStage) error {
+		if taskStage.Audio == nil {
+			taskStage.Audio = &valueobject.PodcastAudio{}
+		}
+
+		var (
+			voices   = taskStage.Audio.Voices
+			prompt   = schema.UserMessage(taskStage.BuildPrompt())
+			messages = append(slices.Clone(state.Messages), prompt)
+			option   = openai.WithResponseSchema(&openai.ResponseSchema{
+				Name:   "podcast_scripts",
+				Schema: ttsai.ScriptsSchema(voices),
+			})
+		)
+
+		for attempt := 0; ; attempt++ {
+			output, err := generate(ctx, taskStage, messages, option)
+			if err != nil {
+				return err
+			}
+
+			scripts, err := state.Prompt.ExtractScripts(output)
+			if err == nil {
+				err = ttsai.ValidateScripts(scripts, voices)
+			}
+
+			if err == nil || attempt >= maxScriptRepairs {
+				taskStage.SetOutput(output)
+				state.Messages = append(state.Messages, prompt, schema.AssistantMessage(output, nil))
+
+				if err != nil {
+					taskStage.Fail("invalid scripts: " + err.Error())
+				} else {
+					taskStage.Audio.Scripts = scripts
+				}
+
+				return nil
+			}
+
+			messages = append(messages, schema.AssistantMessage(output, nil),
+				schema.UserMessage(valueobject.BuildScriptRepairPrompt(state.Task.Language, err)))
+		}
+	}
 }
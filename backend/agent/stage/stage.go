@@ -1,5 +1,96 @@
 package stage
 
-// Stage the stage for converting news to podcast
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+)
+
+// Stage the state of the news to podcast graph, passed through the stage nodes
 type Stage struct {
+	Task     *entity.PodcastTask
+	Prompt   *valueobject.PodcastScriptPrompt
+	Messages []*schema.Message      // the conversation shared by the text stages
+	Current  *valueobject.TaskStage // the task stage executed by the last node, nil if skipped
+
+	ReviewThreshold float64 // the confidence below which the task pauses for human review
+}
+
+// NewStage creates the state of the task, restoring the conversation the processing stages continue
+func NewStage(task *entity.PodcastTask, prompt *valueobject.PodcastScriptPrompt) *Stage {
+	s := &Stage{
+		Task:     task,
+		Prompt:   prompt,
+		Messages: []*schema.Message{schema.SystemMessage(prompt.BuildSystemPrompt(task.Language))},
+	}
+
+	s.restore()
+
+	return s
+}
+
+// restore restores the conversation of the completed stages before the first processing stage, e.g. the task is
+// resumed after a review or a retry. A conversation starts from the stage with the input.
+func (s *Stage) restore() {
+	stages := s.Task.Stages
+
+	end := slices.IndexFunc(stages, func(t *valueobject.TaskStage) bool { return t.Status.IsProcessing() })
+	if end <= 0 || stages[end].Input != "" {
+		return
+	}
+
+	start := end - 1
+	for start > 0 && stages[start].Input == "" {
+		start--
+	}
+
+	for _, item := range stages[start:end] {
+		if item.Status != valueobject.StageStatusCompleted || item.Output == "" ||
+			item.Stage == valueobject.TaskStageTextToSpeech {
+			continue
+		}
+
+		s.Messages = append(s.Messages, schema.UserMessage(item.BuildPrompt()),
+			schema.AssistantMessage(item.Output, nil))
+	}
+}
+
+// Processing returns the first processing task stage of the name, nil if none
+func (s *Stage) Processing(name valueobject.TaskStageName) *valueobject.TaskStage {
+	for _, item := range s.Task.Stages {
+		if item.Stage == name && item.Status.IsProcessing() {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Fail marks the task stage and the task as failed
+func (s *Stage) Fail(taskStage *valueobject.TaskStage, reason string) {
+	taskStage.Fail(reason)
+	s.Task.Result = valueobject.TaskResultFailed
+}
+
+// Review pauses the task for human review if the confidence is below the threshold
+func (s *Stage) Review(taskStage *valueobject.TaskStage, confidence float64) {
+	if confidence >= s.ReviewThreshold {
+		return
+	}
+
+	taskStage.Review(fmt.Sprintf("confidence %.2f is below the review threshold %.2f", confidence, s.ReviewThreshold))
+}
+
+// Paused checks whether the last executed task stage waits for human review
+func (s *Stage) Paused() bool {
+	return s.Current != nil && s.Current.Status == valueobject.StageStatusReview
+}
+
+// Failed checks whether the task failed
+func (s *Stage) Failed() bool {
+	return s.Task.Result.IsFailed()
 }
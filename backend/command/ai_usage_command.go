@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// RecordUsageCommand represents a command to price and save the usage of an ai call.
+type RecordUsageCommand struct {
+	record *usagex.Record
+
+	systemConfigSvc service.SystemConfigService
+	usageSvc        service.UsageService
+}
+
+func NewRecordUsageCommand(record *usagex.Record, systemConfigSvc service.SystemConfigService,
+	usageSvc service.UsageService) *RecordUsageCommand {
+	return &RecordUsageCommand{
+		record:          record,
+		systemConfigSvc: systemConfigSvc,
+		usageSvc:        usageSvc,
+	}
+}
+
+func (c *RecordUsageCommand) Execute(ctx context.Context) error {
+	pricing, err := c.systemConfigSvc.GetAiPricing(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.usageSvc.CreateUsage(ctx, entity.NewAiUsage(c.record, pricing))
+}
+
+// GetAiBudgetCommand represents a command to get the ai cost of the current month against the budget.
+type GetAiBudgetCommand struct {
+	systemConfigSvc service.SystemConfigService
+	usageSvc        service.UsageService
+}
+
+func NewGetAiBudgetCommand(systemConfigSvc service.SystemConfigService,
+	usageSvc service.UsageService) *GetAiBudgetCommand {
+	return &GetAiBudgetCommand{
+		systemConfigSvc: systemConfigSvc,
+		usageSvc:        usageSvc,
+	}
+}
+
+func (c *GetAiBudgetCommand) Execute(ctx context.Context) (*valueobject.AiBudget, error) {
+	pricing, err := c.systemConfigSvc.GetAiPricing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := valueobject.MonthRange(time.Now())
+
+	cost, err := c.usageSvc.SumCost(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valueobject.AiBudget{
+		Month:         start.Format("2006-01"),
+		Currency:      pricing.Currency,
+		Cost:          cost,
+		MonthlyBudget: pricing.MonthlyBudget,
+		Exceeded:      pricing.BudgetExceeded(cost),
+	}, nil
+}
+
+// checkAiBudget returns an error if the ai cost of the current month exceeds the monthly budget.
+func checkAiBudget(ctx context.Context, systemConfigSvc service.SystemConfigService,
+	usageSvc service.UsageService) error {
+	budget, err := NewGetAiBudgetCommand(systemConfigSvc, usageSvc).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	if budget.Exceeded {
+		return errorx.AiBudgetExceeded
+	}
+
+	return nil
+}
+
+// labelUsage labels the ai calls made with the context.
+func labelUsage(ctx context.Context, feature valueobject.AiFeature, newsId uint) context.Context {
+	return usagex.WithLabel(ctx, &usagex.Label{Feature: string(feature), NewsId: newsId})
+}
+
+// labelStageUsage labels the ai calls of the podcast task stage made with the context.
+func labelStageUsage(ctx context.Context, task *entity.PodcastTask, stage *valueobject.TaskStage) context.Context {
+	label := &usagex.Label{Feature: string(valueobject.AiFeaturePodcast), BatchNo: task.BatchNo, StageId: stage.Id}
+
+	if task.News != nil {
+		label.NewsId = task.News.Id
+	}
+
+	return usagex.WithLabel(ctx, label)
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// ApplyRetentionCommand represents a command to delete historical data by the retention policy.
+type ApplyRetentionCommand struct {
+	dryRun    bool
+	scheduled bool // started by the scheduler, only the records expire until the policy is saved
+
+	crawlingSvc     service.CrawlingService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewApplyRetentionCommand(
+	dryRun bool,
+	scheduled bool,
+	crawlingSvc service.CrawlingService,
+	systemConfigSvc service.SystemConfigService,
+) *ApplyRetentionCommand {
+	return &ApplyRetentionCommand{
+		dryRun:          dryRun,
+		scheduled:       scheduled,
+		crawlingSvc:     crawlingSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *ApplyRetentionCommand) Execute(ctx context.Context) (*valueobject.RetentionReport, error) {
+	policy, err := c.policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := c.crawlingSvc.PlanHistory(ctx, policy, c.dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.dryRun {
+		return report, nil
+	}
+
+	if err := c.crawlingSvc.DeleteHistory(ctx, report); err != nil {
+		return nil, err
+	}
+
+	logx.WithContext(ctx).Info("ApplyRetentionCommand", report)
+
+	return report, nil
+}
+
+// policy returns the retention policy. Until the policy is saved, the scheduler only deletes the expired crawling
+// records with their news, as before the policies, the other news are deleted once the user opts in.
+func (c *ApplyRetentionCommand) policy(ctx context.Context) (*valueobject.RetentionPolicy, error) {
+	if c.scheduled {
+		config, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.RetentionPolicyKey.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if config.Id == 0 {
+			return valueobject.NewDefaultRetentionPolicy().RecordRules(), nil
+		}
+	}
+
+	return c.systemConfigSvc.GetRetentionPolicy(ctx)
+}
@@ -0,0 +1,144 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestApplyRetentionCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		crawlingSvc     = service.NewCrawlingService(nil)
+		newsSvc         = service.NewNewsService(nil)
+		ctx             = context.Background()
+	)
+
+	// without a saved policy the scheduler deletes the expired records with their news, the news limits are opt-in
+	expired := newTestRecord(t, crawlingSvc, 40)
+	current := newTestRecord(t, crawlingSvc, 1)
+	expiredNews := newTestNews(t, newsSvc, expired, "a.com", 40, 2)
+	currentNews := newTestNews(t, newsSvc, current, "a.com", 1, 3)
+
+	// the expired news referenced by a chat session is kept
+	if err := repository.Q.ChatSession.WithContext(ctx).Create(&model.ChatSession{Title: "chat",
+		NewsIds: fmt.Sprintf("[%d]", expiredNews[1])}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewApplyRetentionCommand(false, true, crawlingSvc, systemConfigSvc).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(report.RecordIds, []uint{expired}) || !slices.Equal(report.NewsIds, expiredNews[:1]) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	if _, err := crawlingSvc.GetCrawlingRecord(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+
+	if kept := testNewsIds(t, current, "a.com"); !slices.Equal(kept, currentNews) {
+		t.Fatalf("unexpected news kept %v", kept)
+	}
+}
+
+// newTestRecord saves a completed crawling record created the days ago
+func newTestRecord(t *testing.T, crawlingSvc service.CrawlingService, days int) uint {
+	t.Helper()
+
+	record := entity.NewCrawlingRecord(valueobject.CrawlingNews, nil)
+	record.Status = valueobject.CompletedCrawlingRecord
+	record.CreatedAt = time.Now().AddDate(0, 0, -days)
+
+	if err := crawlingSvc.CreateCrawlingRecord(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+
+	return record.Id
+}
+
+// newTestNews saves the count of news of the record and the source created the days ago, the newest last
+func newTestNews(t *testing.T, newsSvc service.NewsService, recordId uint, source string, days, count int) []uint {
+	t.Helper()
+
+	news := make([]*entity.NewsDetail, 0, count)
+
+	for range count {
+		news = append(news, &entity.NewsDetail{RecordId: recordId, Source: source, Title: source,
+			Contents: []string{"content"}, CreatedAt: time.Now().AddDate(0, 0, -days)})
+	}
+
+	if err := newsSvc.CreateNews(context.Background(), news...); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := testNewsIds(t, recordId, source)
+
+	return ids[len(ids)-count:]
+}
+
+// testNewsIds returns the ids of the news of the record and the source
+func testNewsIds(t *testing.T, recordId uint, source string) []uint {
+	t.Helper()
+
+	var (
+		ids  []uint
+		repo = repository.Q.NewsDetail
+	)
+
+	if err := repo.WithContext(context.Background()).Where(repo.RecordId.Eq(recordId), repo.Source.Eq(source)).
+		Order(repo.ID).Pluck(repo.ID, &ids); err != nil {
+		t.Fatal(err)
+	}
+
+	return ids
+}
+
+// TestApplyRetentionSourceRules testing the source rule keeping more news than the global limits
+func TestApplyRetentionSourceRules(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		crawlingSvc     = service.NewCrawlingService(nil)
+		newsSvc         = service.NewNewsService(nil)
+		ctx             = context.Background()
+	)
+
+	saveTestConfig(t, systemConfigSvc, valueobject.RetentionPolicyKey, &valueobject.RetentionPolicy{
+		RecordMaxAgeDays: map[valueobject.CrawlingRecordType]int{valueobject.CrawlingNews: 30},
+		MaxNews:          2,
+		Sources:          []*valueobject.SourceRetention{{Source: "a.com", MaxAgeDays: 60, MaxNews: 4}},
+	})
+
+	expired := newTestRecord(t, crawlingSvc, 40)
+	current := newTestRecord(t, crawlingSvc, 1)
+	keptOld := newTestNews(t, newsSvc, expired, "a.com", 40, 1)
+	deletedOld := newTestNews(t, newsSvc, expired, "b.com", 40, 1)
+	kept := newTestNews(t, newsSvc, current, "a.com", 1, 3)
+	others := newTestNews(t, newsSvc, current, "b.com", 1, 3)
+
+	report, err := NewApplyRetentionCommand(false, false, crawlingSvc, systemConfigSvc).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted := append(deletedOld, others[0])
+	if !slices.Equal(report.RecordIds, []uint{expired}) || !slices.Equal(slices.Sorted(slices.Values(report.NewsIds)),
+		deleted) {
+		t.Fatalf("unexpected report %+v, want the news %v deleted", report, deleted)
+	}
+
+	if ids := append(testNewsIds(t, expired, "a.com"), testNewsIds(t, current, "a.com")...); !slices.Equal(ids,
+		append(keptOld, kept...)) {
+		t.Fatalf("the news of the source rule are deleted, kept %v", ids)
+	}
+}
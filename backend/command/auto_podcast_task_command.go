@@ -3,13 +3,10 @@ package command
 import (
 	"context"
 
-	"github.com/pkg/errors"
-
 	"github.com/mjiee/gokit"
 
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
-	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
 	"github.com/mjiee/world-news/backend/service"
 )
@@ -23,6 +20,9 @@ type AutoPodcastTaskCommand struct {
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
 }
 
 func NewAutoPodcastTaskCommand(
@@ -32,6 +32,9 @@ func NewAutoPodcastTaskCommand(
 	newsSvc service.NewsService,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	usageSvc service.UsageService,
+	jobSvc service.PodcastJobService,
 ) *AutoPodcastTaskCommand {
 	return &AutoPodcastTaskCommand{
 		ctx:             ctx,
@@ -40,6 +43,9 @@ func NewAutoPodcastTaskCommand(
 		newsSvc:         newsSvc,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		usageSvc:        usageSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
@@ -58,35 +64,19 @@ func (c *AutoPodcastTaskCommand) Execute(ctx context.Context) (string, error) {
 		voiceIds = voiceIds[:2]
 	}
 
-	createCmd := NewCreateTaskCommand(c.ctx, c.language, c.news, voiceIds, c.newsSvc, c.systemConfigSvc, c.taskSvc)
+	createCmd := NewCreateTaskCommand(c.ctx, c.language, c.news, voiceIds, c.newsSvc, c.systemConfigSvc, c.taskSvc,
+		c.promptSvc, c.usageSvc, c.jobSvc)
 
 	newTask, err := createCmd.createTask(ctx, textAiConfig, ttsAiConfig, prompt)
 	if err != nil {
 		return "", err
 	}
 
-	go c.executeTask(newTask)
-
-	return newTask.BatchNo, err
-}
-
-func (c *AutoPodcastTaskCommand) executeTask(task *entity.PodcastTask) {
-	// execute task
-	executeCmd := NewExecuteTaskCommand(c.ctx, task, c.systemConfigSvc, c.taskSvc)
-	if err := executeCmd.Execute(c.ctx); err != nil {
-		logx.Error("AutoPodcastTaskCommand.executeTask", err)
-		return
-	}
-
-	scriptedStage := task.GetStage(valueobject.TaskStageScripted)
-	if scriptedStage == nil {
-		logx.Error("AutoPodcastTaskCommand.executeTask", errors.New("scriptedStage is nil"))
-		return
+	// execute task and create audio by the job worker
+	job := entity.NewPodcastJob(valueobject.JobKindAutoTask, newTask.BatchNo)
+	if err := c.jobSvc.EnqueueJob(ctx, job); err != nil {
+		return "", err
 	}
 
-	// create audio
-	createAudioCmd := NewCreateAudioCommand(c.ctx, scriptedStage.Id, c.systemConfigSvc, c.taskSvc)
-	if err := createAudioCmd.Execute(c.ctx); err != nil {
-		logx.Error("AutoPodcastTaskCommand.executeTask", err)
-	}
+	return newTask.BatchNo, nil
 }
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// CancelTaskCommand represents the command to cancel a podcast task by its batch no or the id of a stage.
+// The stages of a task run one after another, so cancelling a stage cancels the run of the task.
+type CancelTaskCommand struct {
+	batchNo string
+	stageId uint
+
+	taskSvc service.PodcastTaskService
+	jobSvc  service.PodcastJobService
+}
+
+func NewCancelTaskCommand(
+	batchNo string,
+	stageId uint,
+	taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService,
+) *CancelTaskCommand {
+	return &CancelTaskCommand{
+		batchNo: batchNo,
+		stageId: stageId,
+		taskSvc: taskSvc,
+		jobSvc:  jobSvc,
+	}
+}
+
+func (c *CancelTaskCommand) Execute(ctx context.Context) error {
+	task, err := c.getTask(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !cancellable(task, c.stageId) {
+		return errorx.TaskNotProcessing
+	}
+
+	// the queued jobs never run
+	if err := c.jobSvc.CancelJobs(ctx, task.BatchNo); err != nil {
+		return err
+	}
+
+	// the in-flight run aborts the generation and saves the cancelled stages itself
+	if streamx.Cancel(task.BatchNo) {
+		return nil
+	}
+
+	task.Cancel()
+
+	return c.taskSvc.SaveTask(ctx, task)
+}
+
+// getTask gets the task of the batch no or the stage
+func (c *CancelTaskCommand) getTask(ctx context.Context) (*entity.PodcastTask, error) {
+	if c.stageId > 0 {
+		return c.taskSvc.GetTaskByStageId(ctx, c.stageId)
+	}
+
+	if c.batchNo == "" {
+		return nil, errorx.ParamsError
+	}
+
+	return c.taskSvc.GetTaskByBatchNo(ctx, c.batchNo)
+}
+
+// cancellable checks whether the stage, any stage if 0, of the task is processing or waiting for review
+func cancellable(task *entity.PodcastTask, stageId uint) bool {
+	for _, stage := range task.Stages {
+		if stageId > 0 && stage.Id != stageId {
+			continue
+		}
+
+		if stage.Status.IsProcessing() || stage.Status == valueobject.StageStatusReview {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestCancelTaskCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		taskSvc         = service.NewPodcastTaskService()
+		jobSvc          = service.NewPodcastJobService()
+		ctx             = context.Background()
+	)
+
+	// a queued task is cancelled with its job
+	queued := newTestJobTask(t, taskSvc, jobSvc, 0, 0)
+
+	if err := jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, queued)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCancelTaskCommand(queued, 0, taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := taskSvc.GetTaskByBatchNo(ctx, queued)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, _, err := jobSvc.QueryJobs(ctx, &valueobject.QueryJobParams{BatchNo: queued, Page: &httpx.Pagination{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.Stages[0].Status != valueobject.StageStatusCancelled || task.VerifyTask() != nil ||
+		jobs[0].Status != valueobject.JobStatusCancelled {
+		t.Fatalf("unexpected stage %s, job %s", task.Stages[0].Status, jobs[0].Status)
+	}
+
+	// the text to speech run in flight keeps the generated segments
+	task = newTestTask()
+	scripted := valueobject.NewTaskStage(valueobject.TaskStageScripted, "", nil)
+	scripted.Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}, Scripts: []*ttsai.TtsScript{
+		{Text: "Our city has a new library.", Speaker: testVoice.Id, AudioUrl: "segment.wav"},
+		{Text: "It runs entirely on solar power.", Speaker: testVoice.Id},
+	}}
+	scripted.SetOutput("scripts")
+	task.AddNewStage(scripted)
+	task.AddNewStage(valueobject.NewTaskStage(valueobject.TaskStageTextToSpeech, "", nil))
+	task.Stages[1].Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}}
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	runCtx, release := streamx.WithCancel(ctx, task.BatchNo)
+	defer release()
+
+	if err := NewCancelTaskCommand("", task.Stages[1].Id, taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ttsAi, _, err := systemConfigSvc.GetPodcastConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCreateAudioCommand(ctx, 0, systemConfigSvc, taskSvc, jobSvc).textToSpeech(runCtx, task,
+		task.Stages[1], ttsAi); err == nil {
+		t.Fatal("expected the cancelled run to fail")
+	}
+
+	if task, err = taskSvc.GetTaskByBatchNo(ctx, task.BatchNo); err != nil {
+		t.Fatal(err)
+	}
+
+	if task.Result != valueobject.TaskResultCancelled || task.Stages[1].Status != valueobject.StageStatusCancelled ||
+		task.GetPodcastScript()[0].AudioUrl == "" || task.VerifyTask() != nil {
+		t.Fatalf("unexpected task result %s, stage %s", task.Result, task.Stages[1].Status)
+	}
+
+	if err := NewCancelTaskCommand(task.BatchNo, 0, taskSvc, jobSvc).Execute(ctx); err == nil {
+		t.Fatal("expected the cancelled task not to be cancelled again")
+	}
+}
@@ -0,0 +1,190 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// CreateChatCommand represents a command to start a chat session anchored on one or more news.
+type CreateChatCommand struct {
+	newsIds []uint
+	title   string
+
+	chatSvc service.ChatService
+	newsSvc service.NewsService
+}
+
+func NewCreateChatCommand(newsIds []uint, title string, chatSvc service.ChatService,
+	newsSvc service.NewsService) *CreateChatCommand {
+	return &CreateChatCommand{
+		newsIds: newsIds,
+		title:   title,
+		chatSvc: chatSvc,
+		newsSvc: newsSvc,
+	}
+}
+
+func (c *CreateChatCommand) Execute(ctx context.Context) (*entity.ChatSession, error) {
+	newsIds := make([]uint, 0, len(c.newsIds))
+
+	for _, id := range c.newsIds {
+		if !slices.Contains(newsIds, id) {
+			newsIds = append(newsIds, id)
+		}
+	}
+
+	if len(newsIds) == 0 || len(newsIds) > valueobject.MaxChatNews || slices.Contains(newsIds, 0) {
+		return nil, errorx.ParamsError
+	}
+
+	title := strings.TrimSpace(c.title)
+
+	for _, id := range newsIds {
+		news, err := c.newsSvc.GetNewsDetail(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if title == "" {
+			title = news.Title
+		}
+	}
+
+	session := entity.NewChatSession(textx.Truncate(title, valueobject.MaxChatTitleLength), newsIds)
+
+	if err := c.chatSvc.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// AskChatCommand represents a command to ask a question in the chat session, answered with the articles as context.
+type AskChatCommand struct {
+	sessionId uint
+	question  string
+	stream    *streamx.Stream // emits the answer as it is generated, optional
+
+	chatSvc         service.ChatService
+	newsSvc         service.NewsService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewAskChatCommand(sessionId uint, question string, stream *streamx.Stream, chatSvc service.ChatService,
+	newsSvc service.NewsService, systemConfigSvc service.SystemConfigService) *AskChatCommand {
+	return &AskChatCommand{
+		sessionId:       sessionId,
+		question:        question,
+		stream:          stream,
+		chatSvc:         chatSvc,
+		newsSvc:         newsSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *AskChatCommand) Execute(ctx context.Context) (*entity.ChatMessage, error) {
+	question := strings.TrimSpace(c.question)
+	if c.sessionId == 0 || question == "" {
+		return nil, errorx.ParamsError
+	}
+
+	textAi, err := loadTextAiConfig(ctx, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := buildChatMessages(ctx, c.sessionId, question, c.chatSvc, c.newsSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := generateText(labelUsage(ctx, valueobject.AiFeatureChat, 0), textAi, messages, c.stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return saveChatAnswer(ctx, c.sessionId, question, answer, c.chatSvc)
+}
+
+// buildChatMessages builds the model input of the question with the articles and the previous messages.
+func buildChatMessages(ctx context.Context, sessionId uint, question string, chatSvc service.ChatService,
+	newsSvc service.NewsService) ([]*schema.Message, error) {
+	session, err := chatSvc.GetSession(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := buildChatArticles(ctx, session.NewsIds, newsSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	history := session.Messages[max(0, len(session.Messages)-valueobject.MaxChatHistory):]
+	messages := make([]*schema.Message, 0, len(history)+2)
+	messages = append(messages, schema.SystemMessage(valueobject.BuildChatPrompt(articles)))
+
+	for _, item := range history {
+		if item.Role == valueobject.ChatRoleAssistant {
+			messages = append(messages, schema.AssistantMessage(item.Content, nil))
+		} else {
+			messages = append(messages, schema.UserMessage(item.Content))
+		}
+	}
+
+	return append(messages, schema.UserMessage(question)), nil
+}
+
+// buildChatArticles builds the article texts of the chat session, skipping the deleted news.
+func buildChatArticles(ctx context.Context, newsIds []uint, newsSvc service.NewsService) (string, error) {
+	var builder strings.Builder
+
+	for _, id := range newsIds {
+		news, err := newsSvc.GetNewsDetail(ctx, id)
+		if errors.Is(err, errorx.NewsNotFound) {
+			logx.WithContext(ctx).Info("buildChatArticles", fmt.Sprintf("news %d not found", id))
+
+			continue
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&builder, "[Article id: %d, source: %s, published: %s]\n%s\n\n", news.Id, news.Source,
+			news.PublishedAt.Format(time.DateOnly), textx.Truncate(news.BuildText(), valueobject.MaxChatArticleLength))
+	}
+
+	if builder.Len() == 0 {
+		return "", errorx.NewsNotFound
+	}
+
+	return builder.String(), nil
+}
+
+// saveChatAnswer saves the question and the answer to the chat session.
+func saveChatAnswer(ctx context.Context, sessionId uint, question, answer string, chatSvc service.ChatService) (
+	*entity.ChatMessage, error) {
+	reply := entity.NewChatMessage(sessionId, valueobject.ChatRoleAssistant, strings.TrimSpace(answer))
+
+	err := chatSvc.AddMessages(ctx, sessionId, entity.NewChatMessage(sessionId, valueobject.ChatRoleUser, question),
+		reply)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/replay"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// testVoice the voice of the recorded tts fixtures
+var testVoice = &ttsai.Voice{Id: "zh_male_xudong_conversation_wvae_bigtts", Name: "Xudong", Model: "seed-tts-1.0"}
+
+// setupReplayTest sets up the test and replays the fixture of the test from testdata, for the tests calling the ai
+// services. The recording needs the api keys of the services in OPENAI_API_KEY and DOUBAO_API_KEY.
+func setupReplayTest(t *testing.T) service.SystemConfigService {
+	t.Helper()
+
+	systemConfigSvc := setupTest(t)
+
+	replay.Use(t, filepath.Join("testdata", t.Name()+".json"))
+
+	return systemConfigSvc
+}
+
+// setupTest opens an in-memory database, keeps the app files in a temp dir and saves the configs of the ai services.
+// The ai services are not called, see setupReplayTest.
+func setupTest(t *testing.T) service.SystemConfigService {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := model.AutoMigrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	repository.SetDefault(db)
+
+	systemConfigSvc := service.NewSystemConfigService()
+
+	saveTestConfig(t, systemConfigSvc, valueobject.TextAIKey, &openai.Config{
+		Platform:   "openai",
+		ApiKey:     os.Getenv("OPENAI_API_KEY"),
+		ApiUrl:     "https://api.openai.com/v1",
+		Model:      "gpt-4o-mini",
+		MaxRetries: -1,
+	})
+	saveTestConfig(t, systemConfigSvc, valueobject.TextToSpeechAIKey, &ttsai.Config{
+		Platform: "doubao",
+		ApiKey:   os.Getenv("DOUBAO_API_KEY"),
+		Voices:   []*ttsai.Voice{testVoice},
+	})
+	saveTestConfig(t, systemConfigSvc, valueobject.PodcastScriptPromptKey, &valueobject.PodcastScriptPrompt{})
+
+	return systemConfigSvc
+}
+
+// saveTestConfig saves the system config
+func saveTestConfig(t *testing.T, systemConfigSvc service.SystemConfigService, key valueobject.SystemConfigKey,
+	value any) {
+	t.Helper()
+
+	config, err := entity.NewSystemConfig(key.String(), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := systemConfigSvc.SaveSystemConfig(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestTask creates a podcast task of a news
+func newTestTask() *entity.PodcastTask {
+	return entity.NewPodcastTask(&entity.NewsDetail{
+		Title:    "City opens its first solar-powered library",
+		Contents: []string{"The library runs entirely on rooftop panels and opens to the public on Monday."},
+	}, "en")
+}
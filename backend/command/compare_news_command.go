@@ -0,0 +1,143 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// maxCompareInputLength is the maximum length of each article sent to the model.
+const maxCompareInputLength = 4000
+
+// CompareNewsCommand represents a command to critique several news covering the same event side by side.
+type CompareNewsCommand struct {
+	newsIds  []uint
+	language string
+
+	newsSvc         service.NewsService
+	analysisSvc     service.NewsAnalysisService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewCompareNewsCommand(
+	newsIds []uint,
+	language string,
+	newsSvc service.NewsService,
+	analysisSvc service.NewsAnalysisService,
+	systemConfigSvc service.SystemConfigService,
+) *CompareNewsCommand {
+	return &CompareNewsCommand{
+		newsIds:         newsIds,
+		language:        language,
+		newsSvc:         newsSvc,
+		analysisSvc:     analysisSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *CompareNewsCommand) Execute(ctx context.Context) (*entity.NewsAnalysis, error) {
+	newsIds := slices.Compact(slices.Sorted(slices.Values(c.newsIds)))
+
+	if len(newsIds) < valueobject.MinComparedNews || len(newsIds) > valueobject.MaxComparedNews ||
+		slices.Contains(newsIds, 0) {
+		return nil, errorx.ParamsError
+	}
+
+	language, err := summaryLanguage(ctx, c.language, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	textAi, err := loadTextAiConfig(ctx, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	news := make([]*entity.NewsDetail, 0, len(newsIds))
+
+	for _, id := range newsIds {
+		item, err := c.newsSvc.GetNewsDetail(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(item.Contents) == 0 {
+			return nil, errorx.ScrapeNewsFailed
+		}
+
+		news = append(news, item)
+	}
+
+	ctx = labelUsage(ctx, valueobject.AiFeatureCompare, 0)
+
+	chatModel, err := openai.NewJSONChatModel(ctx, textAi)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(valueobject.BuildComparativeCritiquePrompt(language)),
+		schema.UserMessage(buildComparedArticles(news)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	critique, err := valueobject.ParseComparativeCritique(resp.Content, newsIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// the source names are taken from the library rather than the model
+	sources := make(map[uint]string, len(news))
+
+	for _, item := range news {
+		sources[item.Id] = item.Source
+	}
+
+	for _, item := range critique.Sources {
+		item.Source = sources[item.NewsId]
+	}
+
+	title := critique.Event
+	if title == "" {
+		title = news[0].Title
+	}
+
+	analysis := entity.NewComparativeCritique(title, language, newsIds, critique)
+
+	if err := c.analysisSvc.CreateAnalysis(ctx, analysis); err != nil {
+		return nil, err
+	}
+
+	return analysis, nil
+}
+
+// buildComparedArticles builds the model input of the compared articles.
+func buildComparedArticles(news []*entity.NewsDetail) string {
+	var builder strings.Builder
+
+	for idx, item := range news {
+		if idx > 0 {
+			builder.WriteString("\n\n")
+		}
+
+		fmt.Fprintf(&builder, "[Article id: %d, source: %s, published: %s]\n", item.Id, item.Source,
+			item.PublishedAt.Format(time.DateOnly))
+		builder.WriteString(textx.Truncate(item.BuildText(), maxCompareInputLength))
+	}
+
+	return builder.String()
+}
@@ -33,10 +33,12 @@ type CrawlingNewsCommand struct {
 	crawlingSvc     service.CrawlingService
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
+	entitySvc       service.EntityService
 }
 
 func NewCrawlingNewsCommand(ctx context.Context, startTime string, sources []string, topics []string,
 	crawlingSvc service.CrawlingService, newsSvc service.NewsService, systemConfigSvc service.SystemConfigService,
+	entitySvc service.EntityService,
 ) *CrawlingNewsCommand {
 	cmd := &CrawlingNewsCommand{
 		ctx:             ctx,
@@ -45,6 +47,7 @@ func NewCrawlingNewsCommand(ctx context.Context, startTime string, sources []str
 		crawlingSvc:     crawlingSvc,
 		newsSvc:         newsSvc,
 		systemConfigSvc: systemConfigSvc,
+		entitySvc:       entitySvc,
 	}
 
 	if startTime != "" {
@@ -128,21 +131,11 @@ func (c *CrawlingNewsCommand) getNewsTopics(ctx context.Context) error {
 		return nil
 	}
 
-	topicConfig, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.NewsTopicKey.String())
+	newsTopics, err := c.systemConfigSvc.GetNewsTopics(ctx)
 	if err != nil {
 		return err
 	}
 
-	if topicConfig.Id == 0 {
-		return nil
-	}
-
-	var newsTopics []string
-
-	if err := topicConfig.UnmarshalValue(&newsTopics); err != nil {
-		return errorx.InternalError.SetErr(errors.New("invalid news topic config"))
-	}
-
 	c.topics = newsTopics
 
 	return nil
@@ -187,7 +180,7 @@ func (c *CrawlingNewsCommand) crawlingHandle(record *entity.CrawlingRecord) {
 
 			record.Quantity += newsQuantity
 
-			if time.Since(startTime) > maxWorkTime || idx == len(record.Config.Sources) {
+			if time.Since(startTime) > maxWorkTime || idx == len(sources)-1 {
 				record.CrawlingCompleted()
 			}
 
@@ -196,16 +189,55 @@ func (c *CrawlingNewsCommand) crawlingHandle(record *entity.CrawlingRecord) {
 
 				return
 			}
+		}
 
-			// check crawling record status, if not processing, interrupt handling
-			if !record.Status.IsProcessing() {
-				return
-			}
+		// check crawling record status, if not processing, interrupt handling
+		if !record.Status.IsProcessing() {
+			break
+		}
+	}
+
+	// the last sources may fail without completing the record
+	if record.Status.IsProcessing() {
+		current, err := c.crawlingSvc.GetCrawlingRecord(c.ctx, record.Id)
+		if err != nil {
+			logx.WithContext(c.ctx).Error("GetCrawlingRecord:", err)
+
+			return
+		}
+
+		// the record may be paused or stopped meanwhile, leave it as it is
+		if !current.Status.IsProcessing() {
+			return
+		}
+
+		record = current
+		record.CrawlingCompleted()
+
+		if err := c.crawlingSvc.UpdateCrawlingRecord(c.ctx, record); err != nil {
+			logx.WithContext(c.ctx).Error("UpdateCrawlingRecord", err)
+
+			return
 		}
 	}
 
+	if !record.Status.IsCompleted() {
+		return
+	}
+
 	logx.WithContext(c.ctx).Info("crawlingHandle", fmt.Sprintf("crawling news website completed, quantity: %d",
 		record.Quantity))
+
+	c.afterCrawling()
+}
+
+// afterCrawling starts the background jobs over the crawled news
+func (c *CrawlingNewsCommand) afterCrawling() {
+	cmd := NewExtractEntitiesCommand(c.ctx, true, c.crawlingSvc, c.entitySvc, c.systemConfigSvc)
+
+	if err := cmd.Execute(c.ctx); err != nil {
+		logx.WithContext(c.ctx).Error("afterCrawling.ExtractEntities", err)
+	}
 }
 
 // crawlingNews crawling news
@@ -27,6 +27,7 @@ type CreateAudioCommand struct {
 
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	jobSvc          service.PodcastJobService
 }
 
 func NewCreateAudioCommand(
@@ -34,12 +35,14 @@ func NewCreateAudioCommand(
 	stageId uint,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService,
 ) *CreateAudioCommand {
 	return &CreateAudioCommand{
 		ctx:             ctx,
 		stageId:         stageId,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
@@ -79,34 +82,36 @@ func (c *CreateAudioCommand) Execute(ctx context.Context) error {
 		return err
 	}
 
-	go c.textToSpeech(task, ttsAi)
-
-	return nil
+	// the processing text to speech stage is executed by the job worker
+	return c.jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo))
 }
 
-func (c *CreateAudioCommand) textToSpeech(task *entity.PodcastTask, ttsAi *ttsai.Config) error {
-	var (
-		stage       = task.GetStage(valueobject.TaskStageTextToSpeech)
-		scriptState = task.GetStage(valueobject.TaskStageScripted)
-	)
+// textToSpeech generates the audio of the scripts missing it, the generation is aborted once ctx is cancelled.
+func (c *CreateAudioCommand) textToSpeech(ctx context.Context, task *entity.PodcastTask, stage *valueobject.TaskStage,
+	ttsAi *ttsai.Config) error {
+	scriptState := task.GetStage(valueobject.TaskStageScripted)
 
 	audioPath, err := pathx.GetAppBasePath(config.AppName, pathx.AudioDir, task.BatchNo)
 	if err != nil {
 		return err
 	}
 
-	err = c.generateAudio(audioPath, stage, scriptState, ttsAi)
+	err = c.generateAudio(labelStageUsage(ctx, task, stage), audioPath, stage, scriptState, ttsAi)
 	if err == nil {
 		err = c.mergeAudio(audioPath, stage, scriptState)
 	}
 
-	if err != nil {
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// cancelled by the user, the generated segments are kept with the scripts
+		task.Cancel()
+	case err != nil:
 		stage.Fail(err.Error())
 		task.Result = valueobject.TaskResultFailed
 		logx.WithContext(c.ctx).Error("GeneratePodcastCommand", err)
 	}
 
-	if !task.Result.IsFailed() {
+	if err == nil && !task.Result.IsFailed() {
 		task.Result = valueobject.TaskResultCompleted
 		stage.SetStatus(valueobject.StageStatusCompleted)
 	}
@@ -118,7 +123,7 @@ func (c *CreateAudioCommand) textToSpeech(task *entity.PodcastTask, ttsAi *ttsai
 	return err
 }
 
-func (c *CreateAudioCommand) generateAudio(audioPath string, ttsStage *valueobject.TaskStage,
+func (c *CreateAudioCommand) generateAudio(ctx context.Context, audioPath string, ttsStage *valueobject.TaskStage,
 	scriptState *valueobject.TaskStage, ttsAi *ttsai.Config) error {
 	ttsClient, err := ttsai.NewDoubaoTTSClient(ttsAi)
 	if err != nil {
@@ -130,6 +135,10 @@ func (c *CreateAudioCommand) generateAudio(audioPath string, ttsStage *valueobje
 			continue
 		}
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		_, err := c.taskSvc.GetTaskStage(c.ctx, ttsStage.Id)
 		if err != nil {
 			return err
@@ -141,7 +150,7 @@ func (c *CreateAudioCommand) generateAudio(audioPath string, ttsStage *valueobje
 
 		script.Format = audio.WAV
 
-		resp, err := ttsClient.TextToSpeech(c.ctx, script)
+		resp, err := ttsClient.TextToSpeech(ctx, script)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestCreateAudioCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupReplayTest(t)
+		taskSvc         = service.NewPodcastTaskService()
+		jobSvc          = service.NewPodcastJobService()
+		ctx             = context.Background()
+		task            = newTestTask()
+		stage           = valueobject.NewTaskStage(valueobject.TaskStageScripted, "", nil)
+	)
+
+	stage.Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}, Scripts: []*ttsai.TtsScript{
+		{Text: "Our city has a new library.", Speaker: testVoice.Id, Emotion: "news", Speed: 1, Volume: 80},
+		{Text: "It runs entirely on solar power.", Speaker: testVoice.Id, Emotion: "news", Speed: 1, Volume: 80},
+	}}
+	stage.SetOutput("scripts")
+	task.AddNewStage(stage)
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCreateAudioCommand(ctx, task.Stages[0].Id, systemConfigSvc, taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	task = runTestJob(t, systemConfigSvc, taskSvc, jobSvc)
+	audio := task.GetStage(valueobject.TaskStageTextToSpeech)
+
+	if !task.Result.IsCompleted() || audio.Status != valueobject.StageStatusCompleted || audio.Audio.Url == "" {
+		t.Fatalf("unexpected task result %s, stage %s: %s", task.Result, audio.Status, audio.Reason)
+	}
+
+	for _, script := range task.GetPodcastScript() {
+		if script.AudioUrl == "" {
+			t.Fatalf("no audio of the script %q", script.Text)
+		}
+	}
+}
+
+// runTestJob runs the queued job like the job worker, returns its task
+func runTestJob(t *testing.T, systemConfigSvc service.SystemConfigService, taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService) *entity.PodcastTask {
+	t.Helper()
+
+	ctx := context.Background()
+
+	job, err := jobSvc.ClaimJob(ctx, "test")
+	if err != nil || job == nil {
+		t.Fatalf("no job claimed: %v", err)
+	}
+
+	err = NewRunJobCommand(job, systemConfigSvc, taskSvc, service.NewPromptService(), jobSvc).Execute(ctx)
+	job.Finish(err)
+
+	if err := jobSvc.FinishJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := taskSvc.GetTaskByBatchNo(ctx, job.BatchNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return task
+}
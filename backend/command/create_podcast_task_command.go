@@ -10,7 +10,6 @@ import (
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
-	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/pkg/openai"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
 	"github.com/mjiee/world-news/backend/service"
@@ -26,6 +25,9 @@ type CreateTaskCommand struct {
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
 }
 
 func NewCreateTaskCommand(
@@ -36,6 +38,9 @@ func NewCreateTaskCommand(
 	newsSvc service.NewsService,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	usageSvc service.UsageService,
+	jobSvc service.PodcastJobService,
 ) *CreateTaskCommand {
 	return &CreateTaskCommand{
 		ctx:             ctx,
@@ -45,6 +50,9 @@ func NewCreateTaskCommand(
 		newsSvc:         newsSvc,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		usageSvc:        usageSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
@@ -64,13 +72,11 @@ func (c *CreateTaskCommand) Execute(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	// execute task
-	executeCmd := NewExecuteTaskCommand(c.ctx, newTask, c.systemConfigSvc, c.taskSvc)
-	go func() {
-		if err := executeCmd.Execute(executeCmd.ctx); err != nil {
-			logx.WithContext(executeCmd.ctx).Error("CreateTaskCommand", err)
-		}
-	}()
+	// execute task by the job worker
+	job := entity.NewPodcastJob(valueobject.JobKindExecuteTask, newTask.BatchNo)
+	if err := c.jobSvc.EnqueueJob(ctx, job); err != nil {
+		return "", err
+	}
 
 	return newTask.BatchNo, nil
 }
@@ -78,6 +84,10 @@ func (c *CreateTaskCommand) Execute(ctx context.Context) (string, error) {
 // create new task
 func (c *CreateTaskCommand) createTask(ctx context.Context, textAi *openai.Config, ttsAi *ttsai.Config,
 	prompt *valueobject.PodcastScriptPrompt) (*entity.PodcastTask, error) {
+	if err := checkAiBudget(ctx, c.systemConfigSvc, c.usageSvc); err != nil {
+		return nil, err
+	}
+
 	voices := gokit.SliceFilter(ttsAi.Voices, func(v *ttsai.Voice) bool { return slices.Contains(c.voiceIds, v.Id) })
 	if len(ttsAi.Voices) == 0 || len(voices) != len(c.voiceIds) {
 		return nil, errorx.PodcastVoiceNotFound
@@ -112,7 +122,20 @@ func (c *CreateTaskCommand) createTask(ctx context.Context, textAi *openai.Confi
 	// create task
 	newTask := entity.NewPodcastTask(c.news, c.language)
 
-	if err := c.buildTaskState(newTask, textAi, ttsAi, prompt); err != nil {
+	if len(voices) == 0 {
+		voices = ttsAi.Voices[:1]
+	}
+
+	if err := applyPodcastPrompts(ctx, c.promptSvc, prompt, podcastPromptVars(newTask, voices, prompt)); err != nil {
+		return nil, err
+	}
+
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.buildTaskState(newTask, textAi, ttsAi, prompt, routing); err != nil {
 		return nil, err
 	}
 
@@ -123,9 +146,7 @@ func (c *CreateTaskCommand) createTask(ctx context.Context, textAi *openai.Confi
 
 // build task state
 func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *openai.Config, ttsAi *ttsai.Config,
-	prompt *valueobject.PodcastScriptPrompt) error {
-	ai := valueobject.NewTaskAiFromTextAi(textAi)
-
+	prompt *valueobject.PodcastScriptPrompt, routing *valueobject.ModelRouting) error {
 	for _, stage := range valueobject.StagePriority {
 		switch stage {
 		case valueobject.TaskStageApproval:
@@ -133,7 +154,8 @@ func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *ope
 				continue
 			}
 
-			stage := valueobject.NewTaskStage(stage, prompt.BuildApprovalPrompt(task.Language), ai)
+			stage := valueobject.NewTaskStage(stage, prompt.BuildApprovalPrompt(task.Language),
+				routing.TaskAi(stage, textAi))
 			stage.Input = task.News.BuildPrompt()
 
 			task.AddNewStage(stage)
@@ -142,7 +164,7 @@ func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *ope
 				continue
 			}
 
-			stage := valueobject.NewTaskStage(stage, prompt.RewritePrompt, ai)
+			stage := valueobject.NewTaskStage(stage, prompt.RewritePrompt, routing.TaskAi(stage, textAi))
 			if len(task.Stages) == 0 {
 				stage.Input = task.News.BuildPrompt()
 			}
@@ -154,7 +176,8 @@ func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *ope
 			}
 
 			// classify
-			stage := valueobject.NewTaskStage(stage, prompt.BuildClassifyPrompt(task.Language), ai)
+			stage := valueobject.NewTaskStage(stage, prompt.BuildClassifyPrompt(task.Language),
+				routing.TaskAi(stage, textAi))
 			if len(task.Stages) == 0 {
 				stage.Input = task.News.BuildPrompt()
 			}
@@ -162,7 +185,8 @@ func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *ope
 			task.AddNewStage(stage)
 
 			// stylize
-			stylizeStage := valueobject.NewTaskStage(valueobject.TaskStageStylize, "", ai)
+			stylizeStage := valueobject.NewTaskStage(valueobject.TaskStageStylize, "",
+				routing.TaskAi(valueobject.TaskStageStylize, textAi))
 			task.AddNewStage(stylizeStage)
 		case valueobject.TaskStageScripted:
 			if len(c.voiceIds) == 0 {
@@ -177,7 +201,8 @@ func (c *CreateTaskCommand) buildTaskState(task *entity.PodcastTask, textAi *ope
 				return slices.Contains(c.voiceIds, v.Id)
 			})
 
-			stage := valueobject.NewTaskStage(stage, valueobject.BuildScriptPrompt(task.Language, voices), ai)
+			stage := valueobject.NewTaskStage(stage, prompt.BuildScriptPrompt(task.Language, voices),
+				routing.TaskAi(stage, textAi))
 
 			if len(task.Stages) == 0 {
 				stage.Input = task.News.BuildPrompt()
@@ -6,9 +6,9 @@ import (
 
 	"github.com/mjiee/gokit"
 
+	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
-	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
 	"github.com/mjiee/world-news/backend/service"
 )
@@ -21,6 +21,8 @@ type CreateScriptCommand struct {
 
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	jobSvc          service.PodcastJobService
 }
 
 func NewCreateScriptCommand(
@@ -29,6 +31,8 @@ func NewCreateScriptCommand(
 	voiceIds []string,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	jobSvc service.PodcastJobService,
 ) *CreateScriptCommand {
 	return &CreateScriptCommand{
 		ctx:             ctx,
@@ -36,12 +40,14 @@ func NewCreateScriptCommand(
 		voiceIds:        voiceIds,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
 func (c *CreateScriptCommand) Execute(ctx context.Context) error {
 	// config
-	textAi, ttsAi, _, err := c.systemConfigSvc.GetPodcastConfig(ctx)
+	textAi, ttsAi, prompt, err := c.systemConfigSvc.GetPodcastConfig(ctx)
 	if err != nil {
 		return err
 	}
@@ -66,10 +72,19 @@ func (c *CreateScriptCommand) Execute(ctx context.Context) error {
 		voices = []*ttsai.Voice{voices[0]}
 	}
 
+	if err := applyPodcastPrompts(ctx, c.promptSvc, prompt, podcastPromptVars(task, voices, prompt)); err != nil {
+		return err
+	}
+
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return err
+	}
+
 	// new stage
 	var (
-		stage = valueobject.NewTaskStage(valueobject.TaskStageScripted, valueobject.BuildScriptPrompt(task.Language, voices),
-			valueobject.NewTaskAiFromTextAi(textAi))
+		stage = valueobject.NewTaskStage(valueobject.TaskStageScripted, prompt.BuildScriptPrompt(task.Language, voices),
+			routing.TaskAi(valueobject.TaskStageScripted, textAi))
 		stylizeStage = task.GetStageById(c.stageId)
 	)
 
@@ -81,13 +96,6 @@ func (c *CreateScriptCommand) Execute(ctx context.Context) error {
 		return err
 	}
 
-	// execute task
-	executeCmd := NewExecuteTaskCommand(c.ctx, task, c.systemConfigSvc, c.taskSvc)
-	go func() {
-		if err := executeCmd.Execute(executeCmd.ctx); err != nil {
-			logx.WithContext(executeCmd.ctx).Error("CreateScriptCommand", err)
-		}
-	}()
-
-	return nil
+	// execute task by the job worker
+	return c.jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo))
 }
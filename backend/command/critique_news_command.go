@@ -11,22 +11,27 @@ import (
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
 	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
 	"github.com/mjiee/world-news/backend/service"
 )
 
 // CritiqueNewsCommand represents a command for news critique.
 type CritiqueNewsCommand struct {
 	contents        []string
+	stream          *streamx.Stream // emits the critique as it is generated, optional
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
+	promptSvc       service.PromptService
 }
 
-func NewCritiqueNewsCommand(contents []string, newsSvc service.NewsService,
-	systemConfigSvc service.SystemConfigService) *CritiqueNewsCommand {
+func NewCritiqueNewsCommand(contents []string, stream *streamx.Stream, newsSvc service.NewsService,
+	systemConfigSvc service.SystemConfigService, promptSvc service.PromptService) *CritiqueNewsCommand {
 	return &CritiqueNewsCommand{
 		contents:        contents,
+		stream:          stream,
 		newsSvc:         newsSvc,
 		systemConfigSvc: systemConfigSvc,
+		promptSvc:       promptSvc,
 	}
 }
 
@@ -51,25 +56,52 @@ func (c CritiqueNewsCommand) Execute(ctx context.Context) ([]string, error) {
 		return nil, err
 	}
 
-	// get news ceritique prompt
-	critiquePrompt, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.NewsCritiquePromptKey.String())
+	systemPrompt, err := c.systemPrompt(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	critiqueConfig, err := entity.UnmarshalValue[newsCritiquePromptConfig](critiquePrompt, errorx.CritiquePromptNotFound)
+	// news critique
+	data, err := generateText(labelUsage(ctx, valueobject.AiFeatureCritique, 0), textAiConfig, []*schema.Message{
+		schema.SystemMessage(systemPrompt),
+		schema.UserMessage(strings.Join(c.contents, "\n")),
+	}, c.stream)
 	if err != nil {
 		return nil, err
 	}
 
-	// news critique
-	data, err := openai.NewChatModel(ctx, textAiConfig).Generate(ctx, []*schema.Message{
-		schema.SystemMessage(critiqueConfig.SystemPrompt),
-		schema.UserMessage(strings.Join(c.contents, "\n")),
-	})
+	return strings.Split(data, "\n"), nil
+}
+
+// systemPrompt returns the critique prompt of the prompt library, the configured prompt by default.
+func (c CritiqueNewsCommand) systemPrompt(ctx context.Context) (string, error) {
+	language, err := c.systemConfigSvc.GetLanguage(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	vars := &valueobject.PromptVars{Language: language, Contents: c.contents}
+
+	prompt, err := c.promptSvc.GetPrompt(ctx, valueobject.PromptNewsCritique, language)
+	if err == nil {
+		return prompt.Render(vars)
+	}
+
+	if !errors.Is(err, errorx.PromptTemplateNotFound) {
+		return "", err
+	}
+
+	// get news ceritique prompt
+	critiquePrompt, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.NewsCritiquePromptKey.String())
+	if err != nil {
+		return "", err
+	}
+
+	critiqueConfig, err := entity.UnmarshalValue[newsCritiquePromptConfig](critiquePrompt,
+		errorx.CritiquePromptNotFound)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return "", err
 	}
 
-	return strings.Split(data.Content, "\n"), nil
+	return critiqueConfig.SystemPrompt, nil
 }
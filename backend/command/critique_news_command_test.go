@@ -0,0 +1,29 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestCritiqueNewsCommand(t *testing.T) {
+	systemConfigSvc := setupReplayTest(t)
+
+	saveTestConfig(t, systemConfigSvc, valueobject.NewsCritiquePromptKey, &newsCritiquePromptConfig{
+		SystemPrompt: "Point out the strengths and the weaknesses of the news, one per line.",
+	})
+
+	task := newTestTask()
+	cmd := NewCritiqueNewsCommand(task.News.Contents, nil, nil, systemConfigSvc, service.NewPromptService())
+
+	result, err := cmd.Execute(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 2 || result[0] == "" || result[1] == "" {
+		t.Fatalf("unexpected critique %q", result)
+	}
+}
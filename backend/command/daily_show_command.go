@@ -0,0 +1,444 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/pkg/urlx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+const (
+	// maxShowNews is the maximum number of news ranked for a daily show.
+	maxShowNews = 500
+
+	// noShowArticlesReason the failure reason of the show without an approved article
+	noShowArticlesReason = "none of the articles is approved and rewritten"
+)
+
+// DailyShowCommand represents the command to create the daily show, a two hosts podcast of the top news
+// of the latest crawling record. Each article is approved and rewritten by its own task, the job of the show task
+// waits until the jobs of the articles end, then merges the rewritten articles and creates the scripts and the audio.
+type DailyShowCommand struct {
+	ctx       context.Context
+	scheduled bool // started by the scheduler, skipped if not enabled or already created
+
+	config  *valueobject.DailyShowConfig
+	created []string // the batch nos of the tasks created for the show, cancelled if the show fails
+
+	crawlingSvc     service.CrawlingService
+	newsSvc         service.NewsService
+	systemConfigSvc service.SystemConfigService
+	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
+}
+
+func NewDailyShowCommand(
+	ctx context.Context,
+	scheduled bool,
+	crawlingSvc service.CrawlingService,
+	newsSvc service.NewsService,
+	systemConfigSvc service.SystemConfigService,
+	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	usageSvc service.UsageService,
+	jobSvc service.PodcastJobService,
+) *DailyShowCommand {
+	return &DailyShowCommand{
+		ctx:             ctx,
+		scheduled:       scheduled,
+		crawlingSvc:     crawlingSvc,
+		newsSvc:         newsSvc,
+		systemConfigSvc: systemConfigSvc,
+		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		usageSvc:        usageSvc,
+		jobSvc:          jobSvc,
+	}
+}
+
+func (c *DailyShowCommand) Execute(ctx context.Context) (string, error) {
+	config, err := c.systemConfigSvc.GetDailyShowConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.config = config
+
+	date := valueobject.AnalyticsDay(time.Now())
+
+	if c.scheduled {
+		var ok bool
+		if date, ok = config.ScheduledDate(time.Now()); !ok {
+			return "", nil
+		}
+
+		// the scheduled show is created once a day
+		exists, err := c.taskSvc.HasTaskTitle(ctx, config.BuildTitle(date))
+		if err != nil || exists {
+			return "", err
+		}
+	}
+
+	if config.Language == "" {
+		if config.Language, err = c.systemConfigSvc.GetLanguage(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	if err := checkAiBudget(ctx, c.systemConfigSvc, c.usageSvc); err != nil {
+		return "", err
+	}
+
+	// config
+	textAi, ttsAi, prompt, err := c.systemConfigSvc.GetPodcastConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	voices, err := c.voices(ttsAi)
+	if err != nil {
+		return "", err
+	}
+
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	news, err := c.rankNews(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// the show task, merged once the articles are rewritten
+	mergeCmd := NewMergeArticleCommand(ctx, config.Language, config.BuildTitle(date), nil,
+		gokit.SliceMap(voices, func(v *ttsai.Voice) string { return v.Id }), c.systemConfigSvc, c.taskSvc,
+		c.promptSvc, c.usageSvc, c.jobSvc)
+
+	show, err := mergeCmd.buildTask(ctx, textAi, voices, prompt, routing, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.createShow(ctx, show, news, textAi, voices, prompt, routing); err != nil {
+		c.cancelCreated(ctx)
+
+		return "", err
+	}
+
+	return show.BatchNo, nil
+}
+
+// createShow creates the tasks of the articles and the show task, whose job waits until the jobs of the articles end.
+func (c *DailyShowCommand) createShow(ctx context.Context, show *entity.PodcastTask, news []*entity.NewsDetail,
+	textAi *openai.Config, voices []*ttsai.Voice, prompt *valueobject.PodcastScriptPrompt,
+	routing *valueobject.ModelRouting) error {
+	extra := show.GetStage(valueobject.TaskStageMerge).Extra
+
+	for _, item := range news {
+		article, err := c.createArticle(ctx, item, textAi, voices, prompt, routing)
+		if err != nil {
+			return err
+		}
+
+		extra.NewsIds = append(extra.NewsIds, item.Id)
+		extra.StageIds = append(extra.StageIds, article.GetStage(valueobject.TaskStageRewrite).Id)
+	}
+
+	if err := c.taskSvc.SaveTask(ctx, show); err != nil {
+		return err
+	}
+
+	c.created = append(c.created, show.BatchNo)
+
+	// the show job is queued once the jobs of its articles end
+	job := entity.NewPodcastJob(valueobject.JobKindDailyShow, show.BatchNo)
+	job.Status = valueobject.JobStatusWaiting
+
+	return c.jobSvc.EnqueueJob(ctx, job)
+}
+
+// cancelCreated cancels the tasks created for the failed show, so that the articles never run for a missing show.
+func (c *DailyShowCommand) cancelCreated(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+
+	for _, batchNo := range c.created {
+		if err := NewCancelTaskCommand(batchNo, 0, c.taskSvc, c.jobSvc).Execute(ctx); err != nil {
+			logx.WithContext(ctx).Error("DailyShowCommand.cancelCreated", err)
+		}
+	}
+}
+
+// voices returns the voices of the two hosts, the first two voices by default.
+func (c *DailyShowCommand) voices(ttsAi *ttsai.Config) ([]*ttsai.Voice, error) {
+	voices := ttsAi.Voices
+
+	if len(c.config.VoiceIds) > 0 {
+		voices = gokit.SliceFilter(ttsAi.Voices, func(v *ttsai.Voice) bool {
+			return slices.Contains(c.config.VoiceIds, v.Id)
+		})
+	}
+
+	if len(voices) < valueobject.ShowVoices {
+		return nil, errorx.PodcastVoiceNotFound
+	}
+
+	return voices[:valueobject.ShowVoices], nil
+}
+
+// rankNews selects the top ranked news of the latest crawling record, the news with a processing task are skipped.
+func (c *DailyShowCommand) rankNews(ctx context.Context) ([]*entity.NewsDetail, error) {
+	records, _, err := c.crawlingSvc.QueryCrawlingRecords(ctx, valueobject.QueryRecordParams{
+		Status: valueobject.CompletedCrawlingRecord.String(),
+		Page:   &httpx.Pagination{Page: 1, Limit: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errorx.CrawlingRecordNotFound
+	}
+
+	news, err := c.loadNews(ctx, records[0].Id)
+	if err != nil {
+		return nil, err
+	}
+
+	websites, err := c.systemConfigSvc.GetNewsWebsites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]int, len(websites))
+
+	for _, item := range websites {
+		weights[urlx.ExtractSecondLevelDomain(item.Url)] = item.Weight
+	}
+
+	slices.SortStableFunc(news, func(a, b *entity.NewsDetail) int {
+		return cmp.Compare(c.config.ShowScore(weights[b.Source], b.Topic, b.Favorited),
+			c.config.ShowScore(weights[a.Source], a.Topic, a.Favorited))
+	})
+
+	result := make([]*entity.NewsDetail, 0, c.config.Limit)
+
+	for _, item := range news {
+		if len(result) == c.config.Limit {
+			break
+		}
+
+		processing, err := c.taskSvc.HasProcessingTasks(ctx, item.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !processing && len(item.Contents) > 0 {
+			result = append(result, item)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, errorx.NewsNotFound
+	}
+
+	return result, nil
+}
+
+// loadNews loads the news of the crawling record.
+func (c *DailyShowCommand) loadNews(ctx context.Context, recordId uint) ([]*entity.NewsDetail, error) {
+	var (
+		result = make([]*entity.NewsDetail, 0)
+		params = valueobject.NewQueryNewsParams(recordId, &httpx.Pagination{Page: 1, Limit: 100})
+	)
+
+	for len(result) < maxShowNews {
+		news, total, err := c.newsSvc.QueryNews(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, news...)
+
+		if len(news) == 0 || int64(len(result)) >= total {
+			break
+		}
+
+		params.Page.Page++
+	}
+
+	return result[:min(len(result), maxShowNews)], nil
+}
+
+// createArticle creates the task approving and rewriting the article of the show, run by its own job.
+func (c *DailyShowCommand) createArticle(ctx context.Context, news *entity.NewsDetail, textAi *openai.Config,
+	voices []*ttsai.Voice, prompt *valueobject.PodcastScriptPrompt, routing *valueobject.ModelRouting) (
+	*entity.PodcastTask, error) {
+	task := entity.NewPodcastTask(news, c.config.Language)
+
+	if err := applyPodcastPrompts(ctx, c.promptSvc, prompt, podcastPromptVars(task, voices, prompt)); err != nil {
+		return nil, err
+	}
+
+	if prompt.ApprovalPrompt != "" {
+		stage := valueobject.NewTaskStage(valueobject.TaskStageApproval, prompt.BuildApprovalPrompt(task.Language),
+			routing.TaskAi(valueobject.TaskStageApproval, textAi))
+		stage.Input = news.BuildPrompt()
+
+		task.AddNewStage(stage)
+	}
+
+	stage := valueobject.NewTaskStage(valueobject.TaskStageRewrite, c.config.BuildRewritePrompt(prompt),
+		routing.TaskAi(valueobject.TaskStageRewrite, textAi))
+	if len(task.Stages) == 0 {
+		stage.Input = news.BuildPrompt()
+	}
+
+	task.AddNewStage(stage)
+
+	if err := c.taskSvc.SaveTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	c.created = append(c.created, task.BatchNo)
+
+	// execute task by the job worker
+	job := entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo)
+	if err := c.jobSvc.EnqueueJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// mergeShowArticles sets the merge input of the daily show, whose job is queued once the jobs of its articles end.
+// The articles rejected, failed or waiting for review are left out of the show.
+func mergeShowArticles(ctx context.Context, task *entity.PodcastTask, taskSvc service.PodcastTaskService) error {
+	stage := task.GetStage(valueobject.TaskStageMerge)
+	if stage == nil || !stage.Status.IsProcessing() || stage.Input != "" || stage.Extra == nil {
+		return nil
+	}
+
+	var (
+		contents = make([]string, 0)
+		stageIds = make([]uint, 0)
+	)
+
+	for _, id := range stage.Extra.StageIds {
+		article, err := taskSvc.GetTaskByStageId(ctx, id)
+		if errors.Is(err, errorx.PodcastTaskNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if rewrite := article.GetStageById(id); rewrite.Status == valueobject.StageStatusCompleted &&
+			rewrite.Output != "" {
+			contents = append(contents, rewrite.Output)
+			stageIds = append(stageIds, id)
+		}
+	}
+
+	if len(contents) == 0 {
+		stage.Fail(noShowArticlesReason)
+		task.Result = valueobject.TaskResultFailed
+	} else {
+		stage.Input = mergeInput(contents)
+		stage.Extra.StageIds = stageIds
+	}
+
+	return taskSvc.SaveTask(ctx, task)
+}
+
+// QueueDailyShowsCommand represents the command to queue the jobs of the daily shows whose articles are done.
+type QueueDailyShowsCommand struct {
+	taskSvc service.PodcastTaskService
+	jobSvc  service.PodcastJobService
+}
+
+func NewQueueDailyShowsCommand(taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService) *QueueDailyShowsCommand {
+	return &QueueDailyShowsCommand{
+		taskSvc: taskSvc,
+		jobSvc:  jobSvc,
+	}
+}
+
+// Execute queues the waiting show jobs once none of the articles of the show has an active job.
+func (c *QueueDailyShowsCommand) Execute(ctx context.Context) error {
+	jobs, err := c.jobSvc.GetWaitingJobs(ctx, valueobject.JobKindDailyShow)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		done, err := c.articlesDone(ctx, job.BatchNo)
+		if err != nil {
+			return err
+		}
+
+		if !done {
+			continue
+		}
+
+		if err := c.jobSvc.QueueWaitingJob(ctx, job); err != nil {
+			return err
+		}
+
+		logx.WithContext(ctx).Info("QueueDailyShowsCommand", job)
+	}
+
+	return nil
+}
+
+// articlesDone checks whether the jobs of the articles of the show ended, the deleted articles are skipped.
+func (c *QueueDailyShowsCommand) articlesDone(ctx context.Context, batchNo string) (bool, error) {
+	show, err := c.taskSvc.GetTaskByBatchNo(ctx, batchNo)
+	if errors.Is(err, errorx.PodcastTaskNotFound) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	merge := show.GetStage(valueobject.TaskStageMerge)
+	if merge == nil || merge.Extra == nil {
+		return true, nil
+	}
+
+	for _, id := range merge.Extra.StageIds {
+		article, err := c.taskSvc.GetTaskByStageId(ctx, id)
+		if errors.Is(err, errorx.PodcastTaskNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		active, err := c.jobSvc.HasActiveJob(ctx, article.BatchNo)
+		if err != nil || active {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
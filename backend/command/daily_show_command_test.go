@@ -0,0 +1,197 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestDailyShowCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		crawlingSvc     = service.NewCrawlingService(nil)
+		newsSvc         = service.NewNewsService(nil)
+		taskSvc         = service.NewPodcastTaskService()
+		jobSvc          = service.NewPodcastJobService()
+		ctx             = context.Background()
+	)
+
+	news := newTestShowNews(t, systemConfigSvc, crawlingSvc, newsSvc)
+
+	batchNo, err := NewDailyShowCommand(ctx, false, crawlingSvc, newsSvc, systemConfigSvc, taskSvc,
+		service.NewPromptService(), service.NewUsageService(), jobSvc).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	show, err := taskSvc.GetTaskByBatchNo(ctx, batchNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merge := show.GetStage(valueobject.TaskStageMerge)
+	if len(merge.Extra.StageIds) != 2 ||
+		len(show.GetStage(valueobject.TaskStageScripted).Audio.Voices) != valueobject.ShowVoices {
+		t.Fatalf("unexpected show %+v", merge.Extra)
+	}
+
+	// the articles end, one rewritten and one rejected, the show job is not queued before
+	for idx, stageId := range merge.Extra.StageIds {
+		if err := NewQueueDailyShowsCommand(taskSvc, jobSvc).Execute(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		article, err := taskSvc.GetTaskByStageId(ctx, stageId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{news[2].Title, news[1].Title}[idx]; article.Title != want {
+			t.Fatalf("unexpected article %s, want %s", article.Title, want)
+		}
+
+		if idx == 0 {
+			article.GetStageById(stageId).SetOutput("A library powered by the sun.")
+		} else {
+			article.GetStageById(stageId).Fail("rejected")
+		}
+
+		if err := taskSvc.SaveTask(ctx, article); err != nil {
+			t.Fatal(err)
+		}
+
+		job, err := jobSvc.ClaimJob(ctx, "test")
+		if err != nil || job.BatchNo != article.BatchNo {
+			t.Fatalf("unexpected job %+v, %v", job, err)
+		}
+
+		job.Finish(nil)
+
+		if err := jobSvc.FinishJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the show job waits for the articles, then it is queued
+	if active, err := jobSvc.HasActiveJob(ctx, batchNo); err != nil || !active {
+		t.Fatalf("the show job is not waiting: %v", err)
+	}
+
+	if err := NewQueueDailyShowsCommand(taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := jobSvc.ClaimJob(ctx, "test")
+	if err != nil || job == nil || job.BatchNo != batchNo {
+		t.Fatalf("unexpected show job %+v, %v", job, err)
+	}
+
+	if err := mergeShowArticles(ctx, show, taskSvc); err != nil {
+		t.Fatal(err)
+	}
+
+	if merge = show.GetStage(valueobject.TaskStageMerge); !strings.Contains(merge.Input, "powered by the sun") ||
+		len(merge.Extra.StageIds) != 1 || show.Result != "" {
+		t.Fatalf("unexpected merge input %q", merge.Input)
+	}
+}
+
+// TestDailyShowCommandFailed testing the tasks created for the failed show are cancelled
+func TestDailyShowCommandFailed(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		crawlingSvc     = service.NewCrawlingService(nil)
+		newsSvc         = service.NewNewsService(nil)
+		taskSvc         = service.NewPodcastTaskService()
+		jobSvc          = &failedShowJobService{PodcastJobService: service.NewPodcastJobService()}
+		ctx             = context.Background()
+	)
+
+	newTestShowNews(t, systemConfigSvc, crawlingSvc, newsSvc)
+
+	_, err := NewDailyShowCommand(ctx, false, crawlingSvc, newsSvc, systemConfigSvc, taskSvc,
+		service.NewPromptService(), service.NewUsageService(), jobSvc).Execute(ctx)
+	if !errors.Is(err, errEnqueueShow) {
+		t.Fatalf("expected the show job not enqueued, got %v", err)
+	}
+
+	repo := repository.Q.PodcastTask
+
+	total, err := repo.WithContext(ctx).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processing, err := repo.WithContext(ctx).Where(
+		repo.Status.Eq(string(valueobject.StageStatusProcessing))).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stages of the show and the articles are cancelled, the jobs of the articles never run
+	if total == 0 || processing != 0 {
+		t.Fatalf("unexpected stages %d, processing %d", total, processing)
+	}
+
+	if job, err := jobSvc.ClaimJob(ctx, "test"); err != nil || job != nil {
+		t.Fatalf("unexpected job %+v, %v", job, err)
+	}
+}
+
+var errEnqueueShow = errors.New("failed to enqueue the show job")
+
+// failedShowJobService fails to enqueue the job of the daily show
+type failedShowJobService struct {
+	service.PodcastJobService
+}
+
+func (s *failedShowJobService) EnqueueJob(ctx context.Context, job *entity.PodcastJob) error {
+	if job.Kind == valueobject.JobKindDailyShow {
+		return errEnqueueShow
+	}
+
+	return s.PodcastJobService.EnqueueJob(ctx, job)
+}
+
+// newTestShowNews saves the daily show config and the news of the latest crawling record, ranked by the preferred
+// topic and the favorite
+func newTestShowNews(t *testing.T, systemConfigSvc service.SystemConfigService, crawlingSvc service.CrawlingService,
+	newsSvc service.NewsService) []*entity.NewsDetail {
+	t.Helper()
+
+	ctx := context.Background()
+
+	saveTestConfig(t, systemConfigSvc, valueobject.TextToSpeechAIKey, &ttsai.Config{
+		Platform: "doubao",
+		Voices:   []*ttsai.Voice{testVoice, {Id: "zh_female_meilinvyou_moon_bigtts", Name: "Meili"}},
+	})
+	saveTestConfig(t, systemConfigSvc, valueobject.DailyShowKey, &valueobject.DailyShowConfig{Limit: 2,
+		Topics: []string{"science"}})
+
+	record := entity.NewCrawlingRecord(valueobject.CrawlingNews, nil)
+	record.Status = valueobject.CompletedCrawlingRecord
+
+	if err := crawlingSvc.CreateCrawlingRecord(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+
+	news := []*entity.NewsDetail{
+		{RecordId: record.Id, Title: "Local team wins the cup", Topic: "sports", Contents: []string{"The final."}},
+		{RecordId: record.Id, Title: "Telescope finds a new moon", Topic: "science", Contents: []string{"Orbit."}},
+		{RecordId: record.Id, Title: "City opens a library", Topic: "city", Contents: []string{"Solar."},
+			Favorited: true},
+	}
+
+	if err := newsSvc.CreateNews(ctx, news...); err != nil {
+		t.Fatal(err)
+	}
+
+	return news
+}
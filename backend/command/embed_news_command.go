@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+const (
+	// embeddingBatchSize is the number of news embedded in one batch.
+	embeddingBatchSize = 32
+
+	// maxEmbeddingInputLength is the maximum length of the article sent to the embedding model.
+	maxEmbeddingInputLength = 4000
+)
+
+// EmbedNewsCommand represents a command to backfill the embeddings of the news in the background.
+// The job is resumable, each run only embeds the news without an embedding of the configured model.
+type EmbedNewsCommand struct {
+	ctx  context.Context
+	auto bool // started by the scheduler, skipped if the embedding ai is not configured
+
+	config *openai.EmbeddingConfig
+
+	crawlingSvc     service.CrawlingService
+	embeddingSvc    service.EmbeddingService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewEmbedNewsCommand(
+	ctx context.Context,
+	auto bool,
+	crawlingSvc service.CrawlingService,
+	embeddingSvc service.EmbeddingService,
+	systemConfigSvc service.SystemConfigService,
+) *EmbedNewsCommand {
+	return &EmbedNewsCommand{
+		ctx:             ctx,
+		auto:            auto,
+		crawlingSvc:     crawlingSvc,
+		embeddingSvc:    embeddingSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *EmbedNewsCommand) Execute(ctx context.Context) error {
+	config, err := loadEmbeddingConfig(ctx, c.systemConfigSvc)
+	if c.auto && errors.Is(err, errorx.EmbeddingConfigNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.config = config
+
+	hasProcessing, err := c.crawlingSvc.HasProcessingRecord(ctx, valueobject.EmbeddingNews)
+	if err != nil {
+		return err
+	}
+
+	if hasProcessing {
+		if c.auto {
+			return nil
+		}
+
+		return errorx.HasProcessingTasks
+	}
+
+	total, err := c.embeddingSvc.CountPendingNews(ctx, c.config.Model)
+	if err != nil || total == 0 {
+		return err
+	}
+
+	// create the record to track the progress
+	record := entity.NewCrawlingRecord(valueobject.EmbeddingNews, &valueobject.CrawlingRecordConfig{Total: total})
+
+	if err := c.crawlingSvc.CreateCrawlingRecord(ctx, record); err != nil {
+		return err
+	}
+
+	go c.embedHandle(record)
+
+	return nil
+}
+
+// loadEmbeddingConfig loads the embedding ai config.
+func loadEmbeddingConfig(ctx context.Context, systemConfigSvc service.SystemConfigService) (
+	*openai.EmbeddingConfig, error) {
+	config, err := systemConfigSvc.GetSystemConfig(ctx, valueobject.EmbeddingAIKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.UnmarshalValue[openai.EmbeddingConfig](config, errorx.EmbeddingConfigNotFound)
+}
+
+// embedHandle embeds the pending news batch by batch.
+func (c *EmbedNewsCommand) embedHandle(record *entity.CrawlingRecord) {
+	var (
+		embedder = openai.NewEmbedder(c.config)
+		afterId  uint
+	)
+
+	for {
+		news, err := c.embeddingSvc.QueryPendingNews(c.ctx, c.config.Model, afterId, embeddingBatchSize)
+		if err != nil {
+			logx.WithContext(c.ctx).Error("embedHandle.QueryPendingNews", err)
+
+			record.CrawlingFailed()
+
+			break
+		}
+
+		if len(news) == 0 {
+			record.CrawlingCompleted()
+
+			break
+		}
+
+		afterId = news[len(news)-1].Id
+
+		if err := c.embedNews(embedder, news); err != nil {
+			logx.WithContext(c.ctx).Error("embedHandle.embedNews", err)
+
+			record.CrawlingFailed()
+
+			break
+		}
+
+		record.Quantity += int64(len(news))
+
+		if !updateJobRecord(c.ctx, c.crawlingSvc, record) {
+			return
+		}
+
+		if c.ctx.Err() != nil {
+			record.CrawlingPaused()
+
+			break
+		}
+	}
+
+	if err := c.crawlingSvc.UpdateCrawlingRecord(c.ctx, record); err != nil {
+		logx.WithContext(c.ctx).Error("embedHandle.UpdateCrawlingRecord", err)
+	}
+
+	logx.WithContext(c.ctx).Info("embedHandle", fmt.Sprintf("news embedding %s, quantity: %d",
+		record.Status, record.Quantity))
+}
+
+// embedNews embeds and saves a batch of news.
+func (c *EmbedNewsCommand) embedNews(embedder *openai.Embedder, news []*entity.NewsDetail) error {
+	texts := gokit.SliceMap(news, func(item *entity.NewsDetail) string {
+		return textx.Truncate(item.BuildText(), maxEmbeddingInputLength)
+	})
+
+	vectors, err := embedder.EmbedStrings(labelUsage(c.ctx, valueobject.AiFeatureEmbedding, 0), texts)
+	if err != nil {
+		return err
+	}
+
+	if len(vectors) != len(news) {
+		return errors.Errorf("embedding count mismatch, expected %d, got %d", len(news), len(vectors))
+	}
+
+	embeddings := make([]*entity.NewsEmbedding, 0, len(news))
+
+	for i, item := range news {
+		embeddings = append(embeddings, entity.NewNewsEmbedding(item.Id, c.config.Model, vectors[i]))
+	}
+
+	return c.embeddingSvc.SaveEmbeddings(c.ctx, embeddings...)
+}
@@ -2,16 +2,18 @@ package command
 
 import (
 	"context"
-	"io"
-	"strings"
 
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
-	"github.com/pkg/errors"
 
+	"github.com/mjiee/world-news/backend/agent"
+	"github.com/mjiee/world-news/backend/agent/stage"
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
 	"github.com/mjiee/world-news/backend/service"
 )
 
@@ -21,6 +23,7 @@ type ExecuteTaskCommand struct {
 	task            *entity.PodcastTask
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
 }
 
 func NewExecuteTaskCommand(
@@ -28,12 +31,14 @@ func NewExecuteTaskCommand(
 	task *entity.PodcastTask,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
 ) *ExecuteTaskCommand {
 	return &ExecuteTaskCommand{
 		ctx:             ctx,
 		task:            task,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
 	}
 }
 
@@ -44,8 +49,22 @@ func (c *ExecuteTaskCommand) Execute(ctx context.Context) error {
 		return err
 	}
 
+	if err := applyPodcastPrompts(ctx, c.promptSvc, prompt, podcastPromptVars(c.task, nil, prompt)); err != nil {
+		return err
+	}
+
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return err
+	}
+
+	review, err := c.systemConfigSvc.GetPodcastReview(ctx)
+	if err != nil {
+		return err
+	}
+
 	// execute task
-	err = c.executeTaskState(ctx, textAi, prompt)
+	err = c.executeTaskState(ctx, textAi, prompt, routing, review)
 	if err != nil {
 		logx.WithContext(c.ctx).Error("ExecuteTaskCommand.executeTaskState", err)
 	}
@@ -58,90 +77,46 @@ func (c *ExecuteTaskCommand) Execute(ctx context.Context) error {
 }
 
 // execute task state
-func (c *ExecuteTaskCommand) executeTaskState(ctx context.Context, textAi *openai.Config, prompt *valueobject.PodcastScriptPrompt) error {
-	var (
-		messages = []*schema.Message{
-			schema.SystemMessage(prompt.BuildSystemPrompt(c.task.Language)),
-		}
-		stylePrompt *valueobject.StylePrompt
-	)
-
-	for _, stage := range c.task.Stages {
-		if !stage.Status.IsProcessing() {
-			continue
-		}
-
-		userMsg := stage.BuildPrompt()
-
-		if stage.Stage == valueobject.TaskStageStylize && stage.Prompt == "" {
-			if stylePrompt == nil {
-				stage.Fail("failed to classify news")
-				c.task.Result = valueobject.TaskResultFailed
-
-				return nil
-			}
-
-			stage.Prompt = stylePrompt.Prompt
-			userMsg = stage.BuildPrompt()
-		}
-
-		messages = append(messages, schema.UserMessage(userMsg))
-
-		stream, err := openai.NewChatModel(ctx, textAi).Stream(ctx, messages)
-		if err != nil {
-			stage.Fail(err.Error())
-			c.task.Result = valueobject.TaskResultFailed
-			return errors.WithStack(err)
-		}
-		defer stream.Close()
-
-		var fullContent strings.Builder
-		for {
-			chunk, err := stream.Recv()
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			if err != nil {
-				stage.Fail(err.Error())
-				c.task.Result = valueobject.TaskResultFailed
-				return errors.WithStack(err)
-			}
-			fullContent.WriteString(chunk.Content)
-		}
-
-		stage.SetOutput(fullContent.String())
-		messages = append(messages, schema.AssistantMessage(stage.Output, nil))
-
-		switch stage.Stage {
-		case valueobject.TaskStageApproval:
-			stage.SetStatus(prompt.VerifyApprovalResult(stage.Output))
-		case valueobject.TaskStageClassify:
-			stylePrompt = prompt.GetStylePrompt(stage.Output)
-			if stylePrompt == nil {
-				stage.Fail("failed to classify news")
-			} else {
-				stage.SetStatus(valueobject.StageStatusCompleted)
-			}
-		case valueobject.TaskStageScripted:
-			logx.WithContext(ctx).Info("TaskStageScripted", stage.Output)
-			scripts := prompt.ExtractScripts(stage.Output)
-			if scripts == nil {
-				stage.Fail("failed to extract scripts")
-				stage.SetOutput(stage.Output)
-			} else {
-				stage.Audio.Scripts = scripts
-				stage.SetStatus(valueobject.StageStatusCompleted)
-			}
-		default:
-			stage.SetStatus(valueobject.StageStatusCompleted)
-		}
-
-		if stage.Status == valueobject.StageStatusFailed {
-			c.task.Result = valueobject.TaskResultFailed
-
-			return nil
-		}
+func (c *ExecuteTaskCommand) executeTaskState(ctx context.Context, textAi *openai.Config,
+	prompt *valueobject.PodcastScriptPrompt, routing *valueobject.ModelRouting,
+	review *valueobject.PodcastReview) error {
+	generate := func(ctx context.Context, taskStage *valueobject.TaskStage, messages []*schema.Message,
+		opts ...model.Option) (string, error) {
+		// the stage output is streamed with the batch no, which also cancels the generation
+		stream := streamx.NewStream(c.task.BatchNo, streamx.Publish).WithStage(string(taskStage.Stage))
+
+		// the stage runs with the model profile recorded at its creation
+		stageAi := routing.TextAi(taskStage.TaskAi, textAi)
+
+		return generateText(labelStageUsage(ctx, c.task, taskStage), stageAi, messages, stream, opts...)
+	}
+
+	nodes := append(agent.TextStageNodes(generate),
+		&agent.StageNode{Name: valueobject.TaskStageTextToSpeech, Handler: c.textToSpeech})
+
+	graph, err := agent.CreateNewsToPodcastGraph(ctx, prompt, nodes...)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	state := stage.NewStage(c.task, prompt)
+	state.ReviewThreshold = review.ConfidenceThreshold
+
+	_, err = graph.Invoke(ctx, state, compose.WithCallbacks(agent.PersistStageCallback(c.taskSvc.SaveTask)))
+
+	return err
+}
+
+// textToSpeech resumes the processing text to speech stage.
+func (c *ExecuteTaskCommand) textToSpeech(ctx context.Context, state *stage.Stage,
+	taskStage *valueobject.TaskStage) error {
+	_, ttsAi, _, err := c.systemConfigSvc.GetPodcastConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the stage is executed in place, no job is enqueued
+	cmd := NewCreateAudioCommand(c.ctx, 0, c.systemConfigSvc, c.taskSvc, nil)
+
+	return cmd.textToSpeech(ctx, state.Task, taskStage, ttsAi)
 }
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestExecuteTaskCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupReplayTest(t)
+		taskSvc         = service.NewPodcastTaskService()
+		ctx             = context.Background()
+		task            = newTestTask()
+	)
+
+	textAi, ttsAi, prompt, err := systemConfigSvc.GetPodcastConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approval := valueobject.NewTaskStage(valueobject.TaskStageApproval, prompt.BuildApprovalPrompt(task.Language),
+		valueobject.NewTaskAiFromTextAi(textAi))
+	approval.Input = task.News.BuildPrompt()
+
+	scripted := valueobject.NewTaskStage(valueobject.TaskStageScripted,
+		prompt.BuildScriptPrompt(task.Language, ttsAi.Voices), valueobject.NewTaskAiFromTextAi(textAi))
+	scripted.Audio = &valueobject.PodcastAudio{Voices: ttsAi.Voices}
+
+	audio := valueobject.NewTaskStage(valueobject.TaskStageTextToSpeech, "", valueobject.NewTaskAiFromTtsAi(ttsAi))
+	audio.Audio = &valueobject.PodcastAudio{Voices: ttsAi.Voices}
+
+	task.AddNewStage(approval)
+	task.AddNewStage(scripted)
+	task.AddNewStage(audio)
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewExecuteTaskCommand(ctx, task, systemConfigSvc, taskSvc, service.NewPromptService())
+	if err := cmd.Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err = taskSvc.GetTaskByBatchNo(ctx, task.BatchNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !task.Result.IsCompleted() {
+		t.Fatalf("unexpected task result %s", task.Result)
+	}
+
+	if approval = task.GetStage(valueobject.TaskStageApproval); approval.Approval == nil ||
+		approval.Approval.Verdict != valueobject.ApprovalVerdictApproved {
+		t.Fatalf("unexpected approval %+v", approval.Approval)
+	}
+
+	if scripts := task.GetPodcastScript(); len(scripts) != 2 || scripts[0].AudioUrl == "" {
+		t.Fatalf("unexpected scripts %+v", scripts)
+	}
+
+	if audio = task.GetStage(valueobject.TaskStageTextToSpeech); audio.Audio.Url == "" {
+		t.Fatalf("no podcast audio, stage %s: %s", audio.Status, audio.Reason)
+	}
+}
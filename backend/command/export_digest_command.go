@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/export"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// ExportDigestCommand represents a command to export the news digest to a file.
+type ExportDigestCommand struct {
+	id     uint
+	format export.Format
+
+	digestSvc service.DigestService
+}
+
+func NewExportDigestCommand(id uint, format string, digestSvc service.DigestService) *ExportDigestCommand {
+	return &ExportDigestCommand{
+		id:        id,
+		format:    export.Format(format),
+		digestSvc: digestSvc,
+	}
+}
+
+func (c *ExportDigestCommand) Execute(ctx context.Context) (*export.File, error) {
+	digest, err := c.digestSvc.GetDigest(ctx, c.id)
+	if err != nil {
+		return nil, err
+	}
+
+	if digest.Status.IsProcessing() {
+		return nil, errorx.NewsDigestProcessing
+	}
+
+	return export.ExportFile(ctx, c.format, digest.ToDocument())
+}
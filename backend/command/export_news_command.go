@@ -0,0 +1,166 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/export"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+const (
+	// maxExportNews is the maximum number of news in an export.
+	maxExportNews = 200
+
+	// exportGroupByTopic groups the exported news by topic, the default is by source.
+	exportGroupByTopic = "topic"
+)
+
+// ExportNewsCommand represents a command to export news to a file.
+type ExportNewsCommand struct {
+	ids      []uint
+	params   *valueobject.QueryNewsParams
+	format   export.Format
+	title    string
+	language string
+	groupBy  string
+
+	newsSvc service.NewsService
+}
+
+func NewExportNewsCommand(
+	ids []uint,
+	params *valueobject.QueryNewsParams,
+	format string,
+	title string,
+	language string,
+	groupBy string,
+	newsSvc service.NewsService,
+) *ExportNewsCommand {
+	return &ExportNewsCommand{
+		ids:      ids,
+		params:   params,
+		format:   export.Format(format),
+		title:    title,
+		language: language,
+		groupBy:  groupBy,
+		newsSvc:  newsSvc,
+	}
+}
+
+func (c *ExportNewsCommand) Execute(ctx context.Context) (*export.File, error) {
+	if len(c.ids) == 0 && c.params == nil {
+		return nil, errorx.ParamsError
+	}
+
+	news, err := c.loadNews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(news) == 0 {
+		return nil, errorx.NewsNotFound
+	}
+
+	return export.ExportFile(ctx, c.format, c.buildDocument(news))
+}
+
+// loadNews loads the news to export, scraping the details that are not yet scraped
+func (c *ExportNewsCommand) loadNews(ctx context.Context) ([]*entity.NewsDetail, error) {
+	if len(c.ids) > 0 {
+		return gokit.SliceMapErr(c.ids[:min(len(c.ids), maxExportNews)], func(id uint) (*entity.NewsDetail, error) {
+			return c.newsSvc.GetNewsDetail(ctx, id)
+		})
+	}
+
+	var (
+		result = make([]*entity.NewsDetail, 0)
+		params = *c.params
+	)
+
+	params.Page = &httpx.Pagination{Page: 1, Limit: 50}
+
+	for len(result) < maxExportNews {
+		news, total, err := c.newsSvc.QueryNews(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, news...)
+
+		if len(news) == 0 || int64(len(result)) >= total {
+			break
+		}
+
+		params.Page.Page++
+	}
+
+	result = result[:min(len(result), maxExportNews)]
+
+	for idx, news := range result {
+		if news.Scraped {
+			continue
+		}
+
+		detail, err := c.newsSvc.GetNewsDetail(ctx, news.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		result[idx] = detail
+	}
+
+	return result, nil
+}
+
+// buildDocument groups the news into chapters by source or topic
+func (c *ExportNewsCommand) buildDocument(news []*entity.NewsDetail) *export.Document {
+	doc := &export.Document{
+		Title:     c.title,
+		Language:  c.language,
+		CreatedAt: time.Now(),
+	}
+
+	if doc.Title == "" {
+		doc.Title = "World News"
+	}
+
+	var (
+		chapters = make(map[string]*export.Chapter)
+		groupKey = func(n *entity.NewsDetail) string { return n.Source }
+	)
+
+	if c.groupBy == exportGroupByTopic {
+		groupKey = func(n *entity.NewsDetail) string { return n.Topic }
+	}
+
+	for _, item := range news {
+		key := groupKey(item)
+
+		chapter, ok := chapters[key]
+		if !ok {
+			chapter = &export.Chapter{Title: key}
+			chapters[key] = chapter
+			doc.Chapters = append(doc.Chapters, chapter)
+		}
+
+		chapter.Articles = append(chapter.Articles, &export.Article{
+			Title:       item.Title,
+			Source:      item.Source,
+			Topic:       item.Topic,
+			Author:      item.Author,
+			Link:        item.Link,
+			PublishedAt: item.PublishedAt,
+			Contents:    item.Contents,
+			Images:      item.Images,
+		})
+	}
+
+	return doc
+}
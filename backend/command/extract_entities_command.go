@@ -0,0 +1,215 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/ner"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// entityExtractionBatchSize is the number of news loaded in one batch.
+const entityExtractionBatchSize = 20
+
+// ExtractEntitiesCommand represents a command to extract the named entities of the news in the background.
+type ExtractEntitiesCommand struct {
+	ctx  context.Context
+	auto bool // started after crawling, skipped if disabled
+
+	config *valueobject.EntityExtractionConfig
+	textAi *openai.Config
+
+	crawlingSvc     service.CrawlingService
+	entitySvc       service.EntityService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewExtractEntitiesCommand(
+	ctx context.Context,
+	auto bool,
+	crawlingSvc service.CrawlingService,
+	entitySvc service.EntityService,
+	systemConfigSvc service.SystemConfigService,
+) *ExtractEntitiesCommand {
+	return &ExtractEntitiesCommand{
+		ctx:             ctx,
+		auto:            auto,
+		crawlingSvc:     crawlingSvc,
+		entitySvc:       entitySvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *ExtractEntitiesCommand) Execute(ctx context.Context) error {
+	if err := c.loadConfig(ctx); err != nil {
+		return err
+	}
+
+	if c.auto && c.config.Disabled {
+		return nil
+	}
+
+	hasProcessing, err := c.crawlingSvc.HasProcessingRecord(ctx, valueobject.ExtractingEntities)
+	if err != nil {
+		return err
+	}
+
+	if hasProcessing {
+		return errorx.HasProcessingTasks
+	}
+
+	total, err := c.entitySvc.CountPendingNews(ctx)
+	if err != nil || total == 0 {
+		return err
+	}
+
+	// create the record to track the progress
+	record := entity.NewCrawlingRecord(valueobject.ExtractingEntities, &valueobject.CrawlingRecordConfig{Total: total})
+
+	if err := c.crawlingSvc.CreateCrawlingRecord(ctx, record); err != nil {
+		return err
+	}
+
+	go c.extractHandle(record)
+
+	return nil
+}
+
+// loadConfig loads the entity extraction config and the text ai config.
+func (c *ExtractEntitiesCommand) loadConfig(ctx context.Context) error {
+	config, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.EntityExtractionKey.String())
+	if err != nil {
+		return err
+	}
+
+	c.config = valueobject.NewDefaultEntityExtractionConfig()
+
+	if config.Id != 0 {
+		if err := config.UnmarshalValue(c.config); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if c.config.DisableAI {
+		return nil
+	}
+
+	c.textAi, err = loadTextAiConfig(ctx, c.systemConfigSvc)
+
+	// the rule-based extractor works without the model
+	if errors.Is(err, errorx.OpenaiConfigNotFound) && c.config.RuleFallback {
+		return nil
+	}
+
+	return err
+}
+
+// extractHandle extracts the entities of the pending news batch by batch.
+func (c *ExtractEntitiesCommand) extractHandle(record *entity.CrawlingRecord) {
+	var afterId uint
+
+	for {
+		news, err := c.entitySvc.QueryPendingNews(c.ctx, afterId, entityExtractionBatchSize)
+		if err != nil {
+			logx.WithContext(c.ctx).Error("extractHandle.QueryPendingNews", err)
+
+			record.CrawlingFailed()
+
+			break
+		}
+
+		if len(news) == 0 {
+			record.CrawlingCompleted()
+
+			break
+		}
+
+		for _, item := range news {
+			if c.ctx.Err() != nil {
+				break
+			}
+
+			afterId = item.Id
+
+			if err := c.extractNews(item); err != nil {
+				logx.WithContext(c.ctx).Error(fmt.Sprintf("extractHandle.extractNews:%d", item.Id), err)
+
+				continue
+			}
+
+			record.Quantity++
+		}
+
+		if !updateJobRecord(c.ctx, c.crawlingSvc, record) {
+			return
+		}
+
+		if c.ctx.Err() != nil {
+			record.CrawlingPaused()
+
+			break
+		}
+	}
+
+	if err := c.crawlingSvc.UpdateCrawlingRecord(c.ctx, record); err != nil {
+		logx.WithContext(c.ctx).Error("extractHandle.UpdateCrawlingRecord", err)
+	}
+
+	logx.WithContext(c.ctx).Info("extractHandle", fmt.Sprintf("entity extraction %s, quantity: %d",
+		record.Status, record.Quantity))
+}
+
+// extractNews extracts and saves the entities of the news.
+func (c *ExtractEntitiesCommand) extractNews(news *entity.NewsDetail) error {
+	var (
+		text     = news.BuildText()
+		entities []*ner.Entity
+		err      error
+	)
+
+	if c.textAi != nil {
+		entities, err = c.extractByModel(news.Id, text)
+	}
+
+	if c.textAi == nil || (err != nil && c.config.RuleFallback) {
+		if err != nil {
+			logx.WithContext(c.ctx).Error("extractNews.extractByModel", err)
+		}
+
+		entities, err = ner.Extract(text), nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.entitySvc.SaveNewsEntities(c.ctx, news.Id, entities)
+}
+
+// extractByModel extracts the entities with the text ai model.
+func (c *ExtractEntitiesCommand) extractByModel(newsId uint, text string) ([]*ner.Entity, error) {
+	ctx := labelUsage(c.ctx, valueobject.AiFeatureEntities, newsId)
+
+	chatModel, err := openai.NewJSONChatModel(ctx, c.textAi)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(valueobject.BuildEntityExtractionPrompt()),
+		schema.UserMessage(text),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return valueobject.ParseEntityExtractionResult(resp.Content)
+}
@@ -0,0 +1,315 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/pkg/urlx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+const (
+	// maxDigestNews is the maximum number of news ranked for a digest.
+	maxDigestNews = 500
+
+	// maxDigestInputLength is the maximum length of the article sent to the model.
+	maxDigestInputLength = 6000
+)
+
+// GenerateDigestCommand represents a command to generate the news digest of a day in the background.
+type GenerateDigestCommand struct {
+	ctx       context.Context
+	date      string
+	scheduled bool // started by the scheduler, skipped if not enabled or already generated
+
+	config *valueobject.NewsDigestConfig
+	textAi *openai.Config
+
+	newsSvc         service.NewsService
+	digestSvc       service.DigestService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewGenerateDigestCommand(
+	ctx context.Context,
+	date string,
+	scheduled bool,
+	newsSvc service.NewsService,
+	digestSvc service.DigestService,
+	systemConfigSvc service.SystemConfigService,
+) *GenerateDigestCommand {
+	return &GenerateDigestCommand{
+		ctx:             ctx,
+		date:            date,
+		scheduled:       scheduled,
+		newsSvc:         newsSvc,
+		digestSvc:       digestSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *GenerateDigestCommand) Execute(ctx context.Context) (*entity.NewsDigest, error) {
+	if err := c.loadConfig(ctx); err != nil {
+		return nil, err
+	}
+
+	if c.scheduled {
+		date, ok := c.config.ScheduledDate(time.Now())
+		if !ok {
+			return nil, nil
+		}
+
+		c.date = date
+	}
+
+	textAi, err := loadTextAiConfig(ctx, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.textAi = textAi
+
+	if c.date == "" {
+		c.date = valueobject.AnalyticsDay(time.Now())
+	}
+
+	publishDate, err := time.ParseInLocation(time.DateOnly, c.date, time.Local)
+	if err != nil {
+		return nil, errorx.ParamsError.SetErr(errors.WithStack(err))
+	}
+
+	digest, err := c.digestSvc.GetDigestByDate(ctx, c.date)
+	if err != nil && !errors.Is(err, errorx.NewsDigestNotFound) {
+		return nil, err
+	}
+
+	// the scheduled digest is generated once a day
+	if digest != nil && c.scheduled {
+		return digest, nil
+	}
+
+	if digest != nil && digest.Status.IsProcessing() {
+		return nil, errorx.NewsDigestProcessing
+	}
+
+	items, err := c.rankNews(ctx, publishDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if digest == nil {
+		digest = entity.NewNewsDigest(c.date, c.config.Language)
+		err = c.digestSvc.CreateDigest(ctx, digest)
+	} else {
+		digest.Restart(c.config.Language)
+		err = c.digestSvc.SaveDigest(ctx, digest)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	go c.generateHandle(digest, items)
+
+	return digest, nil
+}
+
+// loadConfig loads the news digest config.
+func (c *GenerateDigestCommand) loadConfig(ctx context.Context) error {
+	config, err := c.systemConfigSvc.GetSystemConfig(ctx, valueobject.NewsDigestKey.String())
+	if err != nil {
+		return err
+	}
+
+	c.config = valueobject.NewDefaultNewsDigestConfig()
+
+	if config.Id != 0 {
+		if err := config.UnmarshalValue(c.config); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	c.config.Normalize()
+
+	return nil
+}
+
+// rankNews groups the news of the day into stories and selects the top ranked article of each story.
+func (c *GenerateDigestCommand) rankNews(ctx context.Context, publishDate time.Time) (
+	[]*valueobject.DigestItem, error) {
+	news, err := c.loadNews(ctx, publishDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(news) == 0 {
+		return nil, errorx.NewsNotFound
+	}
+
+	websites, err := c.systemConfigSvc.GetNewsWebsites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]int, len(websites))
+
+	for _, item := range websites {
+		weights[urlx.ExtractSecondLevelDomain(item.Url)] = item.Weight
+	}
+
+	docs := gokit.SliceMap(news, func(item *entity.NewsDetail) []string {
+		terms, _ := textx.Keywords(item.Title)
+
+		return terms
+	})
+
+	items := make([]*valueobject.DigestItem, 0)
+
+	for _, cluster := range textx.Cluster(docs, valueobject.DigestClusterThreshold) {
+		var best *valueobject.DigestItem
+
+		for _, idx := range cluster {
+			item := news[idx]
+			score := valueobject.DigestScore(weights[item.Source], len(cluster), item.Favorited)
+
+			if best != nil && score <= best.Score {
+				best.RelatedIds = append(best.RelatedIds, item.Id)
+
+				continue
+			}
+
+			current := &valueobject.DigestItem{
+				NewsId:      item.Id,
+				Title:       item.Title,
+				Source:      item.Source,
+				Link:        item.Link,
+				PublishedAt: item.PublishedAt,
+				ClusterSize: len(cluster),
+				Score:       score,
+			}
+
+			if best != nil {
+				current.RelatedIds = append(best.RelatedIds, best.NewsId)
+			}
+
+			best = current
+		}
+
+		items = append(items, best)
+	}
+
+	slices.SortStableFunc(items, func(a, b *valueobject.DigestItem) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return items[:min(len(items), c.config.Limit)], nil
+}
+
+// loadNews loads the news published on the day.
+func (c *GenerateDigestCommand) loadNews(ctx context.Context, publishDate time.Time) ([]*entity.NewsDetail, error) {
+	var (
+		result = make([]*entity.NewsDetail, 0)
+		params = &valueobject.QueryNewsParams{PublishDate: publishDate, Page: &httpx.Pagination{Page: 1, Limit: 100}}
+	)
+
+	for len(result) < maxDigestNews {
+		news, total, err := c.newsSvc.QueryNews(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, news...)
+
+		if len(news) == 0 || int64(len(result)) >= total {
+			break
+		}
+
+		params.Page.Page++
+	}
+
+	return result[:min(len(result), maxDigestNews)], nil
+}
+
+// generateHandle summarizes the articles and saves the digest sections grouped by topic.
+func (c *GenerateDigestCommand) generateHandle(digest *entity.NewsDigest, items []*valueobject.DigestItem) {
+	var (
+		sections = make([]*valueobject.DigestSection, 0)
+		topics   = make(map[string]*valueobject.DigestSection)
+		failed   error
+	)
+
+	for _, item := range items {
+		news, err := c.newsSvc.GetNewsDetail(c.ctx, item.NewsId)
+		if err != nil {
+			logx.WithContext(c.ctx).Error(fmt.Sprintf("generateHandle.GetNewsDetail:%d", item.NewsId), err)
+
+			continue
+		}
+
+		item.Summary, err = c.summarize(news)
+		if err != nil {
+			failed = err
+
+			break
+		}
+
+		section, ok := topics[news.Topic]
+		if !ok {
+			section = &valueobject.DigestSection{Topic: news.Topic}
+			topics[news.Topic] = section
+			sections = append(sections, section)
+		}
+
+		section.Items = append(section.Items, item)
+	}
+
+	switch {
+	case failed != nil:
+		logx.WithContext(c.ctx).Error("generateHandle.summarize", failed)
+		digest.Failed(failed)
+	case len(sections) == 0:
+		digest.Failed(errorx.NewsNotFound)
+	default:
+		digest.Completed(sections)
+	}
+
+	if err := c.digestSvc.SaveDigest(c.ctx, digest); err != nil {
+		logx.WithContext(c.ctx).Error("generateHandle.SaveDigest", err)
+	}
+}
+
+// summarize summarizes the article with the text ai model.
+func (c *GenerateDigestCommand) summarize(news *entity.NewsDetail) (string, error) {
+	ctx := labelUsage(c.ctx, valueobject.AiFeatureDigest, news.Id)
+
+	chatModel, err := openai.NewChatModel(ctx, c.textAi)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(c.config.BuildPrompt()),
+		schema.UserMessage(textx.Truncate(news.BuildText(), maxDigestInputLength)),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return strings.TrimSpace(resp.Content), nil
+}
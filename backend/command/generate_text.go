@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
+)
+
+// generateText generates the reply of the text ai model, emitting the chunks to the stream if any.
+// The streamed generation can be aborted by cancelling the stream.
+func generateText(ctx context.Context, textAi *openai.Config, messages []*schema.Message,
+	stream *streamx.Stream, opts ...model.Option) (string, error) {
+	chatModel, err := openai.NewChatModel(ctx, textAi)
+	if err != nil {
+		return "", err
+	}
+
+	if stream == nil {
+		resp, err := chatModel.Generate(ctx, messages, opts...)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		return resp.Content, nil
+	}
+
+	ctx, release := stream.Start(ctx)
+	defer release()
+
+	reader, err := chatModel.Stream(ctx, messages, opts...)
+	if err != nil {
+		stream.Fail(err)
+
+		return "", errors.WithStack(err)
+	}
+
+	content, err := openai.ReadStream(reader, stream.Delta)
+	if err != nil {
+		stream.Fail(err)
+
+		return "", err
+	}
+
+	stream.Done()
+
+	return content, nil
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// updateJobRecord saves the progress of a background job, returning false if the record is no longer processing.
+func updateJobRecord(ctx context.Context, crawlingSvc service.CrawlingService, record *entity.CrawlingRecord) bool {
+	current, err := crawlingSvc.GetCrawlingRecord(ctx, record.Id)
+	if err != nil {
+		logx.WithContext(ctx).Error("updateJobRecord.GetCrawlingRecord", err)
+
+		return false
+	}
+
+	// paused or stopped by the user
+	if !current.Status.IsProcessing() {
+		return false
+	}
+
+	if err := crawlingSvc.UpdateCrawlingRecord(ctx, record); err != nil {
+		logx.WithContext(ctx).Error("updateJobRecord.UpdateCrawlingRecord", err)
+
+		return false
+	}
+
+	return true
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
-	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
 	"github.com/mjiee/world-news/backend/service"
 )
@@ -25,6 +25,9 @@ type MergeArticleCommand struct {
 
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
 }
 
 func NewMergeArticleCommand(
@@ -35,6 +38,9 @@ func NewMergeArticleCommand(
 	voiceIds []string,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	usageSvc service.UsageService,
+	jobSvc service.PodcastJobService,
 ) *MergeArticleCommand {
 	return &MergeArticleCommand{
 		language:        language,
@@ -43,6 +49,9 @@ func NewMergeArticleCommand(
 		voiceIds:        voiceIds,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		usageSvc:        usageSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
@@ -51,6 +60,10 @@ func (c *MergeArticleCommand) Execute(ctx context.Context) (string, error) {
 		return "", errorx.ParamsError
 	}
 
+	if err := checkAiBudget(ctx, c.systemConfigSvc, c.usageSvc); err != nil {
+		return "", err
+	}
+
 	// config
 	textAi, ttsAi, prompt, err := c.systemConfigSvc.GetPodcastConfig(ctx)
 	if err != nil {
@@ -84,21 +97,46 @@ func (c *MergeArticleCommand) Execute(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	// new task
-	var (
-		task = entity.NewPodcastTask(nil, c.language)
-		ai   = valueobject.NewTaskAiFromTextAi(textAi)
-	)
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return "", err
+	}
 
-	task.Title = c.title
+	task, err := c.buildTask(ctx, textAi, voices, prompt, routing, tasks, contents)
+	if err != nil {
+		return "", err
+	}
 
-	// merge stage
-	mergeStage := valueobject.NewTaskStage(valueobject.TaskStageMerge, prompt.BuildMergePrompt(c.language), ai)
+	if err := c.taskSvc.SaveTask(ctx, task); err != nil {
+		return "", err
+	}
 
-	for idx, content := range contents {
-		mergeStage.Input = fmt.Sprintf("%s\n\nThe %d'st podcast: \n%s", mergeStage.Input, idx+1, content)
+	// execute task by the job worker
+	job := entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo)
+	if err := c.jobSvc.EnqueueJob(ctx, job); err != nil {
+		return "", err
 	}
 
+	return task.BatchNo, nil
+}
+
+// buildTask builds the task merging the outputs of the article stages, the merge input is set later if no contents.
+func (c *MergeArticleCommand) buildTask(ctx context.Context, textAi *openai.Config, voices []*ttsai.Voice,
+	prompt *valueobject.PodcastScriptPrompt, routing *valueobject.ModelRouting, tasks []*entity.PodcastTask,
+	contents []string) (*entity.PodcastTask, error) {
+	task := entity.NewPodcastTask(nil, c.language)
+
+	task.Title = c.title
+
+	if err := applyPodcastPrompts(ctx, c.promptSvc, prompt, podcastPromptVars(task, voices, prompt)); err != nil {
+		return nil, err
+	}
+
+	// merge stage
+	mergeStage := valueobject.NewTaskStage(valueobject.TaskStageMerge, prompt.BuildMergePrompt(c.language),
+		routing.TaskAi(valueobject.TaskStageMerge, textAi))
+
+	mergeStage.Input = mergeInput(contents)
 	mergeStage.Extra = &valueobject.TaskStageExtra{
 		NewsIds: gokit.SliceFilterMap(tasks, func(t *entity.PodcastTask) (bool, uint) {
 			if t.News == nil {
@@ -115,23 +153,22 @@ func (c *MergeArticleCommand) Execute(ctx context.Context) (string, error) {
 	// scritp stage
 	if len(c.voiceIds) > 0 {
 		scriptStage := valueobject.NewTaskStage(valueobject.TaskStageScripted,
-			valueobject.BuildScriptPrompt(task.Language, voices), ai)
+			prompt.BuildScriptPrompt(task.Language, voices), routing.TaskAi(valueobject.TaskStageScripted, textAi))
 
 		scriptStage.Audio = &valueobject.PodcastAudio{Voices: voices}
 		task.AddNewStage(scriptStage)
 	}
 
-	if err := c.taskSvc.SaveTask(ctx, task); err != nil {
-		return "", err
-	}
+	return task, nil
+}
 
-	// execute task
-	executeCmd := NewExecuteTaskCommand(c.ctx, task, c.systemConfigSvc, c.taskSvc)
-	go func() {
-		if err := executeCmd.Execute(executeCmd.ctx); err != nil {
-			logx.WithContext(executeCmd.ctx).Error("MergeArticleCommand", err)
-		}
-	}()
+// mergeInput builds the input of the merge stage from the outputs of the article stages
+func mergeInput(contents []string) string {
+	var input string
 
-	return task.BatchNo, nil
+	for idx, content := range contents {
+		input = fmt.Sprintf("%s\n\nThe %d'st podcast: \n%s", input, idx+1, content)
+	}
+
+	return input
 }
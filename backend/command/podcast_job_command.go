@@ -0,0 +1,179 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/streamx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// interruptedReason the failure reason of the task stages whose job cannot be resumed
+const interruptedReason = "interrupted by an app restart"
+
+// RunJobCommand represents the command to run a claimed podcast job.
+type RunJobCommand struct {
+	job *entity.PodcastJob
+
+	systemConfigSvc service.SystemConfigService
+	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	jobSvc          service.PodcastJobService
+}
+
+func NewRunJobCommand(
+	job *entity.PodcastJob,
+	systemConfigSvc service.SystemConfigService,
+	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	jobSvc service.PodcastJobService,
+) *RunJobCommand {
+	return &RunJobCommand{
+		job:             job,
+		systemConfigSvc: systemConfigSvc,
+		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		jobSvc:          jobSvc,
+	}
+}
+
+// Execute runs the processing stages of the task, they are resumed from the last saved stage after a restart.
+func (c *RunJobCommand) Execute(ctx context.Context) error {
+	task, err := c.taskSvc.GetTaskByBatchNo(ctx, c.job.BatchNo)
+	if err != nil {
+		return err
+	}
+
+	// the run is cancelled with the batch no of the task, the task is saved with the job context
+	runCtx, release := streamx.WithCancel(ctx, task.BatchNo)
+	defer release()
+
+	// the daily show is merged, its job is queued once its articles are rewritten
+	if c.job.Kind == valueobject.JobKindDailyShow {
+		if err := mergeShowArticles(ctx, task, c.taskSvc); err != nil || task.Result != "" {
+			return err
+		}
+	}
+
+	executeCmd := NewExecuteTaskCommand(ctx, task, c.systemConfigSvc, c.taskSvc, c.promptSvc)
+	if err := executeCmd.Execute(runCtx); err != nil {
+		return err
+	}
+
+	if c.job.Kind == valueobject.JobKindExecuteTask || task.Result != "" {
+		return nil
+	}
+
+	// create audio, unless the task is paused for human review
+	scriptedStage := task.GetStage(valueobject.TaskStageScripted)
+	if scriptedStage == nil {
+		return errors.New("the podcast scripts are not created")
+	}
+
+	if scriptedStage.Status != valueobject.StageStatusCompleted {
+		return nil
+	}
+
+	return NewCreateAudioCommand(ctx, scriptedStage.Id, c.systemConfigSvc, c.taskSvc, c.jobSvc).Execute(ctx)
+}
+
+// RecoverJobsCommand represents the command to recover the podcast jobs interrupted by an app restart.
+type RecoverJobsCommand struct {
+	orphans bool // also fail the processing tasks without a job
+
+	systemConfigSvc service.SystemConfigService
+	taskSvc         service.PodcastTaskService
+	jobSvc          service.PodcastJobService
+}
+
+func NewRecoverJobsCommand(
+	orphans bool,
+	systemConfigSvc service.SystemConfigService,
+	taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService,
+) *RecoverJobsCommand {
+	return &RecoverJobsCommand{
+		orphans:         orphans,
+		systemConfigSvc: systemConfigSvc,
+		taskSvc:         taskSvc,
+		jobSvc:          jobSvc,
+	}
+}
+
+// Execute re-enqueues the stale jobs, the jobs out of attempts fail with their tasks.
+func (c *RecoverJobsCommand) Execute(ctx context.Context) error {
+	config, err := c.systemConfigSvc.GetJobQueueConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := c.jobSvc.GetStaleJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Attempts < config.MaxAttempts {
+			job.Requeue()
+		} else {
+			job.Finish(errors.New(interruptedReason))
+			c.failTask(ctx, job.BatchNo)
+		}
+
+		if err := c.jobSvc.SaveJob(ctx, job); err != nil {
+			return err
+		}
+
+		logx.WithContext(ctx).Info("RecoverJobsCommand", job)
+	}
+
+	if !c.orphans {
+		return nil
+	}
+
+	// the tasks left processing by a goroutine or a deleted job, they would block the task forever.
+	// The tasks updated within the lease may still be run by another process sharing the database.
+	batchNos, err := c.taskSvc.GetProcessingBatchNos(ctx, time.Now().Add(-valueobject.JobLease))
+	if err != nil {
+		return err
+	}
+
+	for _, batchNo := range batchNos {
+		active, err := c.jobSvc.HasActiveJob(ctx, batchNo)
+		if err != nil {
+			return err
+		}
+
+		if !active {
+			c.failTask(ctx, batchNo)
+		}
+	}
+
+	return nil
+}
+
+// failTask fails the processing stages of the task
+func (c *RecoverJobsCommand) failTask(ctx context.Context, batchNo string) {
+	task, err := c.taskSvc.GetTaskByBatchNo(ctx, batchNo)
+	if err != nil {
+		logx.WithContext(ctx).Error("RecoverJobsCommand.GetTaskByBatchNo", err)
+
+		return
+	}
+
+	for _, stage := range task.Stages {
+		if stage.Status.IsProcessing() {
+			stage.Fail(interruptedReason)
+			task.Result = valueobject.TaskResultFailed
+		}
+	}
+
+	if err := c.taskSvc.SaveTask(ctx, task); err != nil {
+		logx.WithContext(ctx).Error("RecoverJobsCommand.SaveTask", err)
+	}
+}
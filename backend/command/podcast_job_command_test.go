@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestRecoverJobsCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		taskSvc         = service.NewPodcastTaskService()
+		jobSvc          = service.NewPodcastJobService()
+		ctx             = context.Background()
+	)
+
+	// a stale job to resume, a stale job out of attempts, a task left processing without a job
+	// and a task without a job still updated within the lease
+	resumed := newTestJobTask(t, taskSvc, jobSvc, 1, valueobject.JobLease)
+	failed := newTestJobTask(t, taskSvc, jobSvc, valueobject.NewDefaultJobQueueConfig().MaxAttempts,
+		valueobject.JobLease)
+	orphan := newTestJobTask(t, taskSvc, jobSvc, 0, 2*valueobject.JobLease)
+	running := newTestJobTask(t, taskSvc, jobSvc, 0, 0)
+
+	// a task paused for review, its later stage left processing without a job
+	paused := newTestTask()
+	approval := valueobject.NewTaskStage(valueobject.TaskStageApproval, "", nil)
+	approval.Review("low confidence")
+	rewrite := valueobject.NewTaskStage(valueobject.TaskStageRewrite, "", nil)
+
+	for _, stage := range []*valueobject.TaskStage{approval, rewrite} {
+		stage.UpdatedAt = time.Now().Add(-2 * valueobject.JobLease)
+		paused.AddNewStage(stage)
+	}
+
+	if err := taskSvc.SaveTask(ctx, paused); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewRecoverJobsCommand(true, systemConfigSvc, taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for batchNo, want := range map[string]valueobject.JobStatus{resumed: valueobject.JobStatusQueued,
+		failed: valueobject.JobStatusFailed} {
+		jobs, _, err := jobSvc.QueryJobs(ctx, &valueobject.QueryJobParams{BatchNo: batchNo,
+			Page: &httpx.Pagination{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(jobs) != 1 || jobs[0].Status != want {
+			t.Fatalf("unexpected jobs of %s: %+v", batchNo, jobs)
+		}
+	}
+
+	for batchNo, want := range map[string]valueobject.StageStatus{resumed: valueobject.StageStatusProcessing,
+		failed: valueobject.StageStatusFailed, orphan: valueobject.StageStatusFailed,
+		running: valueobject.StageStatusProcessing} {
+		task, err := taskSvc.GetTaskByBatchNo(ctx, batchNo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if status := task.Stages[0].Status; status != want {
+			t.Fatalf("unexpected stage status %s of %s, want %s", status, batchNo, want)
+		}
+	}
+
+	task, err := taskSvc.GetTaskByBatchNo(ctx, paused.BatchNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.Stages[0].Status != valueobject.StageStatusReview ||
+		task.Stages[1].Status != valueobject.StageStatusProcessing || task.Result != "" {
+		t.Fatalf("the task paused for review is failed: %+v", task.Stages[1])
+	}
+}
+
+// newTestJobTask saves a processing task last updated the idle time ago, with a stale running job of the attempts,
+// no job if 0
+func newTestJobTask(t *testing.T, taskSvc service.PodcastTaskService, jobSvc service.PodcastJobService,
+	attempts int, idle time.Duration) string {
+	t.Helper()
+
+	stage := valueobject.NewTaskStage(valueobject.TaskStageRewrite, "", nil)
+	stage.UpdatedAt = time.Now().Add(-idle)
+
+	task := newTestTask()
+	task.AddNewStage(stage)
+
+	if err := taskSvc.SaveTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts == 0 {
+		return task.BatchNo
+	}
+
+	job := entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo)
+	job.Status = valueobject.JobStatusRunning
+	job.Attempts = attempts
+	job.LeaseUntil = time.Now().Add(-time.Second)
+
+	if err := jobSvc.SaveJob(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+
+	return task.BatchNo
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// SavePromptCommand represents a command to save a new version of a prompt template.
+type SavePromptCommand struct {
+	name     string
+	language string
+	content  string
+	note     string
+
+	promptSvc service.PromptService
+}
+
+func NewSavePromptCommand(name, language, content, note string, promptSvc service.PromptService) *SavePromptCommand {
+	return &SavePromptCommand{
+		name:      name,
+		language:  language,
+		content:   content,
+		note:      note,
+		promptSvc: promptSvc,
+	}
+}
+
+func (c *SavePromptCommand) Execute(ctx context.Context) (*entity.PromptTemplate, error) {
+	prompt, err := entity.NewPromptTemplate(c.name, c.language, c.content, c.note)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.promptSvc.SavePrompt(ctx, prompt); err != nil {
+		return nil, err
+	}
+
+	return prompt, nil
+}
+
+// PreviewPromptCommand represents a command to render a prompt template without calling the model.
+type PreviewPromptCommand struct {
+	name    string
+	content string // renders the unsaved content instead of the saved template if set
+	newsId  uint   // fills the news variables if set
+	vars    *valueobject.PromptVars
+
+	promptSvc service.PromptService
+	newsSvc   service.NewsService
+}
+
+func NewPreviewPromptCommand(name, content string, newsId uint, vars *valueobject.PromptVars,
+	promptSvc service.PromptService, newsSvc service.NewsService) *PreviewPromptCommand {
+	return &PreviewPromptCommand{
+		name:      name,
+		content:   content,
+		newsId:    newsId,
+		vars:      vars,
+		promptSvc: promptSvc,
+		newsSvc:   newsSvc,
+	}
+}
+
+func (c *PreviewPromptCommand) Execute(ctx context.Context) (string, error) {
+	if c.name == "" || c.vars == nil {
+		return "", errorx.ParamsError
+	}
+
+	if c.newsId != 0 {
+		news, err := c.newsSvc.GetNewsDetail(ctx, c.newsId)
+		if err != nil {
+			return "", err
+		}
+
+		c.vars.Title, c.vars.Source, c.vars.Contents = news.Title, news.Source, news.Contents
+	}
+
+	if c.content != "" {
+		return valueobject.RenderPrompt(c.name, c.content, c.vars)
+	}
+
+	prompt, err := c.promptSvc.GetPrompt(ctx, valueobject.PromptName(c.name), c.vars.Language)
+	if err != nil {
+		return "", err
+	}
+
+	return prompt.Render(c.vars)
+}
+
+// applyPodcastPrompts overrides the podcast script prompt with the defined prompt templates.
+func applyPodcastPrompts(ctx context.Context, promptSvc service.PromptService,
+	prompt *valueobject.PodcastScriptPrompt, vars *valueobject.PromptVars) error {
+	templates, err := promptSvc.GetPrompts(ctx, vars.Language, valueobject.PodcastPromptNames...)
+	if err != nil {
+		return err
+	}
+
+	prompts := make(map[valueobject.PromptName]string, len(templates))
+
+	for name, template := range templates {
+		if prompts[name], err = template.Render(vars); err != nil {
+			return err
+		}
+	}
+
+	prompt.ApplyTemplates(prompts)
+
+	return nil
+}
+
+// podcastPromptVars returns the prompt variables of the podcast task.
+func podcastPromptVars(task *entity.PodcastTask, voices []*ttsai.Voice,
+	prompt *valueobject.PodcastScriptPrompt) *valueobject.PromptVars {
+	vars := &valueobject.PromptVars{
+		Title:    task.Title,
+		Language: task.Language,
+		Voices:   voices,
+		Styles: gokit.SliceMap(prompt.StylizePrompts, func(item *valueobject.StylePrompt) string {
+			return item.Style
+		}),
+	}
+
+	if task.News != nil {
+		vars.Title, vars.Source, vars.Contents = task.News.Title, task.News.Source, task.News.Contents
+	}
+
+	return vars
+}
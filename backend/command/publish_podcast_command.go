@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// PublishPodcastCommand represents the command to publish a completed podcast task into the podcast library.
+type PublishPodcastCommand struct {
+	batchNo     string
+	title       string
+	description string // show notes, built from the source news if empty
+
+	systemConfigSvc service.SystemConfigService
+	newsSvc         service.NewsService
+	taskSvc         service.PodcastTaskService
+	podcastSvc      service.PodcastService
+}
+
+func NewPublishPodcastCommand(
+	batchNo string,
+	title string,
+	description string,
+	systemConfigSvc service.SystemConfigService,
+	newsSvc service.NewsService,
+	taskSvc service.PodcastTaskService,
+	podcastSvc service.PodcastService,
+) *PublishPodcastCommand {
+	return &PublishPodcastCommand{
+		batchNo:         batchNo,
+		title:           title,
+		description:     description,
+		systemConfigSvc: systemConfigSvc,
+		newsSvc:         newsSvc,
+		taskSvc:         taskSvc,
+		podcastSvc:      podcastSvc,
+	}
+}
+
+func (c *PublishPodcastCommand) Execute(ctx context.Context) (*entity.Podcast, error) {
+	task, err := c.taskSvc.GetTaskByBatchNo(ctx, c.batchNo)
+	if err != nil {
+		return nil, err
+	}
+
+	published, err := c.podcastSvc.HasPodcast(ctx, task.BatchNo)
+	if err != nil {
+		return nil, err
+	}
+
+	if published {
+		return nil, errorx.PodcastPublished
+	}
+
+	style, err := c.style(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	podcast, err := entity.NewPodcastFromTask(task, style)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.description == "" {
+		news, err := c.sourceNews(ctx, podcast.NewsIds)
+		if err != nil {
+			return nil, err
+		}
+
+		c.description = podcast.BuildShowNotes(news)
+	}
+
+	podcast.Update(c.title, c.description)
+
+	if err := c.podcastSvc.CreatePodcast(ctx, podcast); err != nil {
+		return nil, err
+	}
+
+	return podcast, nil
+}
+
+// style returns the style picked by the classify stage of the task, empty if not classified.
+func (c *PublishPodcastCommand) style(ctx context.Context, task *entity.PodcastTask) (string, error) {
+	classify := task.GetStage(valueobject.TaskStageClassify)
+	if classify == nil || classify.Classify == nil {
+		return "", nil
+	}
+
+	_, _, prompt, err := c.systemConfigSvc.GetPodcastConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if stylePrompt := prompt.GetStylePrompt(classify.Classify); stylePrompt != nil {
+		return stylePrompt.Style, nil
+	}
+
+	return "", nil
+}
+
+// sourceNews loads the stored source news of the podcast, the deleted news are skipped.
+func (c *PublishPodcastCommand) sourceNews(ctx context.Context, ids []uint) ([]*entity.NewsDetail, error) {
+	return c.newsSvc.GetNewsByIds(ctx, ids...)
+}
+
+// UpdatePodcastCommand represents the command to update the title and the show notes of a published podcast.
+type UpdatePodcastCommand struct {
+	id          uint
+	title       string
+	description string
+
+	podcastSvc service.PodcastService
+}
+
+func NewUpdatePodcastCommand(
+	id uint,
+	title string,
+	description string,
+	podcastSvc service.PodcastService,
+) *UpdatePodcastCommand {
+	return &UpdatePodcastCommand{
+		id:          id,
+		title:       title,
+		description: description,
+		podcastSvc:  podcastSvc,
+	}
+}
+
+func (c *UpdatePodcastCommand) Execute(ctx context.Context) (*entity.Podcast, error) {
+	podcast, err := c.podcastSvc.GetPodcast(ctx, c.id)
+	if err != nil {
+		return nil, err
+	}
+
+	podcast.Update(c.title, c.description)
+
+	if err := c.podcastSvc.SavePodcast(ctx, podcast); err != nil {
+		return nil, err
+	}
+
+	return podcast, nil
+}
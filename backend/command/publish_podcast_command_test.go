@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestPublishPodcastCommand(t *testing.T) {
+	var (
+		systemConfigSvc = setupTest(t)
+		newsSvc         = service.NewNewsService(nil)
+		taskSvc         = service.NewPodcastTaskService()
+		podcastSvc      = service.NewPodcastService()
+		ctx             = context.Background()
+	)
+
+	audioFile := filepath.Join(t.TempDir(), "task.mp3")
+	if err := os.WriteFile(audioFile, []byte("audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// the show notes are built from the stored news, which is never scraped while publishing
+	task := newTestTask()
+	task.News.Id = newTestNews(t, newsSvc, 0, "a.com", 0, 1)[0]
+
+	scripted := valueobject.NewTaskStage(valueobject.TaskStageScripted, "", nil)
+	scripted.Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}, Scripts: []*ttsai.TtsScript{
+		{Text: "Our city has a new library.", Speaker: testVoice.Id},
+	}}
+	scripted.SetOutput("scripts")
+	task.AddNewStage(scripted)
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	publishCmd := NewPublishPodcastCommand(task.BatchNo, "Solar library", "", systemConfigSvc, newsSvc, taskSvc,
+		podcastSvc)
+
+	// the task without audio is not published
+	if _, err := publishCmd.Execute(ctx); !errors.Is(err, errorx.TaskNotCompleted) {
+		t.Fatalf("expected the task not completed, got %v", err)
+	}
+
+	tts := valueobject.NewTaskStage(valueobject.TaskStageTextToSpeech, "", nil)
+	tts.Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}, Format: "mp3", Url: audioFile,
+		Duration: 42}
+	tts.SetOutput(audioFile)
+	task.AddNewStage(tts)
+	task.Result = valueobject.TaskResultCompleted
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := publishCmd.Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if podcast.Id == 0 || podcast.Title != "Solar library" || podcast.Duration != 42 || podcast.Language != "en" ||
+		!strings.Contains(podcast.Script, "Our city has a new library.") || podcast.Audio.Url == audioFile ||
+		!strings.HasPrefix(podcast.Description, "- a.com (a.com)") {
+		t.Fatalf("unexpected podcast %+v", podcast)
+	}
+
+	// the audio is kept once the task audio is removed
+	if err := os.Remove(audioFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(podcast.Audio.Url); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := publishCmd.Execute(ctx); !errors.Is(err, errorx.PodcastPublished) {
+		t.Fatalf("expected the task published once, got %v", err)
+	}
+
+	podcast, err = NewUpdatePodcastCommand(podcast.Id, "", "- Solar library", podcastSvc).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcasts, total, err := podcastSvc.QueryPodcasts(ctx, &valueobject.QueryPodcastParams{Language: "en",
+		Page: &httpx.Pagination{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 1 || podcasts[0].Title != "Solar library" || podcasts[0].Description != "- Solar library" {
+		t.Fatalf("unexpected podcasts %d", total)
+	}
+
+	if err := podcastSvc.DeletePodcast(ctx, podcast.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := podcastSvc.GetPodcast(ctx, podcast.Id); !errors.Is(err, errorx.PodcastNotFound) {
+		t.Fatalf("expected the podcast unpublished, got %v", err)
+	}
+
+	if _, err := os.Stat(podcast.Audio.Url); !os.IsNotExist(err) {
+		t.Fatal("expected the podcast audio removed")
+	}
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// QueryNewsTimeSeriesCommand represents a command to count the news per day by source, topic or keyword.
+type QueryNewsTimeSeriesCommand struct {
+	params *valueobject.NewsAnalyticsParams
+
+	analyticsSvc    service.AnalyticsService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewQueryNewsTimeSeriesCommand(
+	params *valueobject.NewsAnalyticsParams,
+	analyticsSvc service.AnalyticsService,
+	systemConfigSvc service.SystemConfigService,
+) *QueryNewsTimeSeriesCommand {
+	return &QueryNewsTimeSeriesCommand{
+		params:          params,
+		analyticsSvc:    analyticsSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *QueryNewsTimeSeriesCommand) Execute(ctx context.Context) ([]*valueobject.TimeSeries, error) {
+	// the keyword series defaults to the configured news topics
+	if c.params.Dimension == valueobject.AnalyticsByKeyword && len(c.params.Keywords) == 0 {
+		topics, err := c.systemConfigSvc.GetNewsTopics(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.params.Keywords = topics
+	}
+
+	return c.analyticsSvc.NewsTimeSeries(ctx, c.params)
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// GetNewsDetailCommand represents a command to get the news detail with the related articles.
+type GetNewsDetailCommand struct {
+	id uint
+
+	newsSvc   service.NewsService
+	entitySvc service.EntityService
+}
+
+func NewGetNewsDetailCommand(id uint, newsSvc service.NewsService,
+	entitySvc service.EntityService) *GetNewsDetailCommand {
+	return &GetNewsDetailCommand{
+		id:        id,
+		newsSvc:   newsSvc,
+		entitySvc: entitySvc,
+	}
+}
+
+func (c *GetNewsDetailCommand) Execute(ctx context.Context) (*entity.NewsDetail, error) {
+	news, err := c.newsSvc.GetNewsDetail(ctx, c.id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the news detail is still returned without the related articles
+	news.Related, err = findRelatedNews(ctx, news, valueobject.DefaultRelatedNewsLimit, c.newsSvc, c.entitySvc)
+	if err != nil {
+		logx.WithContext(ctx).Error("GetNewsDetail.findRelatedNews", err)
+	}
+
+	return news, nil
+}
+
+// GetRelatedNewsCommand represents a command to get the articles related to the news.
+type GetRelatedNewsCommand struct {
+	id    uint
+	limit int
+
+	newsSvc   service.NewsService
+	entitySvc service.EntityService
+}
+
+func NewGetRelatedNewsCommand(id uint, limit int, newsSvc service.NewsService,
+	entitySvc service.EntityService) *GetRelatedNewsCommand {
+	return &GetRelatedNewsCommand{
+		id:        id,
+		limit:     valueobject.NormalizeRelatedNewsLimit(limit),
+		newsSvc:   newsSvc,
+		entitySvc: entitySvc,
+	}
+}
+
+func (c *GetRelatedNewsCommand) Execute(ctx context.Context) ([]*entity.RelatedNews, error) {
+	news, err := c.newsSvc.GetNewsDetail(ctx, c.id)
+	if err != nil {
+		return nil, err
+	}
+
+	return findRelatedNews(ctx, news, c.limit, c.newsSvc, c.entitySvc)
+}
+
+// findRelatedNews ranks the local articles by shared named entities, title similarity,
+// same story cluster and time proximity.
+func findRelatedNews(ctx context.Context, news *entity.NewsDetail, limit int, newsSvc service.NewsService,
+	entitySvc service.EntityService) ([]*entity.RelatedNews, error) {
+	shared, err := entitySvc.CountSharedEntities(ctx, news.Id, valueobject.MaxRelatedCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := newsSvc.QueryRelatedCandidates(ctx, news, slices.Collect(maps.Keys(shared)))
+	if err != nil {
+		return nil, err
+	}
+
+	// the news is the first document of the clusters
+	docs := make([][]string, 0, len(candidates)+1)
+
+	for _, item := range append([]*entity.NewsDetail{news}, candidates...) {
+		terms, _ := textx.Keywords(item.Title)
+		docs = append(docs, terms)
+	}
+
+	story := make(map[int]bool)
+
+	for _, cluster := range textx.Cluster(docs, valueobject.DigestClusterThreshold) {
+		if cluster[0] != 0 {
+			continue
+		}
+
+		for _, idx := range cluster[1:] {
+			story[idx-1] = true
+		}
+	}
+
+	result := make([]*entity.RelatedNews, 0, limit)
+
+	for idx, item := range candidates {
+		signals := &valueobject.RelatedSignals{
+			SharedEntities:  shared[item.Id],
+			TitleSimilarity: textx.Similarity(docs[0], docs[idx+1]),
+			SameStory:       story[idx],
+			SameSource:      item.Source == news.Source,
+			TimeDistance:    item.PublishedAt.Sub(news.PublishedAt).Abs(),
+		}
+
+		if !signals.IsRelated() {
+			continue
+		}
+
+		result = append(result, &entity.RelatedNews{
+			News:           item,
+			Score:          signals.Score(),
+			SharedEntities: signals.SharedEntities,
+			Reasons:        signals.Reasons(),
+		})
+	}
+
+	slices.SortStableFunc(result, func(a, b *entity.RelatedNews) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return result[:min(len(result), limit)], nil
+}
@@ -3,8 +3,8 @@ package command
 import (
 	"context"
 
+	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
-	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/service"
 )
 
@@ -17,6 +17,8 @@ type RestyleArticleCommand struct {
 
 	systemConfigSvc service.SystemConfigService
 	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	jobSvc          service.PodcastJobService
 }
 
 func NewRestyleArticleCommand(
@@ -25,6 +27,8 @@ func NewRestyleArticleCommand(
 	prompt string,
 	systemConfigSvc service.SystemConfigService,
 	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	jobSvc service.PodcastJobService,
 ) *RestyleArticleCommand {
 	return &RestyleArticleCommand{
 		ctx:             ctx,
@@ -32,6 +36,8 @@ func NewRestyleArticleCommand(
 		prompt:          prompt,
 		systemConfigSvc: systemConfigSvc,
 		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		jobSvc:          jobSvc,
 	}
 }
 
@@ -51,10 +57,15 @@ func (c *RestyleArticleCommand) Execute(ctx context.Context) error {
 		return err
 	}
 
+	routing, err := c.systemConfigSvc.GetModelRouting(ctx)
+	if err != nil {
+		return err
+	}
+
 	// new stage
 	var (
 		stage = valueobject.NewTaskStage(valueobject.TaskStageStylize, c.prompt,
-			valueobject.NewTaskAiFromTextAi(textAi))
+			routing.TaskAi(valueobject.TaskStageStylize, textAi))
 		oldStage = task.GetStageById(c.stageId)
 	)
 
@@ -70,13 +81,6 @@ func (c *RestyleArticleCommand) Execute(ctx context.Context) error {
 		return err
 	}
 
-	// execute task
-	executeCmd := NewExecuteTaskCommand(c.ctx, task, c.systemConfigSvc, c.taskSvc)
-	go func() {
-		if err := executeCmd.Execute(executeCmd.ctx); err != nil {
-			logx.WithContext(executeCmd.ctx).Error("RestyleArticleCommand", err)
-		}
-	}()
-
-	return err
+	// execute task by the job worker
+	return c.jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo))
 }
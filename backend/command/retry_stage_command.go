@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// RetryStageCommand represents the command to rerun a failed or cancelled task stage with the same inputs.
+// The text to speech stage only generates the audio of the scripts missing it.
+type RetryStageCommand struct {
+	stageId uint
+
+	taskSvc service.PodcastTaskService
+	jobSvc  service.PodcastJobService
+}
+
+func NewRetryStageCommand(
+	stageId uint,
+	taskSvc service.PodcastTaskService,
+	jobSvc service.PodcastJobService,
+) *RetryStageCommand {
+	return &RetryStageCommand{
+		stageId: stageId,
+		taskSvc: taskSvc,
+		jobSvc:  jobSvc,
+	}
+}
+
+func (c *RetryStageCommand) Execute(ctx context.Context) error {
+	task, err := c.taskSvc.GetTaskByStageId(ctx, c.stageId)
+	if err != nil {
+		return err
+	}
+
+	// the stages left processing by a failed run do not block the retry, a running job does
+	active, err := c.jobSvc.HasActiveJob(ctx, task.BatchNo)
+	if err != nil {
+		return err
+	}
+
+	if active {
+		return errorx.HasProcessingTasks
+	}
+
+	if err := task.Retry(c.stageId); err != nil {
+		return err
+	}
+
+	if err := c.taskSvc.SaveTask(ctx, task); err != nil {
+		return err
+	}
+
+	// execute task by the job worker
+	return c.jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo))
+}
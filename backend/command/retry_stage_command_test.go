@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+func TestRetryStageCommand(t *testing.T) {
+	var (
+		_       = setupTest(t)
+		taskSvc = service.NewPodcastTaskService()
+		jobSvc  = service.NewPodcastJobService()
+		ctx     = context.Background()
+	)
+
+	// the text to speech stage failed with a segment generated
+	task := newTestTask()
+	scripted := valueobject.NewTaskStage(valueobject.TaskStageScripted, "", nil)
+	scripted.Audio = &valueobject.PodcastAudio{Voices: []*ttsai.Voice{testVoice}, Scripts: []*ttsai.TtsScript{
+		{Text: "Our city has a new library.", Speaker: testVoice.Id, AudioUrl: "segment.wav"},
+		{Text: "It runs entirely on solar power.", Speaker: testVoice.Id},
+	}}
+	scripted.SetOutput("scripts")
+	task.AddNewStage(scripted)
+	task.AddNewStage(valueobject.NewTaskStage(valueobject.TaskStageTextToSpeech, "", nil))
+	task.Stages[1].Fail("rate limited")
+	task.Result = valueobject.TaskResultFailed
+
+	if err := taskSvc.SaveTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewRetryStageCommand(task.Stages[0].Id, taskSvc, jobSvc).Execute(ctx); err == nil {
+		t.Fatal("expected the completed stage not to be retried")
+	}
+
+	if err := NewRetryStageCommand(task.Stages[1].Id, taskSvc, jobSvc).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := taskSvc.GetTaskByBatchNo(ctx, task.BatchNo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stage := task.Stages[1]
+	if stage.Status != valueobject.StageStatusProcessing || stage.Attempts != 2 || len(stage.Failures) != 1 ||
+		stage.Failures[0].Reason != "rate limited" || task.Result != "" ||
+		task.GetPodcastScript()[0].AudioUrl == "" {
+		t.Fatalf("unexpected stage %s, attempts %d, failures %d", stage.Status, stage.Attempts, len(stage.Failures))
+	}
+
+	jobs, _, err := jobSvc.QueryJobs(ctx, &valueobject.QueryJobParams{BatchNo: task.BatchNo, Page: &httpx.Pagination{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Status != valueobject.JobStatusQueued {
+		t.Fatalf("unexpected jobs %d", len(jobs))
+	}
+
+	// the queued job runs the stage, it is not retried twice
+	if err := NewRetryStageCommand(stage.Id, taskSvc, jobSvc).Execute(ctx); err == nil {
+		t.Fatal("expected the queued stage not to be retried")
+	}
+}
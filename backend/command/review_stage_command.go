@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// ReviewStageCommand is a command to review the task stage paused for human review.
+// The approved task resumes its remaining stages, the rejected task fails.
+type ReviewStageCommand struct {
+	ctx      context.Context
+	stageId  uint
+	approved bool
+	index    int // the style index picked by the reviewer for the classify stage, 0 keeps the classification
+
+	systemConfigSvc service.SystemConfigService
+	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	jobSvc          service.PodcastJobService
+}
+
+func NewReviewStageCommand(
+	ctx context.Context,
+	stageId uint,
+	approved bool,
+	index int,
+	systemConfigSvc service.SystemConfigService,
+	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	jobSvc service.PodcastJobService,
+) *ReviewStageCommand {
+	return &ReviewStageCommand{
+		ctx:             ctx,
+		stageId:         stageId,
+		approved:        approved,
+		index:           index,
+		systemConfigSvc: systemConfigSvc,
+		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		jobSvc:          jobSvc,
+	}
+}
+
+func (c *ReviewStageCommand) Execute(ctx context.Context) error {
+	task, err := c.taskSvc.GetTaskByStageId(ctx, c.stageId)
+	if err != nil {
+		return err
+	}
+
+	stage := task.GetStageById(c.stageId)
+	if stage == nil || stage.Status != valueobject.StageStatusReview {
+		return errorx.TaskStageNotInReview
+	}
+
+	if !c.approved {
+		stage.Fail("rejected by review")
+		task.Result = valueobject.TaskResultFailed
+
+		return c.taskSvc.SaveTask(ctx, task)
+	}
+
+	if stage.Stage == valueobject.TaskStageClassify && c.index > 0 {
+		_, _, prompt, err := c.systemConfigSvc.GetPodcastConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		result := &valueobject.ClassifyResult{Index: c.index, Confidence: 1, Rationale: "picked by review"}
+		if prompt.GetStylePrompt(result) == nil {
+			return errorx.ParamsError
+		}
+
+		stage.Classify = result
+	}
+
+	stage.Reason = ""
+	stage.SetStatus(valueobject.StageStatusCompleted)
+
+	if err = c.taskSvc.SaveTask(ctx, task); err != nil {
+		return err
+	}
+
+	// execute task by the job worker
+	return c.jobSvc.EnqueueJob(ctx, entity.NewPodcastJob(valueobject.JobKindExecuteTask, task.BatchNo))
+}
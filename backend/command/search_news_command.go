@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// SearchNewsCommand represents a command to search the news by meaning.
+type SearchNewsCommand struct {
+	params *valueobject.SearchNewsParams
+
+	embeddingSvc    service.EmbeddingService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewSearchNewsCommand(params *valueobject.SearchNewsParams, embeddingSvc service.EmbeddingService,
+	systemConfigSvc service.SystemConfigService) *SearchNewsCommand {
+	return &SearchNewsCommand{
+		params:          params,
+		embeddingSvc:    embeddingSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *SearchNewsCommand) Execute(ctx context.Context) ([]*entity.NewsMatch, error) {
+	if c.params == nil || strings.TrimSpace(c.params.Query) == "" {
+		return nil, errorx.ParamsError
+	}
+
+	config, err := loadEmbeddingConfig(ctx, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := openai.NewEmbedder(config).
+		EmbedStrings(labelUsage(ctx, valueobject.AiFeatureSearch, 0), []string{c.params.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) == 0 {
+		return nil, errors.New("empty query embedding")
+	}
+
+	return c.embeddingSvc.SearchNews(ctx, config.Model, vectors[0], c.params.Normalize())
+}
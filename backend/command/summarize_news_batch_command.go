@@ -0,0 +1,174 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+const (
+	// maxBatchSummaries is the maximum number of news summarized by a batch job.
+	maxBatchSummaries = 500
+
+	// summaryBatchSize is the number of news loaded in one batch.
+	summaryBatchSize = 20
+)
+
+// SummarizeNewsBatchCommand represents a command to summarize the news of a query result in the background.
+type SummarizeNewsBatchCommand struct {
+	ctx      context.Context
+	params   *valueobject.QueryNewsParams
+	language string
+
+	textAi *openai.Config
+
+	crawlingSvc     service.CrawlingService
+	newsSvc         service.NewsService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewSummarizeNewsBatchCommand(
+	ctx context.Context,
+	params *valueobject.QueryNewsParams,
+	language string,
+	crawlingSvc service.CrawlingService,
+	newsSvc service.NewsService,
+	systemConfigSvc service.SystemConfigService,
+) *SummarizeNewsBatchCommand {
+	return &SummarizeNewsBatchCommand{
+		ctx:             ctx,
+		params:          params,
+		language:        language,
+		crawlingSvc:     crawlingSvc,
+		newsSvc:         newsSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *SummarizeNewsBatchCommand) Execute(ctx context.Context) error {
+	if c.params == nil {
+		return errorx.ParamsError
+	}
+
+	language, err := summaryLanguage(ctx, c.language, c.systemConfigSvc)
+	if err != nil {
+		return err
+	}
+
+	c.language = language
+
+	if c.textAi, err = loadTextAiConfig(ctx, c.systemConfigSvc); err != nil {
+		return err
+	}
+
+	hasProcessing, err := c.crawlingSvc.HasProcessingRecord(ctx, valueobject.SummarizingNews)
+	if err != nil {
+		return err
+	}
+
+	if hasProcessing {
+		return errorx.HasProcessingTasks
+	}
+
+	params := *c.params
+	params.SummaryLanguage = c.language
+	params.Page = &httpx.Pagination{Page: 1, Limit: summaryBatchSize}
+
+	_, total, err := c.newsSvc.QueryNews(ctx, &params)
+	if err != nil || total == 0 {
+		return err
+	}
+
+	// create the record to track the progress
+	record := entity.NewCrawlingRecord(valueobject.SummarizingNews,
+		&valueobject.CrawlingRecordConfig{Total: min(total, maxBatchSummaries)})
+
+	if err := c.crawlingSvc.CreateCrawlingRecord(ctx, record); err != nil {
+		return err
+	}
+
+	go c.summarizeHandle(record, &params)
+
+	return nil
+}
+
+// summarizeHandle summarizes the news of the query result page by page, skipping the cached summaries.
+func (c *SummarizeNewsBatchCommand) summarizeHandle(record *entity.CrawlingRecord,
+	params *valueobject.QueryNewsParams) {
+	for record.Quantity < record.Config.Total {
+		news, _, err := c.newsSvc.QueryNews(c.ctx, params)
+		if err != nil {
+			logx.WithContext(c.ctx).Error("summarizeHandle.QueryNews", err)
+
+			record.CrawlingFailed()
+
+			break
+		}
+
+		if len(news) == 0 {
+			break
+		}
+
+		for _, item := range news[:min(int64(len(news)), record.Config.Total-record.Quantity)] {
+			if c.ctx.Err() != nil {
+				break
+			}
+
+			record.Quantity++
+
+			if item.Summary != nil && item.Summary.Language == c.language {
+				continue
+			}
+
+			if err := c.summarize(item); err != nil {
+				logx.WithContext(c.ctx).Error(fmt.Sprintf("summarizeHandle.summarize:%d", item.Id), err)
+			}
+		}
+
+		if !updateJobRecord(c.ctx, c.crawlingSvc, record) {
+			return
+		}
+
+		if c.ctx.Err() != nil {
+			record.CrawlingPaused()
+
+			break
+		}
+
+		params.Page.Page++
+	}
+
+	if record.Status.IsProcessing() {
+		record.CrawlingCompleted()
+	}
+
+	if err := c.crawlingSvc.UpdateCrawlingRecord(c.ctx, record); err != nil {
+		logx.WithContext(c.ctx).Error("summarizeHandle.UpdateCrawlingRecord", err)
+	}
+
+	logx.WithContext(c.ctx).Info("summarizeHandle", fmt.Sprintf("news summary %s, quantity: %d",
+		record.Status, record.Quantity))
+}
+
+// summarize summarizes the news, scraping the detail if it is not yet scraped.
+func (c *SummarizeNewsBatchCommand) summarize(news *entity.NewsDetail) error {
+	if !news.Scraped {
+		detail, err := c.newsSvc.GetNewsDetail(c.ctx, news.Id)
+		if err != nil {
+			return err
+		}
+
+		news = detail
+	}
+
+	_, err := summarizeNews(c.ctx, c.textAi, news, c.language, c.newsSvc)
+
+	return err
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// maxSummaryInputLength is the maximum length of the article sent to the model.
+const maxSummaryInputLength = 8000
+
+// SummarizeNewsCommand represents a command to summarize the news, the summary is cached by language.
+type SummarizeNewsCommand struct {
+	newsId   uint
+	language string
+	refresh  bool // generate again even if cached
+
+	newsSvc         service.NewsService
+	systemConfigSvc service.SystemConfigService
+}
+
+func NewSummarizeNewsCommand(newsId uint, language string, refresh bool, newsSvc service.NewsService,
+	systemConfigSvc service.SystemConfigService) *SummarizeNewsCommand {
+	return &SummarizeNewsCommand{
+		newsId:          newsId,
+		language:        language,
+		refresh:         refresh,
+		newsSvc:         newsSvc,
+		systemConfigSvc: systemConfigSvc,
+	}
+}
+
+func (c *SummarizeNewsCommand) Execute(ctx context.Context) (*entity.NewsSummary, error) {
+	if c.newsId == 0 {
+		return nil, errorx.ParamsError
+	}
+
+	language, err := summaryLanguage(ctx, c.language, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.refresh {
+		summary, err := c.newsSvc.GetNewsSummary(ctx, c.newsId, language)
+		if !errors.Is(err, errorx.NewsSummaryNotFound) {
+			return summary, err
+		}
+	}
+
+	textAi, err := loadTextAiConfig(ctx, c.systemConfigSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	news, err := c.newsSvc.GetNewsDetail(ctx, c.newsId)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeNews(ctx, textAi, news, language, c.newsSvc)
+}
+
+// summaryLanguage returns the target language of the summary, the app language by default.
+func summaryLanguage(ctx context.Context, language string, systemConfigSvc service.SystemConfigService) (
+	string, error) {
+	if language != "" {
+		return language, nil
+	}
+
+	return systemConfigSvc.GetLanguage(ctx)
+}
+
+// loadTextAiConfig loads the text ai config.
+func loadTextAiConfig(ctx context.Context, systemConfigSvc service.SystemConfigService) (*openai.Config, error) {
+	config, err := systemConfigSvc.GetSystemConfig(ctx, valueobject.TextAIKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.UnmarshalValue[openai.Config](config, errorx.OpenaiConfigNotFound)
+}
+
+// summarizeNews generates the short and long summaries of the news and caches them.
+func summarizeNews(ctx context.Context, textAi *openai.Config, news *entity.NewsDetail, language string,
+	newsSvc service.NewsService) (*entity.NewsSummary, error) {
+	if len(news.Contents) == 0 {
+		return nil, errorx.ScrapeNewsFailed
+	}
+
+	ctx = labelUsage(ctx, valueobject.AiFeatureSummary, news.Id)
+
+	chatModel, err := openai.NewJSONChatModel(ctx, textAi)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(valueobject.BuildNewsSummaryPrompt(language)),
+		schema.UserMessage(textx.Truncate(news.BuildText(), maxSummaryInputLength)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result, err := valueobject.ParseNewsSummaryResult(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := entity.NewNewsSummary(news.Id, language, result)
+
+	if err := newsSvc.SaveNewsSummary(ctx, summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
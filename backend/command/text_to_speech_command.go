@@ -3,9 +3,11 @@ package command
 import (
 	"context"
 
+	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/audio"
 	"github.com/mjiee/world-news/backend/pkg/pathx"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
 	"github.com/mjiee/world-news/backend/service"
 )
 
@@ -45,6 +47,8 @@ func (c *TextToSpeechCommand) Execute(ctx context.Context) (string, error) {
 		c.script.Format = audio.WAV
 	}
 
+	ctx = usagex.WithLabel(ctx, &usagex.Label{Feature: string(valueobject.AiFeaturePodcast), BatchNo: c.batchNo})
+
 	resp, err := ttsClient.TextToSpeech(ctx, c.script)
 	if err != nil {
 		return "", err
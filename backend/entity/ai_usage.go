@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// AiUsage represents the token or character usage of an ai call and its cost.
+type AiUsage struct {
+	Id               uint
+	Feature          valueobject.AiFeature
+	Kind             usagex.Kind
+	Platform         string
+	Model            string
+	BatchNo          string
+	StageId          uint
+	NewsId           uint
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Characters       int
+	Cost             float64 // priced when recorded, later price changes do not apply
+	CreatedAt        time.Time
+}
+
+// NewAiUsage creates a new AiUsage entity priced with the pricing.
+func NewAiUsage(record *usagex.Record, pricing *valueobject.AiPricing) *AiUsage {
+	return &AiUsage{
+		Feature:          valueobject.AiFeature(record.Feature),
+		Kind:             record.Kind,
+		Platform:         record.Platform,
+		Model:            record.Model,
+		BatchNo:          record.BatchNo,
+		StageId:          record.StageId,
+		NewsId:           record.NewsId,
+		PromptTokens:     record.PromptTokens,
+		CompletionTokens: record.CompletionTokens,
+		TotalTokens:      record.TotalTokens,
+		Characters:       record.Characters,
+		Cost: pricing.Cost(record.Platform, record.Model, record.PromptTokens, record.CompletionTokens,
+			record.Characters),
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewAiUsageFromModel converts an AiUsageModel to an AiUsage entity.
+func NewAiUsageFromModel(m *model.AiUsage) *AiUsage {
+	return &AiUsage{
+		Id:               m.ID,
+		Feature:          valueobject.AiFeature(m.Feature),
+		Kind:             usagex.Kind(m.Kind),
+		Platform:         m.Platform,
+		Model:            m.Model,
+		BatchNo:          m.BatchNo,
+		StageId:          m.StageId,
+		NewsId:           m.NewsId,
+		PromptTokens:     m.PromptTokens,
+		CompletionTokens: m.CompletionTokens,
+		TotalTokens:      m.TotalTokens,
+		Characters:       m.Characters,
+		Cost:             m.Cost,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// ToModel converts the AiUsage entity to an AiUsageModel.
+func (a *AiUsage) ToModel() *model.AiUsage {
+	return &model.AiUsage{
+		ID:               a.Id,
+		Feature:          string(a.Feature),
+		Kind:             string(a.Kind),
+		Platform:         a.Platform,
+		Model:            a.Model,
+		BatchNo:          a.BatchNo,
+		StageId:          a.StageId,
+		NewsId:           a.NewsId,
+		PromptTokens:     a.PromptTokens,
+		CompletionTokens: a.CompletionTokens,
+		TotalTokens:      a.TotalTokens,
+		Characters:       a.Characters,
+		Cost:             a.Cost,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+// StatKey returns the key of the usage in the statistics of the dimension.
+func (a *AiUsage) StatKey(dimension valueobject.UsageDimension) string {
+	switch dimension {
+	case valueobject.UsageByModel:
+		if a.Platform == "" {
+			return a.Model
+		}
+
+		return a.Platform + "/" + a.Model
+	case valueobject.UsageByFeature:
+		return string(a.Feature)
+	default:
+		return valueobject.AnalyticsDay(a.CreatedAt)
+	}
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// ChatSession represents a conversation about one or more news.
+type ChatSession struct {
+	Id        uint
+	Title     string
+	NewsIds   []uint // news used as the context of the conversation
+	Messages  []*ChatMessage
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ChatMessage represents a message of the chat session.
+type ChatMessage struct {
+	Id        uint
+	SessionId uint
+	Role      valueobject.ChatRole
+	Content   string
+	CreatedAt time.Time
+}
+
+// NewChatSession creates a new ChatSession entity.
+func NewChatSession(title string, newsIds []uint) *ChatSession {
+	return &ChatSession{
+		Title:   title,
+		NewsIds: newsIds,
+	}
+}
+
+// NewChatSessionFromModel converts a ChatSessionModel to a ChatSession entity.
+func NewChatSessionFromModel(m *model.ChatSession) (*ChatSession, error) {
+	if m == nil {
+		return nil, errorx.ChatSessionNotFound
+	}
+
+	var newsIds []uint
+
+	if err := json.Unmarshal([]byte(m.NewsIds), &newsIds); err != nil {
+		return nil, errors.WithMessagef(err, "chatSessionId: %d", m.ID)
+	}
+
+	return &ChatSession{
+		Id:        m.ID,
+		Title:     m.Title,
+		NewsIds:   newsIds,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// ToModel converts the ChatSession entity to a ChatSessionModel.
+func (c *ChatSession) ToModel() (*model.ChatSession, error) {
+	if c == nil {
+		return nil, errorx.ChatSessionNotFound
+	}
+
+	newsIds, err := json.Marshal(c.NewsIds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.ChatSession{
+		ID:        c.Id,
+		Title:     c.Title,
+		NewsIds:   string(newsIds),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}, nil
+}
+
+// NewChatMessage creates a new ChatMessage entity.
+func NewChatMessage(sessionId uint, role valueobject.ChatRole, content string) *ChatMessage {
+	return &ChatMessage{
+		SessionId: sessionId,
+		Role:      role,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewChatMessageFromModel converts a ChatMessageModel to a ChatMessage entity.
+func NewChatMessageFromModel(m *model.ChatMessage) *ChatMessage {
+	return &ChatMessage{
+		Id:        m.ID,
+		SessionId: m.SessionId,
+		Role:      valueobject.ChatRole(m.Role),
+		Content:   m.Content,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ToModel converts the ChatMessage entity to a ChatMessageModel.
+func (c *ChatMessage) ToModel() *model.ChatMessage {
+	return &model.ChatMessage{
+		ID:        c.Id,
+		SessionId: c.SessionId,
+		Role:      string(c.Role),
+		Content:   c.Content,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/mjiee/world-news/backend/pkg/ner"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NamedEntity represents a person, organization or location mentioned by the news.
+type NamedEntity struct {
+	Id        uint
+	Type      ner.EntityType
+	Name      string
+	Mentions  int64 // number of news mentioning the entity
+	CreatedAt time.Time
+}
+
+// NewNamedEntityFromModel converts a NamedEntityModel to a NamedEntity entity.
+func NewNamedEntityFromModel(m *model.NamedEntity) *NamedEntity {
+	return &NamedEntity{
+		Id:        m.ID,
+		Type:      ner.EntityType(m.Type),
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// NewNamedEntityModel creates the model of the extracted entity.
+func NewNamedEntityModel(item *ner.Entity) *model.NamedEntity {
+	return &model.NamedEntity{
+		Type:      string(item.Type),
+		Key:       ner.Key(item.Name),
+		Name:      ner.Normalize(item.Name),
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsAnalysis represents the ai analysis of one or more news.
+type NewsAnalysis struct {
+	Id        uint
+	Type      valueobject.AnalysisType
+	Title     string
+	Language  string
+	NewsIds   []uint // analyzed news
+	Critique  *valueobject.ComparativeCritique
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewComparativeCritique creates a new comparative critique NewsAnalysis entity.
+func NewComparativeCritique(title, language string, newsIds []uint,
+	critique *valueobject.ComparativeCritique) *NewsAnalysis {
+	return &NewsAnalysis{
+		Type:     valueobject.ComparativeCritiqueAnalysis,
+		Title:    title,
+		Language: language,
+		NewsIds:  newsIds,
+		Critique: critique,
+	}
+}
+
+// NewNewsAnalysisFromModel converts a NewsAnalysisModel to a NewsAnalysis entity.
+func NewNewsAnalysisFromModel(m *model.NewsAnalysis) (*NewsAnalysis, error) {
+	if m == nil {
+		return nil, errorx.NewsAnalysisNotFound
+	}
+
+	var (
+		newsIds  []uint
+		critique *valueobject.ComparativeCritique
+	)
+
+	if err := json.Unmarshal([]byte(m.NewsIds), &newsIds); err != nil {
+		return nil, errors.WithMessagef(err, "newsAnalysisId: %d", m.ID)
+	}
+
+	if m.Result != "" {
+		if err := json.Unmarshal([]byte(m.Result), &critique); err != nil {
+			return nil, errors.WithMessagef(err, "newsAnalysisId: %d", m.ID)
+		}
+	}
+
+	return &NewsAnalysis{
+		Id:        m.ID,
+		Type:      valueobject.AnalysisType(m.Type),
+		Title:     m.Title,
+		Language:  m.Language,
+		NewsIds:   newsIds,
+		Critique:  critique,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// ToModel converts the NewsAnalysis entity to a NewsAnalysisModel.
+func (n *NewsAnalysis) ToModel() (*model.NewsAnalysis, error) {
+	if n == nil {
+		return nil, errorx.NewsAnalysisNotFound
+	}
+
+	newsIds, err := json.Marshal(n.NewsIds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result, err := json.Marshal(n.Critique)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.NewsAnalysis{
+		ID:        n.Id,
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Language:  n.Language,
+		NewsIds:   string(newsIds),
+		Result:    string(result),
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}, nil
+}
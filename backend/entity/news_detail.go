@@ -35,6 +35,22 @@ type NewsDetail struct {
 	Scraped     bool
 	Favorited   bool
 	CreatedAt   time.Time
+
+	ScrapeAttempts int       // consecutive failed scrapes
+	NextScrapeAt   time.Time // next retry time of the failed scrape
+
+	EntityExtracted bool // named entities extracted
+
+	Summary *NewsSummary   // cached ai summary, only attached in list queries
+	Related []*RelatedNews // related articles, only attached to the news detail
+}
+
+// RelatedNews represents an article related to the news.
+type RelatedNews struct {
+	News           *NewsDetail
+	Score          float64
+	SharedEntities int
+	Reasons        []valueobject.RelatedReason
 }
 
 // NewNewsDetailFromModel converts a NewsDetailModel to a NewsDetail entity.
@@ -56,22 +72,31 @@ func NewNewsDetailFromModel(m *model.NewsDetail) (*NewsDetail, error) {
 		return nil, errors.WithMessagef(err, "newsId: %d", m.ID)
 	}
 
-	return &NewsDetail{
-		Id:          m.ID,
-		RecordId:    m.RecordId,
-		Source:      m.Source,
-		Topic:       m.Topic,
-		Title:       m.Title,
-		Author:      m.Author,
-		Link:        m.Link,
-		Contents:    contents,
-		Images:      images,
-		Video:       m.Video,
-		Scraped:     m.Scraped,
-		PublishedAt: m.PublishedAt,
-		Favorited:   m.Favorited,
-		CreatedAt:   m.CreatedAt,
-	}, nil
+	news := &NewsDetail{
+		Id:             m.ID,
+		RecordId:       m.RecordId,
+		Source:         m.Source,
+		Topic:          m.Topic,
+		Title:          m.Title,
+		Author:         m.Author,
+		Link:           m.Link,
+		Contents:       contents,
+		Images:         images,
+		Video:          m.Video,
+		Scraped:        m.Scraped,
+		PublishedAt:    m.PublishedAt,
+		Favorited:      m.Favorited,
+		CreatedAt:      m.CreatedAt,
+		ScrapeAttempts: m.ScrapeAttempts,
+
+		EntityExtracted: m.EntityExtracted,
+	}
+
+	if m.NextScrapeAt != nil {
+		news.NextScrapeAt = *m.NextScrapeAt
+	}
+
+	return news, nil
 }
 
 // NewNewsDetailFromTopicLink creates a NewsDetail entity from a NewsTopicLink.
@@ -99,22 +124,64 @@ func (n *NewsDetail) ToModel() (*model.NewsDetail, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	return &model.NewsDetail{
-		ID:          n.Id,
-		RecordId:    n.RecordId,
-		Source:      n.Source,
-		Topic:       n.Topic,
-		Title:       n.Title,
-		Author:      n.Author,
-		Link:        n.Link,
-		Contents:    string(contents),
-		Images:      string(images),
-		Video:       n.Video,
-		Scraped:     n.Scraped,
-		PublishedAt: n.PublishedAt,
-		Favorited:   n.Favorited,
-		CreatedAt:   n.CreatedAt,
-	}, nil
+	data := &model.NewsDetail{
+		ID:             n.Id,
+		RecordId:       n.RecordId,
+		Source:         n.Source,
+		Topic:          n.Topic,
+		Title:          n.Title,
+		Author:         n.Author,
+		Link:           n.Link,
+		Contents:       string(contents),
+		Images:         string(images),
+		Video:          n.Video,
+		Scraped:        n.Scraped,
+		PublishedAt:    n.PublishedAt,
+		Favorited:      n.Favorited,
+		CreatedAt:      n.CreatedAt,
+		ScrapeAttempts: n.ScrapeAttempts,
+
+		EntityExtracted: n.EntityExtracted,
+	}
+
+	if !n.NextScrapeAt.IsZero() {
+		data.NextScrapeAt = &n.NextScrapeAt
+	}
+
+	return data, nil
+}
+
+// CanScrape checks if the news detail page can be scraped now.
+func (n *NewsDetail) CanScrape(now time.Time) bool {
+	if n.ScrapeAttempts >= valueobject.MaxScrapeAttempts {
+		return false
+	}
+
+	return n.NextScrapeAt.IsZero() || !n.NextScrapeAt.After(now)
+}
+
+// ScrapeFailed records a failed scrape and schedules the next retry.
+func (n *NewsDetail) ScrapeFailed(now time.Time) {
+	n.ScrapeAttempts++
+	n.NextScrapeAt = now.Add(valueobject.ScrapeRetryDelay(n.ScrapeAttempts))
+}
+
+// ScrapeFailing checks if the detail page failed to be scraped, the news only has the summary of the list page.
+func (n *NewsDetail) ScrapeFailing() bool {
+	return !n.Scraped && n.ScrapeAttempts > 0
+}
+
+// ScrapeSucceeded applies the scraped page to the news detail.
+func (n *NewsDetail) ScrapeSucceeded(page *NewsDetail) {
+	n.Contents = page.Contents
+	n.Images = page.Images
+	n.Scraped = true
+	n.ScrapeAttempts = 0
+	n.NextScrapeAt = time.Time{}
+
+	if page.Author != "" {
+		n.Author = page.Author
+	}
 }
 
 // BuildPrompt builds the prompt for the podcast script.
@@ -126,6 +193,11 @@ func (n *NewsDetail) BuildPrompt() string {
 	return fmt.Sprintf("\nNews title: %s\nNews content: \n%s", n.Title, strings.Join(n.Contents, "\n"))
 }
 
+// BuildText builds the plain text of the title and contents.
+func (n *NewsDetail) BuildText() string {
+	return n.Title + "\n" + strings.Join(n.Contents, "\n")
+}
+
 // IsValid checks if the news detail is valid.
 func (n *NewsDetail) IsValid(minPublishTime time.Time) bool {
 	return isValidPublishTime(n.PublishedAt, minPublishTime) && isNewsTitle(n.Title) && n.Link != ""
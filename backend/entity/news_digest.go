@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/export"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsDigest represents the daily digest of the news.
+type NewsDigest struct {
+	Id        uint
+	Date      string // the day of the digested news, 2006-01-02
+	Title     string
+	Language  string
+	Status    valueobject.DigestStatus
+	Sections  []*valueobject.DigestSection
+	Reason    string // failure reason
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewNewsDigest creates a new NewsDigest entity.
+func NewNewsDigest(date, language string) *NewsDigest {
+	return &NewsDigest{
+		Date:     date,
+		Title:    fmt.Sprintf("News Digest %s", date),
+		Language: language,
+		Status:   valueobject.DigestStatusProcessing,
+	}
+}
+
+// NewNewsDigestFromModel converts a NewsDigestModel to a NewsDigest entity.
+func NewNewsDigestFromModel(m *model.NewsDigest) (*NewsDigest, error) {
+	if m == nil {
+		return nil, errorx.NewsDigestNotFound
+	}
+
+	var sections []*valueobject.DigestSection
+
+	if m.Sections != "" {
+		if err := json.Unmarshal([]byte(m.Sections), &sections); err != nil {
+			return nil, errors.WithMessagef(err, "newsDigestId: %d", m.ID)
+		}
+	}
+
+	return &NewsDigest{
+		Id:        m.ID,
+		Date:      m.Date,
+		Title:     m.Title,
+		Language:  m.Language,
+		Status:    valueobject.DigestStatus(m.Status),
+		Sections:  sections,
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// ToModel converts the NewsDigest entity to a NewsDigestModel.
+func (n *NewsDigest) ToModel() (*model.NewsDigest, error) {
+	if n == nil {
+		return nil, errorx.NewsDigestNotFound
+	}
+
+	sections, err := json.Marshal(n.Sections)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.NewsDigest{
+		ID:        n.Id,
+		Date:      n.Date,
+		Title:     n.Title,
+		Language:  n.Language,
+		Status:    string(n.Status),
+		Sections:  string(sections),
+		Reason:    n.Reason,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}, nil
+}
+
+// Restart resets the digest to be generated again.
+func (n *NewsDigest) Restart(language string) {
+	n.Language = language
+	n.Status = valueobject.DigestStatusProcessing
+	n.Sections = nil
+	n.Reason = ""
+}
+
+// Completed sets the digest sections and marks it as completed.
+func (n *NewsDigest) Completed(sections []*valueobject.DigestSection) {
+	n.Status = valueobject.DigestStatusCompleted
+	n.Sections = sections
+}
+
+// Failed marks the digest as failed.
+func (n *NewsDigest) Failed(err error) {
+	n.Status = valueobject.DigestStatusFailed
+	n.Reason = err.Error()
+}
+
+// ToDocument converts the digest into an export document with a chapter per topic.
+func (n *NewsDigest) ToDocument() *export.Document {
+	doc := &export.Document{
+		Title:     n.Title,
+		Language:  n.Language,
+		CreatedAt: n.UpdatedAt,
+	}
+
+	if doc.CreatedAt.IsZero() {
+		doc.CreatedAt = time.Now()
+	}
+
+	for _, section := range n.Sections {
+		chapter := &export.Chapter{Title: section.Topic}
+
+		for _, item := range section.Items {
+			chapter.Articles = append(chapter.Articles, &export.Article{
+				Title:       item.Title,
+				Source:      item.Source,
+				Topic:       section.Topic,
+				Link:        item.Link,
+				PublishedAt: item.PublishedAt,
+				Contents:    []string{item.Summary},
+			})
+		}
+
+		doc.Chapters = append(doc.Chapters, chapter)
+	}
+
+	return doc
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/vectorx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsEmbedding represents the embedding vector of the news.
+type NewsEmbedding struct {
+	Id        uint
+	NewsId    uint
+	Model     string
+	Vector    []float64
+	CreatedAt time.Time
+}
+
+// NewNewsEmbedding creates a new NewsEmbedding entity.
+func NewNewsEmbedding(newsId uint, model string, vector []float64) *NewsEmbedding {
+	return &NewsEmbedding{
+		NewsId: newsId,
+		Model:  model,
+		Vector: vector,
+	}
+}
+
+// NewNewsEmbeddingFromModel converts a NewsEmbeddingModel to a NewsEmbedding entity.
+func NewNewsEmbeddingFromModel(m *model.NewsEmbedding) (*NewsEmbedding, error) {
+	vector, err := vectorx.Decode(m.Vector)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "newsEmbeddingId: %d", m.ID)
+	}
+
+	return &NewsEmbedding{
+		Id:        m.ID,
+		NewsId:    m.NewsId,
+		Model:     m.Model,
+		Vector:    vector,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+// ToModel converts the NewsEmbedding entity to a NewsEmbeddingModel.
+func (n *NewsEmbedding) ToModel() *model.NewsEmbedding {
+	return &model.NewsEmbedding{
+		ID:         n.Id,
+		NewsId:     n.NewsId,
+		Model:      n.Model,
+		Dimensions: len(n.Vector),
+		Vector:     vectorx.Encode(n.Vector),
+		CreatedAt:  n.CreatedAt,
+	}
+}
+
+// NewsMatch represents a news matched by the semantic search.
+type NewsMatch struct {
+	News  *NewsDetail
+	Score float64 // cosine similarity
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsRevision represents a scraped revision of the news content.
+type NewsRevision struct {
+	Id        uint
+	NewsId    uint
+	Revision  int
+	Title     string
+	Author    string
+	Contents  []string
+	CreatedAt time.Time
+}
+
+// NewNewsRevision creates the next revision of the news from the scraped page.
+func NewNewsRevision(newsId uint, page *NewsDetail, latest *NewsRevision) *NewsRevision {
+	revision := &NewsRevision{
+		NewsId:    newsId,
+		Revision:  1,
+		Title:     page.Title,
+		Author:    page.Author,
+		Contents:  page.Contents,
+		CreatedAt: time.Now(),
+	}
+
+	if latest != nil {
+		revision.Revision = latest.Revision + 1
+	}
+
+	return revision
+}
+
+// NewNewsRevisionFromModel converts a NewsRevisionModel to a NewsRevision entity.
+func NewNewsRevisionFromModel(m *model.NewsRevision) (*NewsRevision, error) {
+	if m == nil {
+		return nil, errorx.NewsRevisionNotFound
+	}
+
+	var contents []string
+
+	if err := json.Unmarshal([]byte(m.Contents), &contents); err != nil {
+		return nil, errors.WithMessagef(err, "newsRevisionId: %d", m.ID)
+	}
+
+	return &NewsRevision{
+		Id:        m.ID,
+		NewsId:    m.NewsId,
+		Revision:  m.Revision,
+		Title:     m.Title,
+		Author:    m.Author,
+		Contents:  contents,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+// ToModel converts the NewsRevision entity to a NewsRevisionModel.
+func (n *NewsRevision) ToModel() (*model.NewsRevision, error) {
+	if n == nil {
+		return nil, errorx.NewsRevisionNotFound
+	}
+
+	contents, err := json.Marshal(n.Contents)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.NewsRevision{
+		ID:        n.Id,
+		NewsId:    n.NewsId,
+		Revision:  n.Revision,
+		Title:     n.Title,
+		Author:    n.Author,
+		Contents:  string(contents),
+		CreatedAt: n.CreatedAt,
+	}, nil
+}
+
+// Changed checks if the revision differs from the other revision.
+func (n *NewsRevision) Changed(other *NewsRevision) bool {
+	if other == nil {
+		return true
+	}
+
+	return n.Title != other.Title || n.Author != other.Author || !slices.Equal(n.Contents, other.Contents)
+}
+
+// NewsRevisionDiff represents the difference between two revisions of the news.
+type NewsRevisionDiff struct {
+	From     *NewsRevision
+	To       *NewsRevision
+	Title    []*textx.DiffLine
+	Author   []*textx.DiffLine
+	Contents []*textx.DiffLine
+}
+
+// NewNewsRevisionDiff compares two revisions of the news.
+func NewNewsRevisionDiff(from, to *NewsRevision) *NewsRevisionDiff {
+	return &NewsRevisionDiff{
+		From:     from,
+		To:       to,
+		Title:    textx.Diff([]string{from.Title}, []string{to.Title}),
+		Author:   textx.Diff([]string{from.Author}, []string{to.Author}),
+		Contents: textx.Diff(from.Contents, to.Contents),
+	}
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsSummary represents the ai summary of the news in a language.
+type NewsSummary struct {
+	Id        uint
+	NewsId    uint
+	Language  string
+	Short     string // one sentence summary
+	Long      string // paragraph summary
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewNewsSummary creates a new NewsSummary entity from the model output.
+func NewNewsSummary(newsId uint, language string, result *valueobject.NewsSummaryResult) *NewsSummary {
+	return &NewsSummary{
+		NewsId:   newsId,
+		Language: language,
+		Short:    result.Short,
+		Long:     result.Long,
+	}
+}
+
+// NewNewsSummaryFromModel converts a NewsSummaryModel to a NewsSummary entity.
+func NewNewsSummaryFromModel(m *model.NewsSummary) (*NewsSummary, error) {
+	if m == nil {
+		return nil, errorx.NewsSummaryNotFound
+	}
+
+	return &NewsSummary{
+		Id:        m.ID,
+		NewsId:    m.NewsId,
+		Language:  m.Language,
+		Short:     m.Short,
+		Long:      m.Long,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// ToModel converts the NewsSummary entity to a NewsSummaryModel.
+func (n *NewsSummary) ToModel() (*model.NewsSummary, error) {
+	if n == nil {
+		return nil, errorx.NewsSummaryNotFound
+	}
+
+	return &model.NewsSummary{
+		ID:        n.Id,
+		NewsId:    n.NewsId,
+		Language:  n.Language,
+		Short:     n.Short,
+		Long:      n.Long,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}, nil
+}
@@ -1,19 +1,168 @@
 package entity
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/mjiee/gokit"
+
 	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+	"github.com/mjiee/world-news/backend/repository/model"
 )
 
-// Podcast represents the podcast entity.
+// Podcast represents the podcast episode published from a completed podcast task.
 type Podcast struct {
-	Id        uint
-	News      *NewsDetail
-	Script    string
-	Language  string
-	Audio     *valueobject.PodcastAudio
-	Style     string
-	TtsAi     *valueobject.TaskAi
-	CreatedAt time.Time
+	Id          uint
+	BatchNo     string // the published podcast task
+	NewsIds     []uint // the source news
+	Title       string
+	Description string // show notes
+	Script      string // transcript of the scripts
+	Language    string
+	Audio       *valueobject.PodcastAudio
+	Duration    int // s
+	Style       string
+	TtsAi       *valueobject.TaskAi
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewPodcastFromTask creates the podcast episode of the completed task, the audio is the last text to speech stage.
+func NewPodcastFromTask(task *PodcastTask, style string) (*Podcast, error) {
+	if task == nil || !task.Result.IsCompleted() {
+		return nil, errorx.TaskNotCompleted
+	}
+
+	ttsStage := gokit.SliceFindLast(task.Stages, func(s *valueobject.TaskStage) bool {
+		return s.Stage == valueobject.TaskStageTextToSpeech && s.Status == valueobject.StageStatusCompleted &&
+			s.Audio != nil && s.Audio.Url != ""
+	})
+	if ttsStage == nil {
+		return nil, errorx.TaskNotCompleted
+	}
+
+	podcast := &Podcast{
+		BatchNo:  task.BatchNo,
+		Title:    task.Title,
+		Language: task.Language,
+		Audio: &valueobject.PodcastAudio{Voices: ttsStage.Audio.Voices, Format: ttsStage.Audio.Format,
+			Url: ttsStage.Audio.Url},
+		Duration: ttsStage.Audio.Duration,
+		Style:    style,
+		TtsAi:    ttsStage.TaskAi,
+	}
+
+	if task.News != nil {
+		podcast.NewsIds = []uint{task.News.Id}
+	}
+
+	if merge := task.GetStage(valueobject.TaskStageMerge); merge != nil && merge.Extra != nil {
+		podcast.NewsIds = merge.Extra.NewsIds
+	}
+
+	if scripted := task.GetStage(valueobject.TaskStageScripted); scripted != nil && scripted.Audio != nil {
+		podcast.Script = buildTranscript(scripted.Audio.Voices, scripted.Audio.Scripts)
+	}
+
+	return podcast, nil
+}
+
+// buildTranscript builds the transcript of the scripts, each line starting with the name of the speaker.
+func buildTranscript(voices []*ttsai.Voice, scripts []*ttsai.TtsScript) string {
+	names := make(map[string]string, len(voices))
+
+	for _, voice := range voices {
+		names[voice.Id] = voice.Name
+	}
+
+	lines := make([]string, 0, len(scripts))
+
+	for _, script := range scripts {
+		if script.Text == "" {
+			continue
+		}
+
+		if name := names[script.Speaker]; name != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", name, script.Text))
+		} else {
+			lines = append(lines, script.Text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// NewPodcastFromModel converts a model.Podcast to a Podcast entity.
+func NewPodcastFromModel(m *model.Podcast) (*Podcast, error) {
+	if m == nil {
+		return nil, errorx.PodcastNotFound
+	}
+
+	return &Podcast{
+		Id:          m.ID,
+		BatchNo:     m.BatchNo,
+		NewsIds:     gokit.UnmarshalSafe[[]uint](m.NewsIds),
+		Title:       m.Title,
+		Description: m.Description,
+		Script:      m.Script,
+		Language:    m.Language,
+		Audio:       gokit.UnmarshalSafe[*valueobject.PodcastAudio](m.Audio),
+		Duration:    m.Duration,
+		Style:       m.Style,
+		TtsAi:       gokit.UnmarshalSafe[*valueobject.TaskAi](m.TtsAi),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}, nil
+}
+
+// ToModel converts the Podcast entity to a model.Podcast.
+func (p *Podcast) ToModel() *model.Podcast {
+	data := &model.Podcast{
+		ID:          p.Id,
+		BatchNo:     p.BatchNo,
+		NewsIds:     gokit.MarshalSafe(p.NewsIds),
+		Title:       p.Title,
+		Description: p.Description,
+		Script:      p.Script,
+		Language:    p.Language,
+		Audio:       gokit.MarshalSafe(p.Audio),
+		Duration:    p.Duration,
+		Style:       p.Style,
+		TtsAi:       gokit.MarshalSafe(p.TtsAi),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+
+	if len(p.NewsIds) > 0 {
+		data.NewsId = p.NewsIds[0]
+	}
+
+	return data
+}
+
+// Update updates the title and the show notes of the podcast, the empty ones are kept.
+func (p *Podcast) Update(title, description string) {
+	if title != "" {
+		p.Title = title
+	}
+
+	if description != "" {
+		p.Description = description
+	}
+
+	p.UpdatedAt = time.Now()
+}
+
+// BuildShowNotes builds the default show notes of the podcast from its source news.
+func (p *Podcast) BuildShowNotes(news []*NewsDetail) string {
+	notes := make([]string, 0, len(news))
+
+	for _, item := range news {
+		notes = append(notes, fmt.Sprintf("- %s (%s) %s", item.Title, item.Source, item.Link))
+	}
+
+	return strings.Join(notes, "\n")
 }
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// PodcastJob represents a background job of a podcast task, persisted so that it survives an app restart.
+type PodcastJob struct {
+	Id         uint
+	Kind       valueobject.JobKind
+	BatchNo    string
+	Status     valueobject.JobStatus
+	Attempts   int       // number of runs started
+	Worker     string    // the worker running the job
+	LeaseUntil time.Time // the running job is stale after it without a heartbeat
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewPodcastJob creates a queued job of the task.
+func NewPodcastJob(kind valueobject.JobKind, batchNo string) *PodcastJob {
+	return &PodcastJob{
+		Kind:      kind,
+		BatchNo:   batchNo,
+		Status:    valueobject.JobStatusQueued,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// NewPodcastJobFromModel converts a PodcastJob model to a PodcastJob entity.
+func NewPodcastJobFromModel(m *model.PodcastJob) *PodcastJob {
+	return &PodcastJob{
+		Id:         m.ID,
+		Kind:       valueobject.JobKind(m.Kind),
+		BatchNo:    m.BatchNo,
+		Status:     valueobject.JobStatus(m.Status),
+		Attempts:   m.Attempts,
+		Worker:     m.Worker,
+		LeaseUntil: m.LeaseUntil,
+		Error:      m.Error,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// ToModel converts the PodcastJob entity to a PodcastJob model.
+func (j *PodcastJob) ToModel() *model.PodcastJob {
+	return &model.PodcastJob{
+		ID:         j.Id,
+		Kind:       string(j.Kind),
+		BatchNo:    j.BatchNo,
+		Status:     string(j.Status),
+		Attempts:   j.Attempts,
+		Worker:     j.Worker,
+		LeaseUntil: j.LeaseUntil,
+		Error:      j.Error,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+	}
+}
+
+// Requeue puts the interrupted job back to the queue.
+func (j *PodcastJob) Requeue() {
+	j.Status = valueobject.JobStatusQueued
+	j.Worker = ""
+	j.LeaseUntil = time.Time{}
+}
+
+// Finish marks the job as completed, cancelled if its run is cancelled, or failed with the error.
+func (j *PodcastJob) Finish(err error) {
+	switch {
+	case err == nil:
+		j.Status = valueobject.JobStatusCompleted
+	case errors.Is(err, context.Canceled):
+		j.Status = valueobject.JobStatusCancelled
+	default:
+		j.Status = valueobject.JobStatusFailed
+		j.Error = err.Error()
+	}
+}
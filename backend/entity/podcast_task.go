@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/mjiee/gokit"
@@ -24,16 +26,37 @@ type PodcastTask struct {
 	UpdatedAt time.Time
 }
 
+var (
+	batchNoMu   sync.Mutex
+	lastBatchAt time.Time
+)
+
 // NewPodcastTask creates a new PodcastTask instance.
 func NewPodcastTask(news *NewsDetail, language string) *PodcastTask {
 	return &PodcastTask{
 		News:     news,
 		Language: language,
-		BatchNo:  time.Now().Format("060102150405"),
+		BatchNo:  newBatchNo(),
 		Stages:   make([]*valueobject.TaskStage, 0),
 	}
 }
 
+// newBatchNo returns the batch no of the creation time in microseconds. It increases within the process,
+// so that the tasks created at the same time get different batch nos.
+func newBatchNo() string {
+	batchNoMu.Lock()
+	defer batchNoMu.Unlock()
+
+	now := time.Now().Truncate(time.Microsecond)
+	if !now.After(lastBatchAt) {
+		now = lastBatchAt.Add(time.Microsecond)
+	}
+
+	lastBatchAt = now
+
+	return fmt.Sprintf("%s%06d", now.Format("060102150405"), now.Nanosecond()/int(time.Microsecond))
+}
+
 // NewPodcastTaskFromModel converts a model.PodcastTask to a PodcastTask.
 func NewPodcastTaskFromModel(news *model.NewsDetail, tasks []*model.PodcastTask) (*PodcastTask, error) {
 	slices.SortFunc(tasks, func(a, b *model.PodcastTask) int { return int(a.ID - b.ID) })
@@ -111,6 +134,10 @@ func (t *PodcastTask) ToModel() ([]*model.PodcastTask, error) {
 			Audio:     gokit.MarshalSafe(i.Audio),
 			TaskAi:    gokit.MarshalSafe(i.TaskAi),
 			Extra:     gokit.MarshalSafe(i.Extra),
+			Approval:  gokit.MarshalSafe(i.Approval),
+			Classify:  gokit.MarshalSafe(i.Classify),
+			Attempts:  i.Attempts,
+			Failures:  gokit.MarshalSafe(i.Failures),
 			CreatedAt: i.CreatedAt,
 			UpdatedAt: i.UpdatedAt,
 		}
@@ -156,6 +183,36 @@ func (t *PodcastTask) GetStage(stage valueobject.TaskStageName) *valueobject.Tas
 	})
 }
 
+// Cancel cancels the stages of the task processing or waiting for review
+func (t *PodcastTask) Cancel() {
+	for _, stage := range t.Stages {
+		if stage.Status.IsProcessing() || stage.Status == valueobject.StageStatusReview {
+			stage.Cancel()
+			t.Result = valueobject.TaskResultCancelled
+		}
+	}
+}
+
+// Retry resets the failed or cancelled stage to processing, with the following stages cancelled in the same run
+func (t *PodcastTask) Retry(stageId uint) error {
+	idx := slices.IndexFunc(t.Stages, func(s *valueobject.TaskStage) bool { return s.Id == stageId })
+	if idx < 0 || !t.Stages[idx].IsRetryable() {
+		return errorx.TaskStageNotRetryable
+	}
+
+	t.Stages[idx].Retry()
+
+	for _, stage := range t.Stages[idx+1:] {
+		if stage.Status == valueobject.StageStatusCancelled {
+			stage.Retry()
+		}
+	}
+
+	t.Result = ""
+
+	return nil
+}
+
 // VerifyTask verify task
 func (t *PodcastTask) VerifyTask() error {
 	if len(t.Stages) == 0 {
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// PromptTemplate represents a version of a named prompt template in a language.
+type PromptTemplate struct {
+	Id        uint
+	Name      valueobject.PromptName
+	Language  string // empty for the default variant used by all languages
+	Version   int
+	Content   string // text/template rendered with valueobject.PromptVars
+	Note      string
+	CreatedAt time.Time
+}
+
+// NewPromptTemplate creates a new PromptTemplate entity, the content must be a valid template.
+func NewPromptTemplate(name, language, content, note string) (*PromptTemplate, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || len(name) > valueobject.MaxPromptNameLength || strings.TrimSpace(content) == "" {
+		return nil, errorx.ParamsError
+	}
+
+	if _, err := valueobject.ParsePrompt(name, content); err != nil {
+		return nil, err
+	}
+
+	return &PromptTemplate{
+		Name:     valueobject.PromptName(name),
+		Language: strings.TrimSpace(language),
+		Content:  content,
+		Note:     note,
+	}, nil
+}
+
+// NewPromptTemplateFromModel converts a PromptTemplateModel to a PromptTemplate entity.
+func NewPromptTemplateFromModel(m *model.PromptTemplate) *PromptTemplate {
+	return &PromptTemplate{
+		Id:        m.ID,
+		Name:      valueobject.PromptName(m.Name),
+		Language:  m.Language,
+		Version:   m.Version,
+		Content:   m.Content,
+		Note:      m.Note,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ToModel converts the PromptTemplate entity to a PromptTemplateModel.
+func (p *PromptTemplate) ToModel() *model.PromptTemplate {
+	return &model.PromptTemplate{
+		ID:        p.Id,
+		Name:      string(p.Name),
+		Language:  p.Language,
+		Version:   p.Version,
+		Content:   p.Content,
+		Note:      p.Note,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// Render renders the template with the variables.
+func (p *PromptTemplate) Render(vars *valueobject.PromptVars) (string, error) {
+	return valueobject.RenderPrompt(string(p.Name), p.Content, vars)
+}
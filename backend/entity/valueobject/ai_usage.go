@@ -0,0 +1,141 @@
+package valueobject
+
+import (
+	"time"
+)
+
+// AiFeature represents the feature making the ai calls.
+type AiFeature string
+
+const (
+	AiFeatureSummary   AiFeature = "summary"
+	AiFeatureDigest    AiFeature = "digest"
+	AiFeatureCritique  AiFeature = "critique"
+	AiFeatureCompare   AiFeature = "compare"
+	AiFeatureChat      AiFeature = "chat"
+	AiFeatureEntities  AiFeature = "entities"
+	AiFeatureEmbedding AiFeature = "embedding"
+	AiFeatureSearch    AiFeature = "search"
+	AiFeaturePodcast   AiFeature = "podcast"
+)
+
+// UsageDimension represents the dimension of the ai usage statistics.
+type UsageDimension string
+
+const (
+	UsageByDay     UsageDimension = "day"
+	UsageByModel   UsageDimension = "model"
+	UsageByFeature UsageDimension = "feature"
+)
+
+// maxUsageDays is the maximum number of days of the ai usage statistics.
+const maxUsageDays = 366
+
+// priceUnit is the number of tokens or characters of a price.
+const priceUnit = 1_000_000
+
+// AiPricing represents the prices of the ai models and the monthly budget.
+type AiPricing struct {
+	Currency      string        `json:"currency,omitempty"`
+	MonthlyBudget float64       `json:"monthlyBudget,omitempty"` // new tasks are blocked once exceeded, 0 for no limit
+	Models        []*ModelPrice `json:"models,omitempty"`
+}
+
+// ModelPrice represents the prices of a model per million tokens or characters.
+type ModelPrice struct {
+	Platform        string  `json:"platform,omitempty"` // matches any platform if empty
+	Model           string  `json:"model"`
+	PromptPrice     float64 `json:"promptPrice,omitempty"`
+	CompletionPrice float64 `json:"completionPrice,omitempty"`
+	CharacterPrice  float64 `json:"characterPrice,omitempty"`
+}
+
+// Cost returns the cost of the usage, 0 if the model has no price.
+func (p *AiPricing) Cost(platform, model string, promptTokens, completionTokens, characters int) float64 {
+	var price *ModelPrice
+
+	for _, item := range p.Models {
+		if item.Model != model || (item.Platform != "" && item.Platform != platform) {
+			continue
+		}
+
+		// the price of the platform takes precedence
+		if price == nil || item.Platform != "" {
+			price = item
+		}
+	}
+
+	if price == nil {
+		return 0
+	}
+
+	return (float64(promptTokens)*price.PromptPrice + float64(completionTokens)*price.CompletionPrice +
+		float64(characters)*price.CharacterPrice) / priceUnit
+}
+
+// BudgetExceeded checks whether the cost of the month exceeds the monthly budget.
+func (p *AiPricing) BudgetExceeded(monthCost float64) bool {
+	return p.MonthlyBudget > 0 && monthCost >= p.MonthlyBudget
+}
+
+// MonthRange returns the start of the month of the time and the start of the next month.
+func MonthRange(t time.Time) (time.Time, time.Time) {
+	year, month, _ := t.Local().Date()
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+
+	return start, start.AddDate(0, 1, 0)
+}
+
+// QueryUsageParams represents the params of the ai usage statistics.
+type QueryUsageParams struct {
+	StartDate time.Time // first day, inclusive
+	EndDate   time.Time // last day, inclusive
+	Dimension UsageDimension
+	Feature   AiFeature
+	BatchNo   string
+}
+
+// Normalize fills the default values and limits the date range.
+func (p *QueryUsageParams) Normalize() *QueryUsageParams {
+	if p.EndDate.IsZero() {
+		p.EndDate = time.Now()
+	}
+
+	p.EndDate = truncateDay(p.EndDate)
+
+	if p.StartDate.IsZero() || p.StartDate.After(p.EndDate) {
+		p.StartDate, _ = MonthRange(p.EndDate)
+	}
+
+	p.StartDate = truncateDay(p.StartDate)
+
+	if p.EndDate.Sub(p.StartDate) >= maxUsageDays*day {
+		p.StartDate = p.EndDate.AddDate(0, 0, -(maxUsageDays - 1))
+	}
+
+	if p.Dimension == "" {
+		p.Dimension = UsageByDay
+	}
+
+	return p
+}
+
+// UsageStat represents the aggregated ai usage of a day, model or feature.
+type UsageStat struct {
+	Key              string  `json:"key"`
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	TotalTokens      int     `json:"totalTokens"`
+	Characters       int     `json:"characters"`
+	Cost             float64 `json:"cost"`
+}
+
+// AiBudget represents the ai cost of the current month against the monthly budget.
+type AiBudget struct {
+	Month         string  `json:"month"`
+	Currency      string  `json:"currency,omitempty"`
+	Cost          float64 `json:"cost"`
+	MonthlyBudget float64 `json:"monthlyBudget,omitempty"`
+	Exceeded      bool    `json:"exceeded"`
+}
@@ -0,0 +1,38 @@
+package valueobject
+
+import "fmt"
+
+const (
+	// MaxChatNews is the maximum number of news a chat session is anchored on.
+	MaxChatNews = 5
+
+	// MaxChatHistory is the maximum number of previous messages sent to the model.
+	MaxChatHistory = 20
+
+	// MaxChatArticleLength is the maximum length of each article sent to the model.
+	MaxChatArticleLength = 6000
+
+	// MaxChatTitleLength is the maximum length of the chat session title.
+	MaxChatTitleLength = 100
+)
+
+// ChatRole is the role of the chat message author.
+type ChatRole string
+
+const (
+	ChatRoleUser      ChatRole = "user"
+	ChatRoleAssistant ChatRole = "assistant"
+)
+
+// chatPrompt is the system prompt of the chat with articles.
+const chatPrompt = `You are a research assistant helping an analyst read news articles.
+Answer the questions using the articles below as the main source. If the articles do not contain the answer, ` +
+	`say so before adding any general knowledge, and never invent quotes or numbers. ` +
+	`Reply in the language of the question.
+
+%s`
+
+// BuildChatPrompt builds the system prompt of the chat with the article texts.
+func BuildChatPrompt(articles string) string {
+	return fmt.Sprintf(chatPrompt, articles)
+}
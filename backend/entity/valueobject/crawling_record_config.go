@@ -10,6 +10,7 @@ import (
 type CrawlingRecordConfig struct {
 	Sources []*NewsWebsite `json:"sources,omitempty"`
 	Topics  []string       `json:"topics,omitempty"`
+	Total   int64          `json:"total,omitempty"` // number of news to process by a background job
 }
 
 // NewCrawlingRecordConfig creates a new CrawlingRecordConfig.
@@ -6,4 +6,16 @@ type CrawlingRecordType string
 const (
 	CrawlingWebsite CrawlingRecordType = "crawlingWebsite"
 	CrawlingNews    CrawlingRecordType = "crawlingNews"
+
+	// background jobs over the crawled news
+	ExtractingEntities CrawlingRecordType = "extractingEntities"
+	SummarizingNews    CrawlingRecordType = "summarizingNews"
+	EmbeddingNews      CrawlingRecordType = "embeddingNews"
 )
+
+// CrawlingRecordTypes are the record types of crawling websites and news.
+var CrawlingRecordTypes = []CrawlingRecordType{CrawlingWebsite, CrawlingNews}
+
+func (t CrawlingRecordType) String() string {
+	return string(t)
+}
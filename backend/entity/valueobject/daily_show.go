@@ -0,0 +1,103 @@
+package valueobject
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// defaultShowLimit is the default number of articles in a daily show.
+	defaultShowLimit = 5
+
+	// maxShowLimit is the maximum number of articles in a daily show.
+	maxShowLimit = 10
+
+	// defaultShowHour is the default hour of the day to create the scheduled daily show.
+	defaultShowHour = 8
+
+	// defaultShowTitle is the default title of the daily show, followed by the date.
+	defaultShowTitle = "Daily show"
+
+	// ShowVoices is the number of the hosts of the daily show.
+	ShowVoices = 2
+)
+
+// showTopicWeight is the score of the article of a preferred topic
+const showTopicWeight = 3.0
+
+// showRewritePrompt is the rewrite prompt of the show articles if the podcast has none.
+const showRewritePrompt = "Rewrite the news into a short segment of a news podcast, keeping the key facts."
+
+// DailyShowConfig represents the configuration of the daily show, a podcast merged from the top news.
+type DailyShowConfig struct {
+	Scheduled bool     `json:"scheduled"`          // create the daily show every day
+	Hour      int      `json:"hour,omitempty"`     // hour of the day to create the scheduled show
+	Limit     int      `json:"limit,omitempty"`    // number of articles in a show
+	Language  string   `json:"language,omitempty"` // language of the show
+	Title     string   `json:"title,omitempty"`    // title of the show, followed by the date
+	Topics    []string `json:"topics,omitempty"`   // preferred topics of the articles
+	VoiceIds  []string `json:"voiceIds,omitempty"` // voices of the two hosts, the first two voices by default
+}
+
+// NewDefaultDailyShowConfig creates the default daily show config.
+func NewDefaultDailyShowConfig() *DailyShowConfig {
+	return &DailyShowConfig{Hour: defaultShowHour, Limit: defaultShowLimit, Title: defaultShowTitle}
+}
+
+// Normalize fills the default values of the config.
+func (c *DailyShowConfig) Normalize() *DailyShowConfig {
+	if c.Limit <= 0 {
+		c.Limit = defaultShowLimit
+	}
+
+	c.Limit = min(c.Limit, maxShowLimit)
+
+	if c.Hour < 0 || c.Hour > 23 {
+		c.Hour = defaultShowHour
+	}
+
+	if c.Title == "" {
+		c.Title = defaultShowTitle
+	}
+
+	return c
+}
+
+// ScheduledDate returns the date of the scheduled show once the show hour of the day is reached.
+func (c *DailyShowConfig) ScheduledDate(now time.Time) (string, bool) {
+	if !c.Scheduled || now.Hour() < c.Hour {
+		return "", false
+	}
+
+	return AnalyticsDay(now), true
+}
+
+// BuildTitle builds the title of the show of the date.
+func (c *DailyShowConfig) BuildTitle(date string) string {
+	return fmt.Sprintf("%s %s", c.Title, date)
+}
+
+// BuildRewritePrompt returns the rewrite prompt of the podcast, the default one if none.
+func (c *DailyShowConfig) BuildRewritePrompt(prompt *PodcastScriptPrompt) string {
+	if prompt.RewritePrompt != "" {
+		return prompt.RewritePrompt
+	}
+
+	return showRewritePrompt
+}
+
+// ShowScore ranks the article by the source weight, the preferred topics and the favorite.
+func (c *DailyShowConfig) ShowScore(sourceWeight int, topic string, favorited bool) float64 {
+	score := float64(sourceWeight)
+
+	if slices.Contains(c.Topics, topic) {
+		score += showTopicWeight
+	}
+
+	if favorited {
+		score += digestFavoriteWeight
+	}
+
+	return score
+}
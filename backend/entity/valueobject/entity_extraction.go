@@ -0,0 +1,46 @@
+package valueobject
+
+import (
+	"github.com/mjiee/world-news/backend/pkg/ner"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+)
+
+// entityExtractionPrompt is the system prompt of the named entity extraction.
+const entityExtractionPrompt = `Extract the people, organizations and locations mentioned in the news article.
+Reply with a json object only, in the format:
+{"entities": [{"name": "Emmanuel Macron", "type": "person"}, {"name": "Paris", "type": "location"}]}
+The type must be one of "person", "organization" or "location". Use the full name as written in the article, ` +
+	`do not translate it, and list each entity once.`
+
+// EntityExtractionConfig represents the configuration of the named entity extraction.
+type EntityExtractionConfig struct {
+	Disabled     bool `json:"disabled"`     // do not extract entities after crawling
+	DisableAI    bool `json:"disableAI"`    // only use the rule-based extractor
+	RuleFallback bool `json:"ruleFallback"` // use the rule-based extractor if the model fails
+}
+
+// NewDefaultEntityExtractionConfig creates the default entity extraction config.
+func NewDefaultEntityExtractionConfig() *EntityExtractionConfig {
+	return &EntityExtractionConfig{RuleFallback: true}
+}
+
+// BuildEntityExtractionPrompt builds the system prompt of the named entity extraction.
+func BuildEntityExtractionPrompt() string {
+	return entityExtractionPrompt
+}
+
+// entityExtractionResult is the json output of the named entity extraction.
+type entityExtractionResult struct {
+	Entities []*ner.Entity `json:"entities"`
+}
+
+// ParseEntityExtractionResult parses the model output of the named entity extraction.
+func ParseEntityExtractionResult(output string) ([]*ner.Entity, error) {
+	var result entityExtractionResult
+
+	if err := openai.ParseJSON(output, &result); err != nil {
+		return nil, err
+	}
+
+	return ner.Merge(result.Entities), nil
+}
@@ -0,0 +1,66 @@
+package valueobject
+
+import (
+	"github.com/mjiee/world-news/backend/pkg/openai"
+)
+
+// ModelProfile represents a named text ai model.
+type ModelProfile struct {
+	Name string `json:"name"`
+	openai.Config
+}
+
+// ModelRouting represents the text ai model profiles and the profile used by each podcast task stage.
+// The stages without a profile use the default text ai.
+type ModelRouting struct {
+	Profiles []*ModelProfile          `json:"profiles,omitempty"`
+	Stages   map[TaskStageName]string `json:"stages,omitempty"` // stage name to profile name
+}
+
+// getProfile returns the profile of the name, nil if not found.
+func (r *ModelRouting) getProfile(name string) *ModelProfile {
+	if r == nil || name == "" {
+		return nil
+	}
+
+	for _, profile := range r.Profiles {
+		if profile != nil && profile.Name == name {
+			return profile
+		}
+	}
+
+	return nil
+}
+
+// TaskAi returns the ai of the stage, recording the profile routed to.
+func (r *ModelRouting) TaskAi(stage TaskStageName, textAi *openai.Config) *TaskAi {
+	var profile *ModelProfile
+
+	if r != nil {
+		profile = r.getProfile(r.Stages[stage])
+	}
+
+	if profile == nil {
+		return NewTaskAiFromTextAi(textAi)
+	}
+
+	ai := NewTaskAiFromTextAi(&profile.Config)
+	ai.Profile = profile.Name
+
+	return ai
+}
+
+// TextAi returns the text ai of the profile recorded in the stage ai, the default text ai if the stage has no
+// profile or the profile was removed.
+func (r *ModelRouting) TextAi(ai *TaskAi, textAi *openai.Config) *openai.Config {
+	if ai == nil {
+		return textAi
+	}
+
+	profile := r.getProfile(ai.Profile)
+	if profile == nil {
+		return textAi
+	}
+
+	return &profile.Config
+}
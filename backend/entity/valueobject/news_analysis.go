@@ -0,0 +1,94 @@
+package valueobject
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/openai"
+)
+
+const (
+	// MinComparedNews is the minimum number of news in a comparative critique.
+	MinComparedNews = 2
+
+	// MaxComparedNews is the maximum number of news in a comparative critique.
+	MaxComparedNews = 6
+)
+
+// AnalysisType is the type of the news analysis.
+type AnalysisType string
+
+const (
+	ComparativeCritiqueAnalysis AnalysisType = "comparativeCritique"
+)
+
+// comparativeCritiquePrompt is the system prompt of the comparative critique.
+const comparativeCritiquePrompt = `You are a media analyst reviewing how several sources cover the same event.
+Each article is given with its id and source. Compare the articles and write the analysis in the language: %s.
+Reply with a json object only, in the format:
+{"event": "short name of the event",
+"summary": "3 to 5 sentences on how the coverage differs",
+"commonFacts": ["facts reported by all the sources"],
+"contestedFacts": ["facts the sources report differently or contradict"],
+"sources": [{"newsId": 1, "source": "the source",
+"framing": "how the article frames the event", "tone": "the tone of the article",
+"claimedFacts": ["facts claimed only or mainly by this source"],
+"omissions": ["facts reported by the other sources but omitted here"]}]}
+Include every article in sources. Base the analysis on the articles only and do not judge which side is right.`
+
+// SourceCritique is the critique of an article in the comparative critique.
+type SourceCritique struct {
+	NewsId       uint     `json:"newsId"`
+	Source       string   `json:"source"`
+	Framing      string   `json:"framing"`
+	Tone         string   `json:"tone"`
+	ClaimedFacts []string `json:"claimedFacts"`
+	Omissions    []string `json:"omissions"`
+}
+
+// ComparativeCritique is the json output of the comparative critique.
+type ComparativeCritique struct {
+	Event          string            `json:"event"`
+	Summary        string            `json:"summary"`
+	CommonFacts    []string          `json:"commonFacts"`
+	ContestedFacts []string          `json:"contestedFacts"`
+	Sources        []*SourceCritique `json:"sources"`
+}
+
+// QueryAnalysisParams query news analysis params
+type QueryAnalysisParams struct {
+	NewsId uint
+	Type   AnalysisType
+	Page   *httpx.Pagination
+}
+
+// BuildComparativeCritiquePrompt builds the system prompt of the comparative critique in the language.
+func BuildComparativeCritiquePrompt(language string) string {
+	return fmt.Sprintf(comparativeCritiquePrompt, language)
+}
+
+// ParseComparativeCritique parses the model output of the comparative critique,
+// dropping the source critiques of the news that are not compared.
+func ParseComparativeCritique(output string, newsIds []uint) (*ComparativeCritique, error) {
+	var result ComparativeCritique
+
+	if err := openai.ParseJSON(output, &result); err != nil {
+		return nil, err
+	}
+
+	result.Event = strings.TrimSpace(result.Event)
+	result.Summary = strings.TrimSpace(result.Summary)
+	result.Sources = slices.DeleteFunc(result.Sources, func(item *SourceCritique) bool {
+		return item == nil || !slices.Contains(newsIds, item.NewsId)
+	})
+
+	if result.Summary == "" || len(result.Sources) == 0 {
+		return nil, errors.Errorf("invalid comparative critique: %s", output)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,253 @@
+package valueobject
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/mjiee/gokit"
+)
+
+// AnalyticsDimension represents the dimension of the news analytics.
+type AnalyticsDimension string
+
+const (
+	AnalyticsByDay     AnalyticsDimension = "day"
+	AnalyticsBySource  AnalyticsDimension = "source"
+	AnalyticsByTopic   AnalyticsDimension = "topic"
+	AnalyticsByKeyword AnalyticsDimension = "keyword"
+)
+
+// analytics defaults
+const (
+	defaultAnalyticsDays = 7
+	maxAnalyticsDays     = 90
+	defaultAnalyticsSize = 20
+	defaultBaselineDays  = 7
+	minTrendingCount     = 3
+	minTrendingScore     = 2.0
+)
+
+// NewsAnalyticsParams represents the params of the news analytics.
+type NewsAnalyticsParams struct {
+	StartDate    time.Time // first day, inclusive
+	EndDate      time.Time // last day, inclusive
+	Source       string
+	Topic        string
+	Dimension    AnalyticsDimension
+	Keywords     []string
+	Limit        int // maximum number of terms or series
+	WindowDays   int // trending window
+	BaselineDays int // trailing baseline before the trending window
+}
+
+// Normalize fills the default values and limits the date range.
+func (p *NewsAnalyticsParams) Normalize() *NewsAnalyticsParams {
+	if p.EndDate.IsZero() {
+		p.EndDate = time.Now()
+	}
+
+	p.EndDate = truncateDay(p.EndDate)
+
+	if p.StartDate.IsZero() || p.StartDate.After(p.EndDate) {
+		p.StartDate = p.EndDate.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	}
+
+	p.StartDate = truncateDay(p.StartDate)
+
+	if p.EndDate.Sub(p.StartDate) >= maxAnalyticsDays*day {
+		p.StartDate = p.EndDate.AddDate(0, 0, -(maxAnalyticsDays - 1))
+	}
+
+	if p.Dimension == "" {
+		p.Dimension = AnalyticsByDay
+	}
+
+	if p.Limit <= 0 {
+		p.Limit = defaultAnalyticsSize
+	}
+
+	if p.WindowDays <= 0 {
+		p.WindowDays = 1
+	}
+
+	if p.BaselineDays <= 0 {
+		p.BaselineDays = defaultBaselineDays
+	}
+
+	p.WindowDays = min(p.WindowDays, maxAnalyticsDays)
+	p.BaselineDays = min(p.BaselineDays, maxAnalyticsDays)
+
+	return p
+}
+
+// Days returns the days of the date range.
+func (p *NewsAnalyticsParams) Days() []string {
+	days := make([]string, 0)
+
+	for date := p.StartDate; !date.After(p.EndDate); date = date.AddDate(0, 0, 1) {
+		days = append(days, date.Format(time.DateOnly))
+	}
+
+	return days
+}
+
+// TrendingRange returns the start of the baseline, the start of the window and the end of the window.
+func (p *NewsAnalyticsParams) TrendingRange() (baselineStart, windowStart, windowEnd time.Time) {
+	windowEnd = p.EndDate.AddDate(0, 0, 1)
+	windowStart = windowEnd.AddDate(0, 0, -p.WindowDays)
+	baselineStart = windowStart.AddDate(0, 0, -p.BaselineDays)
+
+	return baselineStart, windowStart, windowEnd
+}
+
+// truncateDay returns the start of the day in the local time zone.
+func truncateDay(t time.Time) time.Time {
+	year, month, date := t.Local().Date()
+
+	return time.Date(year, month, date, 0, 0, 0, 0, time.Local)
+}
+
+// AnalyticsDay returns the day of the time used by the news analytics.
+func AnalyticsDay(t time.Time) string {
+	return t.Local().Format(time.DateOnly)
+}
+
+// TermCount represents the number of news mentioning a term or phrase.
+type TermCount struct {
+	Term   string `json:"term"`
+	Count  int    `json:"count"`
+	Phrase bool   `json:"phrase,omitempty"`
+}
+
+// TermCounter counts the terms and phrases by news.
+type TermCounter map[string]*TermCount
+
+// Add counts the terms and phrases of a news once.
+func (c TermCounter) Add(terms, phrases []string) {
+	seen := make(map[string]struct{}, len(terms)+len(phrases))
+
+	add := func(term string, phrase bool) {
+		if _, ok := seen[term]; ok {
+			return
+		}
+
+		seen[term] = struct{}{}
+
+		if item, ok := c[term]; ok {
+			item.Count++
+		} else {
+			c[term] = &TermCount{Term: term, Count: 1, Phrase: phrase}
+		}
+	}
+
+	for _, term := range terms {
+		add(term, false)
+	}
+
+	for _, phrase := range phrases {
+		add(phrase, true)
+	}
+}
+
+// Count returns the count of the term.
+func (c TermCounter) Count(term string) int {
+	if item, ok := c[term]; ok {
+		return item.Count
+	}
+
+	return 0
+}
+
+// Top returns the most frequent terms.
+func (c TermCounter) Top(limit int) []*TermCount {
+	items := gokit.MapToSlice(c, func(_ string, v *TermCount) *TermCount { return v })
+
+	slices.SortFunc(items, func(a, b *TermCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Term, b.Term))
+	})
+
+	return items[:min(limit, len(items))]
+}
+
+// TermGroup represents the term frequencies of a day, source or topic.
+type TermGroup struct {
+	Name  string       `json:"name"`
+	Total int          `json:"total"` // number of news
+	Terms []*TermCount `json:"terms"`
+}
+
+// TrendingTerm represents a term rising sharply compared with the trailing baseline.
+type TrendingTerm struct {
+	Term     string  `json:"term"`
+	Phrase   bool    `json:"phrase,omitempty"`
+	Count    int     `json:"count"`    // number of news in the window
+	Baseline float64 `json:"baseline"` // expected number of news by the baseline
+	Score    float64 `json:"score"`
+}
+
+// NewTrendingTerms finds the trending terms of the window compared with the baseline.
+func NewTrendingTerms(window, baseline TermCounter, windowDays, baselineDays, limit int) []*TrendingTerm {
+	result := make([]*TrendingTerm, 0)
+
+	for term, item := range window {
+		if item.Count < minTrendingCount {
+			continue
+		}
+
+		var (
+			expected = float64(baseline.Count(term)) / float64(baselineDays) * float64(windowDays)
+			score    = float64(item.Count+1) / (expected + 1)
+		)
+
+		if score < minTrendingScore {
+			continue
+		}
+
+		result = append(result, &TrendingTerm{
+			Term:     term,
+			Phrase:   item.Phrase,
+			Count:    item.Count,
+			Baseline: expected,
+			Score:    score,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b *TrendingTerm) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.Count, a.Count), cmp.Compare(a.Term, b.Term))
+	})
+
+	return result[:min(limit, len(result))]
+}
+
+// TimeSeries represents the number of news per day.
+type TimeSeries struct {
+	Name   string       `json:"name"`
+	Total  int          `json:"total"`
+	Points []*TimePoint `json:"points"`
+}
+
+// TimePoint represents the number of news of a day.
+type TimePoint struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// NewTimeSeries creates an empty time series of the days.
+func NewTimeSeries(name string, days []string) *TimeSeries {
+	return &TimeSeries{
+		Name:   name,
+		Points: gokit.SliceMap(days, func(d string) *TimePoint { return &TimePoint{Date: d} }),
+	}
+}
+
+// Add counts a news of the day.
+func (s *TimeSeries) Add(day string) {
+	point := gokit.SliceFind(s.Points, func(p *TimePoint) bool { return p.Date == day })
+	if point == nil {
+		return
+	}
+
+	point.Count++
+	s.Total++
+}
@@ -0,0 +1,128 @@
+package valueobject
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultDigestLimit is the default number of articles in a digest.
+	defaultDigestLimit = 10
+
+	// maxDigestLimit is the maximum number of articles in a digest.
+	maxDigestLimit = 50
+
+	// defaultDigestHour is the default hour of the day to generate the scheduled digest.
+	defaultDigestHour = 7
+)
+
+// DigestClusterThreshold is the title similarity of the articles reporting the same story.
+const DigestClusterThreshold = 0.3
+
+// digest ranking weights
+const (
+	digestClusterWeight  = 2.0 // each other article of the story
+	digestFavoriteWeight = 5.0 // favorited article
+)
+
+// digestPrompt is the default system prompt of the digest article summary.
+const digestPrompt = `You are the editor of a morning news briefing. Summarize the news article in 2 to 3 sentences, ` +
+	`keeping the key facts, names and numbers. Reply with the summary only.`
+
+// digestLanguagePrompt asks the model to reply in the digest language.
+const digestLanguagePrompt = " Write the summary in the language: %s."
+
+// DigestStatus is the status of the news digest.
+type DigestStatus string
+
+const (
+	DigestStatusProcessing DigestStatus = "processing"
+	DigestStatusCompleted  DigestStatus = "completed"
+	DigestStatusFailed     DigestStatus = "failed"
+)
+
+func (s DigestStatus) IsProcessing() bool {
+	return s == DigestStatusProcessing
+}
+
+// NewsDigestConfig represents the configuration of the news digest.
+type NewsDigestConfig struct {
+	Scheduled    bool   `json:"scheduled"`              // generate the digest of the previous day every morning
+	Hour         int    `json:"hour,omitempty"`         // hour of the day to generate the scheduled digest
+	Limit        int    `json:"limit,omitempty"`        // number of articles in a digest
+	Language     string `json:"language,omitempty"`     // language of the summaries
+	SystemPrompt string `json:"systemPrompt,omitempty"` // system prompt of the article summary
+}
+
+// NewDefaultNewsDigestConfig creates the default news digest config.
+func NewDefaultNewsDigestConfig() *NewsDigestConfig {
+	return &NewsDigestConfig{Hour: defaultDigestHour, Limit: defaultDigestLimit}
+}
+
+// Normalize fills the default values of the config.
+func (c *NewsDigestConfig) Normalize() *NewsDigestConfig {
+	if c.Limit <= 0 {
+		c.Limit = defaultDigestLimit
+	}
+
+	c.Limit = min(c.Limit, maxDigestLimit)
+
+	if c.Hour < 0 || c.Hour > 23 {
+		c.Hour = defaultDigestHour
+	}
+
+	return c
+}
+
+// BuildPrompt builds the system prompt of the article summary.
+func (c *NewsDigestConfig) BuildPrompt() string {
+	prompt := c.SystemPrompt
+	if prompt == "" {
+		prompt = digestPrompt
+	}
+
+	if c.Language != "" {
+		prompt += fmt.Sprintf(digestLanguagePrompt, c.Language)
+	}
+
+	return prompt
+}
+
+// ScheduledDate returns the date of the scheduled digest, the previous day once the digest hour is reached.
+func (c *NewsDigestConfig) ScheduledDate(now time.Time) (string, bool) {
+	if !c.Scheduled || now.Hour() < c.Hour {
+		return "", false
+	}
+
+	return AnalyticsDay(now.AddDate(0, 0, -1)), true
+}
+
+// DigestScore ranks the article by the source weight, the story cluster size and the favorite.
+func DigestScore(sourceWeight, clusterSize int, favorited bool) float64 {
+	score := float64(sourceWeight) + float64(clusterSize-1)*digestClusterWeight
+
+	if favorited {
+		score += digestFavoriteWeight
+	}
+
+	return score
+}
+
+// DigestSection represents the articles of a topic in the digest.
+type DigestSection struct {
+	Topic string        `json:"topic"`
+	Items []*DigestItem `json:"items"`
+}
+
+// DigestItem represents a summarized article in the digest.
+type DigestItem struct {
+	NewsId      uint      `json:"newsId"`
+	Title       string    `json:"title"`
+	Source      string    `json:"source"`
+	Link        string    `json:"link"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Summary     string    `json:"summary"`
+	ClusterSize int       `json:"clusterSize"` // number of articles reporting the same story
+	RelatedIds  []uint    `json:"relatedIds,omitempty"`
+	Score       float64   `json:"score"`
+}
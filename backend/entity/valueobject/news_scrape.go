@@ -0,0 +1,16 @@
+package valueobject
+
+import "time"
+
+const (
+	// MaxScrapeAttempts is the maximum number of consecutive failed scrapes before giving up.
+	MaxScrapeAttempts = 5
+
+	// ScrapeRetryInterval is the base interval of the scrape retry backoff.
+	ScrapeRetryInterval = 10 * time.Minute
+)
+
+// ScrapeRetryDelay returns the exponential backoff delay after the failed attempts.
+func ScrapeRetryDelay(attempts int) time.Duration {
+	return ScrapeRetryInterval << min(max(attempts-1, 0), 6)
+}
@@ -0,0 +1,45 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/openai"
+)
+
+// newsSummaryPrompt is the system prompt of the news summary.
+const newsSummaryPrompt = `Summarize the news article in the language: %s.
+Reply with a json object only, in the format:
+{"short": "one sentence headline summary", "long": "a paragraph of 4 to 6 sentences"}
+Keep the key facts, names and numbers, and do not add opinions that are not in the article.`
+
+// NewsSummaryResult is the json output of the news summary.
+type NewsSummaryResult struct {
+	Short string `json:"short"`
+	Long  string `json:"long"`
+}
+
+// BuildNewsSummaryPrompt builds the system prompt of the news summary in the language.
+func BuildNewsSummaryPrompt(language string) string {
+	return fmt.Sprintf(newsSummaryPrompt, language)
+}
+
+// ParseNewsSummaryResult parses the model output of the news summary.
+func ParseNewsSummaryResult(output string) (*NewsSummaryResult, error) {
+	var result NewsSummaryResult
+
+	if err := openai.ParseJSON(output, &result); err != nil {
+		return nil, err
+	}
+
+	result.Short = strings.TrimSpace(result.Short)
+	result.Long = strings.TrimSpace(result.Long)
+
+	if result.Short == "" && result.Long == "" {
+		return nil, errors.Errorf("empty news summary: %s", output)
+	}
+
+	return &result, nil
+}
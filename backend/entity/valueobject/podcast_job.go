@@ -0,0 +1,79 @@
+package valueobject
+
+import (
+	"time"
+
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+)
+
+const (
+	// defaultJobConcurrency is the default number of podcast jobs running at the same time.
+	defaultJobConcurrency = 2
+
+	// maxJobConcurrency is the maximum number of podcast jobs running at the same time.
+	maxJobConcurrency = 16
+
+	// defaultJobAttempts is the default number of runs of a job interrupted by a restart before it fails.
+	defaultJobAttempts = 3
+)
+
+// JobLease is how long a running job is owned by its worker without a heartbeat.
+const JobLease = time.Minute
+
+// JobKind the kind of the podcast job
+type JobKind string
+
+const (
+	JobKindExecuteTask JobKind = "executeTask" // run the processing stages of the task
+	JobKindAutoTask    JobKind = "autoTask"    // run the processing stages, then create the audio of the scripts
+	JobKindDailyShow   JobKind = "dailyShow"   // queued once the articles end, merge them and run as the auto task
+)
+
+// JobStatus the status of the podcast job
+type JobStatus string
+
+const (
+	JobStatusWaiting   JobStatus = "waiting" // waits for the jobs it depends on, then queued
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+func (s JobStatus) IsActive() bool {
+	return s == JobStatusWaiting || s == JobStatusQueued || s == JobStatusRunning
+}
+
+// JobQueueConfig the config of the podcast job queue
+type JobQueueConfig struct {
+	Concurrency int `json:"concurrency,omitempty"` // number of jobs running at the same time
+	MaxAttempts int `json:"maxAttempts,omitempty"` // number of runs of an interrupted job before it fails
+}
+
+// NewDefaultJobQueueConfig creates the default job queue config.
+func NewDefaultJobQueueConfig() *JobQueueConfig {
+	return &JobQueueConfig{Concurrency: defaultJobConcurrency, MaxAttempts: defaultJobAttempts}
+}
+
+// Normalize fills the default values of the config.
+func (c *JobQueueConfig) Normalize() *JobQueueConfig {
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultJobConcurrency
+	}
+
+	c.Concurrency = min(c.Concurrency, maxJobConcurrency)
+
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultJobAttempts
+	}
+
+	return c
+}
+
+// QueryJobParams query podcast job params
+type QueryJobParams struct {
+	BatchNo string
+	Status  JobStatus
+	Page    *httpx.Pagination
+}
@@ -3,9 +3,11 @@ package valueobject
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/mjiee/gokit"
 
 	"github.com/mjiee/world-news/backend/pkg/locale"
@@ -24,6 +26,8 @@ var (
 	scriptJsonKey = "scriptJson"
 	mergeKey      = "merge"
 	approvalKey   = "approval"
+	repairKey     = "repair"
+	styleJsonKey  = "styleJson"
 )
 
 // buildKey builds the key
@@ -35,8 +39,8 @@ func buildKey(key, language string) string {
 var promptLocale = map[string]string{
 	buildKey(systemKey, locale.En):     "You are a podcast script generation assistant.",
 	buildKey(systemKey, locale.Zh):     "你是一位中文播客脚本生成助手。",
-	buildKey(classifyKey, locale.En):   "Please analyze the news content and determine which podcast category it is most suitable for, returning the index of the category (single choice):",
-	buildKey(classifyKey, locale.Zh):   "请分析新闻内容，判断它最适合哪种播客分类，只返回分类索引（单选）：",
+	buildKey(classifyKey, locale.En):   "Please analyze the news content and determine which podcast category it is most suitable for (single choice):",
+	buildKey(classifyKey, locale.Zh):   "请分析新闻内容，判断它最适合哪种播客分类（单选）：",
 	buildKey(scriptKey, locale.En):     "Convert podcast content into tts scripts.",
 	buildKey(scriptKey, locale.Zh):     "将播客内容转换为tts脚本。",
 	buildKey(announcerKey, locale.En):  "Radio announcer: ",
@@ -53,8 +57,12 @@ var promptLocale = map[string]string{
 	buildKey(scriptJsonKey, locale.Zh): "要求只输出标准json列表，示例：",
 	buildKey(mergeKey, locale.En):      "Please merge the following podcast content into a single podcast script.",
 	buildKey(mergeKey, locale.Zh):      "请合并多篇播客内容，保留所有文本的核心信息，使其成为一篇完整的播客文案。",
-	buildKey(approvalKey, locale.En):   `Please reply with "yes" or "no" and provide the reason.`,
-	buildKey(approvalKey, locale.Zh):   `请回复“yes”或“no”，并给出理由。`,
+	buildKey(approvalKey, locale.En):   `Only reply with a json object. Example: {"verdict": "approved", "confidence": 0.9, "reasons": ["..."], "flaggedClaims": ["..."]}, the verdict is "approved" or "rejected", the confidence is between 0 and 1, the flagged claims are the doubtful claims of the news.`,
+	buildKey(approvalKey, locale.Zh):   `只回复json对象，示例：{"verdict": "approved", "confidence": 0.9, "reasons": ["..."], "flaggedClaims": ["..."]}，verdict为"approved"或"rejected"，confidence为0到1之间的置信度，flaggedClaims为新闻中存疑的说法。`,
+	buildKey(styleJsonKey, locale.En):  `Only reply with a json object. Example: {"index": 1, "confidence": 0.9, "rationale": "..."}, the index is the index of the category, the confidence is between 0 and 1.`,
+	buildKey(styleJsonKey, locale.Zh):  `只回复json对象，示例：{"index": 1, "confidence": 0.9, "rationale": "..."}，index为分类索引，confidence为0到1之间的置信度，rationale为分类理由。`,
+	buildKey(repairKey, locale.En):     "The scripts are invalid:\n%s\nPlease fix the errors and output the complete json only.",
+	buildKey(repairKey, locale.Zh):     "脚本不符合要求：\n%s\n请修正以上错误，只输出完整的json。",
 }
 
 // getDefaultPrompt returns the default prompt
@@ -116,64 +124,121 @@ func (p *PodcastScriptPrompt) BuildClassifyPrompt(language string) string {
 		prompt = fmt.Sprintf("%s\n%d. %s;\n", prompt, idx+1, style.Style)
 	}
 
-	return prompt
+	return fmt.Sprintf("%s\n%s", prompt, getDefaultPrompt(styleJsonKey, language))
 }
 
-// GetStylePrompt returns the style
-func (p *PodcastScriptPrompt) GetStylePrompt(output string) *StylePrompt {
-	for _, item := range strings.Split(output, "\n") {
-		if item != "" {
-			output = item
-			break
-		}
+// BuildScriptPrompt returns the script prompt, built from the voices by default
+func (p *PodcastScriptPrompt) BuildScriptPrompt(language string, voices []*ttsai.Voice) string {
+	if p.ScriptPrompt != "" {
+		return p.ScriptPrompt
 	}
 
-	for idx, style := range p.StylizePrompts {
-		if strings.Contains(output, strconv.Itoa(idx+1)) {
-			return style
-		}
+	return BuildScriptPrompt(language, voices)
+}
+
+// GetStylePrompt returns the style prompt of the classify result, nil if out of range
+func (p *PodcastScriptPrompt) GetStylePrompt(result *ClassifyResult) *StylePrompt {
+	if result == nil || result.Index < 1 || result.Index > len(p.StylizePrompts) {
+		return nil
 	}
 
-	return nil
+	return p.StylizePrompts[result.Index-1]
 }
 
-// VerifyApprovalResult verifies the approval result
-func (p *PodcastScriptPrompt) VerifyApprovalResult(result string) StageStatus {
-	for _, item := range strings.Split(result, "\n") {
-		if item != "" {
-			result = item
-			break
-		}
+// ParseClassifyResult parses the json object of the classify result
+func (p *PodcastScriptPrompt) ParseClassifyResult(output string) (*ClassifyResult, error) {
+	result, err := extractObject[ClassifyResult](output)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Index < 1 || result.Index > len(p.StylizePrompts) {
+		return nil, errors.Errorf("index %d is out of [1, %d]", result.Index, len(p.StylizePrompts))
 	}
 
-	if strings.Contains(strings.ToLower(result), "yes") {
-		return StageStatusCompleted
+	if result.Confidence < 0 || result.Confidence > 1 {
+		return nil, errors.Errorf("confidence %v is out of [0, 1]", result.Confidence)
 	}
 
-	return StageStatusFailed
+	return result, nil
 }
 
-// ExtractScripts extracts the scripts
-func (p *PodcastScriptPrompt) ExtractScripts(result string) []*ttsai.TtsScript {
-	var (
-		scripts []*ttsai.TtsScript
-		start   = strings.Index(result, "[")
-	)
+// ParseApprovalResult parses the json object of the approval result
+func (p *PodcastScriptPrompt) ParseApprovalResult(output string) (*ApprovalResult, error) {
+	result, err := extractObject[ApprovalResult](output)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(ApprovalVerdicts, result.Verdict) {
+		return nil, errors.Errorf("verdict %q is not one of %q", result.Verdict, ApprovalVerdicts)
+	}
+
+	if result.Confidence < 0 || result.Confidence > 1 {
+		return nil, errors.Errorf("confidence %v is out of [0, 1]", result.Confidence)
+	}
+
+	return result, nil
+}
+
+// ExtractScripts extracts the scripts from the json list, or the json object of the list replied with a schema
+func (p *PodcastScriptPrompt) ExtractScripts(result string) ([]*ttsai.TtsScript, error) {
+	var data struct {
+		Scripts []*ttsai.TtsScript `json:"scripts"`
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(result)), &data); err == nil && data.Scripts != nil {
+		return data.Scripts, nil
+	}
 
+	start := strings.Index(result, "[")
 	if start == -1 {
-		return scripts
+		return nil, errors.New("no json list found")
 	}
 
 	jsonStr := extractFromPosition(result, start, '[', ']')
 	if jsonStr == "" {
-		return scripts
+		return nil, errors.New("incomplete json list")
 	}
 
+	var scripts []*ttsai.TtsScript
+
 	if err := json.Unmarshal([]byte(jsonStr), &scripts); err != nil {
-		return nil
+		return nil, errors.WithStack(err)
+	}
+
+	return scripts, nil
+}
+
+// BuildScriptRepairPrompt builds the prompt asking the model to fix the invalid scripts
+func BuildScriptRepairPrompt(language string, err error) string {
+	prompt := getDefaultPrompt(repairKey, language)
+	if prompt == "" {
+		prompt = getDefaultPrompt(repairKey, locale.En)
+	}
+
+	return fmt.Sprintf(prompt, err.Error())
+}
+
+// extractObject extracts the first json object of the text
+func extractObject[T any](text string) (*T, error) {
+	start := strings.Index(text, "{")
+	if start == -1 {
+		return nil, errors.New("no json object found")
+	}
+
+	jsonStr := extractFromPosition(text, start, '{', '}')
+	if jsonStr == "" {
+		return nil, errors.New("incomplete json object")
+	}
+
+	var result T
+
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return scripts
+	return &result, nil
 }
 
 // extractFromPosition extracts the text from the given position
@@ -0,0 +1,104 @@
+package valueobject
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/ttsai"
+)
+
+// MaxPromptNameLength is the maximum length of the prompt template name.
+const MaxPromptNameLength = 64
+
+// PromptName is the name of a prompt template, the commands reference the templates by name.
+type PromptName string
+
+const (
+	PromptNewsCritique    PromptName = "newsCritique"    // system prompt of the news critique
+	PromptPodcastSystem   PromptName = "podcastSystem"   // system prompt of the podcast task
+	PromptPodcastApproval PromptName = "podcastApproval" // approval stage, the yes/no instruction is appended
+	PromptPodcastRewrite  PromptName = "podcastRewrite"  // rewrite stage
+	PromptPodcastClassify PromptName = "podcastClassify" // classify stage, the style list is appended
+	PromptPodcastMerge    PromptName = "podcastMerge"    // merge stage
+	PromptPodcastScript   PromptName = "podcastScript"   // script stage
+)
+
+// PodcastPromptNames are the templates overriding the podcast script prompt.
+var PodcastPromptNames = []PromptName{
+	PromptPodcastSystem,
+	PromptPodcastApproval,
+	PromptPodcastRewrite,
+	PromptPodcastClassify,
+	PromptPodcastMerge,
+	PromptPodcastScript,
+}
+
+// PromptVars represents the variables of the prompt templates.
+type PromptVars struct {
+	Title    string         `json:"title"`
+	Source   string         `json:"source"`
+	Language string         `json:"language"`
+	Contents []string       `json:"contents"`
+	Voices   []*ttsai.Voice `json:"voices"`
+	Styles   []string       `json:"styles"`
+}
+
+// promptFuncs are the functions available in the prompt templates.
+var promptFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v any) string { return gokit.MarshalSafe(v) },
+	"inc":  func(i int) int { return i + 1 },
+}
+
+// ParsePrompt parses the prompt template.
+func ParsePrompt(name, content string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, errorx.PromptTemplateInvalid.SetErr(err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderPrompt renders the prompt template with the variables.
+func RenderPrompt(name, content string, vars *PromptVars) (string, error) {
+	tmpl, err := ParsePrompt(name, content)
+	if err != nil {
+		return "", err
+	}
+
+	if vars == nil {
+		vars = &PromptVars{}
+	}
+
+	var prompt strings.Builder
+
+	if err := tmpl.Execute(&prompt, vars); err != nil {
+		return "", errorx.PromptTemplateInvalid.SetErr(err)
+	}
+
+	return strings.TrimSpace(prompt.String()), nil
+}
+
+// ApplyTemplates overrides the configured prompts with the rendered prompt templates.
+func (p *PodcastScriptPrompt) ApplyTemplates(prompts map[PromptName]string) {
+	for name, prompt := range prompts {
+		switch name {
+		case PromptPodcastSystem:
+			p.SystemPrompt = prompt
+		case PromptPodcastApproval:
+			p.ApprovalPrompt = prompt
+		case PromptPodcastRewrite:
+			p.RewritePrompt = prompt
+		case PromptPodcastClassify:
+			p.ClassifyPrompt = prompt
+		case PromptPodcastMerge:
+			p.MergePrompt = prompt
+		case PromptPodcastScript:
+			p.ScriptPrompt = prompt
+		}
+	}
+}
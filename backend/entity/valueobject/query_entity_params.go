@@ -0,0 +1,13 @@
+package valueobject
+
+import (
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/pkg/ner"
+)
+
+// QueryEntityParams query named entity params
+type QueryEntityParams struct {
+	Name string // entity name, fuzzy match when querying entities
+	Type ner.EntityType
+	Page *httpx.Pagination
+}
@@ -14,6 +14,8 @@ type QueryNewsParams struct {
 	PublishDate time.Time
 	Favorited   bool
 	Page        *httpx.Pagination
+
+	SummaryLanguage string // preferred language of the attached summaries
 }
 
 // NewQueryNewsParams creates a new QueryNewsParams instance.
@@ -0,0 +1,10 @@
+package valueobject
+
+import "github.com/mjiee/world-news/backend/pkg/httpx"
+
+// QueryPodcastParams query published podcast params
+type QueryPodcastParams struct {
+	Language string
+	Style    string
+	Page     *httpx.Pagination
+}
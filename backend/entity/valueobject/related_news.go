@@ -0,0 +1,118 @@
+package valueobject
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultRelatedNewsLimit is the default number of related articles.
+	DefaultRelatedNewsLimit = 5
+
+	// maxRelatedNewsLimit is the maximum number of related articles.
+	maxRelatedNewsLimit = 20
+
+	// RelatedNewsWindow is the publish time distance of the related article candidates.
+	RelatedNewsWindow = 72 * time.Hour
+
+	// MaxRelatedCandidates is the maximum number of articles compared with the news.
+	MaxRelatedCandidates = 300
+
+	// minRelatedScore is the minimum score of a related article.
+	minRelatedScore = 0.15
+)
+
+// related article score weights, the sum is 1
+const (
+	relatedEntityWeight = 0.4 // shared named entities
+	relatedTitleWeight  = 0.3 // title similarity
+	relatedStoryWeight  = 0.2 // same story cluster
+	relatedTimeWeight   = 0.1 // time proximity
+
+	// relatedEntitySaturation is the number of shared entities reaching the full entity score.
+	relatedEntitySaturation = 5
+
+	// relatedSameSourceFactor lowers the articles of the same source, the other sources' coverage goes first.
+	relatedSameSourceFactor = 0.8
+)
+
+// RelatedReason is a reason why an article is related to the news.
+type RelatedReason string
+
+const (
+	RelatedSharedEntities RelatedReason = "sharedEntities"
+	RelatedSimilarTitle   RelatedReason = "similarTitle"
+	RelatedSameStory      RelatedReason = "sameStory"
+	RelatedPublishedNear  RelatedReason = "publishedNear"
+)
+
+// RelatedSignals represents the signals relating an article to the news.
+type RelatedSignals struct {
+	SharedEntities  int
+	TitleSimilarity float64
+	SameStory       bool
+	SameSource      bool
+	TimeDistance    time.Duration
+}
+
+// NormalizeRelatedNewsLimit returns the number of related articles within the allowed range.
+func NormalizeRelatedNewsLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultRelatedNewsLimit
+	}
+
+	return min(limit, maxRelatedNewsLimit)
+}
+
+// Score returns the relatedness score between 0 and 1.
+func (s *RelatedSignals) Score() float64 {
+	score := relatedEntityWeight*float64(min(s.SharedEntities, relatedEntitySaturation))/relatedEntitySaturation +
+		relatedTitleWeight*s.TitleSimilarity + relatedTimeWeight*s.timeProximity()
+
+	if s.SameStory {
+		score += relatedStoryWeight
+	}
+
+	if s.SameSource {
+		score *= relatedSameSourceFactor
+	}
+
+	return score
+}
+
+// IsRelated checks if the article is related, the time proximity alone is not enough.
+func (s *RelatedSignals) IsRelated() bool {
+	if s.SharedEntities == 0 && s.TitleSimilarity == 0 && !s.SameStory {
+		return false
+	}
+
+	return s.Score() >= minRelatedScore
+}
+
+// Reasons returns the reasons of the relation.
+func (s *RelatedSignals) Reasons() []RelatedReason {
+	reasons := make([]RelatedReason, 0, 4)
+
+	if s.SharedEntities > 0 {
+		reasons = append(reasons, RelatedSharedEntities)
+	}
+
+	if s.TitleSimilarity >= DigestClusterThreshold {
+		reasons = append(reasons, RelatedSimilarTitle)
+	}
+
+	if s.SameStory {
+		reasons = append(reasons, RelatedSameStory)
+	}
+
+	if s.TimeDistance <= 24*time.Hour {
+		reasons = append(reasons, RelatedPublishedNear)
+	}
+
+	return reasons
+}
+
+// timeProximity returns 1 for the articles published together, decaying by day.
+func (s *RelatedSignals) timeProximity() float64 {
+	return 1 / (1 + math.Abs(s.TimeDistance.Hours())/24)
+}
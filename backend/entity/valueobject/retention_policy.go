@@ -0,0 +1,107 @@
+package valueobject
+
+import (
+	"time"
+)
+
+// day is the duration of a day.
+const day = 24 * time.Hour
+
+// RetentionPolicy represents the rules for deleting historical news and crawling records.
+type RetentionPolicy struct {
+	RecordMaxAgeDays map[CrawlingRecordType]int `json:"recordMaxAgeDays,omitempty"` // maximum age per record type
+	MaxNews          int                        `json:"maxNews,omitempty"`          // maximum number of news
+	Sources          []*SourceRetention         `json:"sources,omitempty"`          // per-source overrides
+	KeepFavorited    bool                       `json:"keepFavorited"`              // exempt favorited news
+	KeepPodcast      bool                       `json:"keepPodcast"`                // exempt podcast-linked news
+}
+
+// SourceRetention represents the retention rule of a news source. Its limits replace the global ones for the news
+// of the source, the zero limits fall back to the global ones. The news kept by the rule outlive their records.
+type SourceRetention struct {
+	Source     string `json:"source"`
+	MaxAgeDays int    `json:"maxAgeDays,omitempty"`
+	MaxNews    int    `json:"maxNews,omitempty"`
+}
+
+// NewDefaultRetentionPolicy creates the default retention policy.
+func NewDefaultRetentionPolicy() *RetentionPolicy {
+	return &RetentionPolicy{
+		RecordMaxAgeDays: map[CrawlingRecordType]int{
+			CrawlingWebsite: 30,
+			CrawlingNews:    30,
+		},
+		KeepFavorited: true,
+		KeepPodcast:   true,
+	}
+}
+
+// RecordRules returns the policy deleting only the expired crawling records with their news, without the news
+// limits and the per-source rules.
+func (p *RetentionPolicy) RecordRules() *RetentionPolicy {
+	return &RetentionPolicy{
+		RecordMaxAgeDays: p.RecordMaxAgeDays,
+		KeepFavorited:    p.KeepFavorited,
+		KeepPodcast:      p.KeepPodcast,
+	}
+}
+
+// RecordDeadline returns the deadline of the record type, zero if the record type never expires.
+func (p *RetentionPolicy) RecordDeadline(recordType CrawlingRecordType, now time.Time) time.Time {
+	days := p.RecordMaxAgeDays[recordType]
+	if days <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-time.Duration(days) * day)
+}
+
+// Deadline returns the deadline of the source rule, zero if the source never expires.
+func (s *SourceRetention) Deadline(now time.Time) time.Time {
+	if s.MaxAgeDays <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-time.Duration(s.MaxAgeDays) * day)
+}
+
+// RetentionReport represents the records and news deleted by a retention policy.
+type RetentionReport struct {
+	DryRun    bool           `json:"dryRun"`
+	RecordIds []uint         `json:"recordIds"`
+	NewsIds   []uint         `json:"-"`
+	NewsCount int            `json:"newsCount"`
+	Sources   map[string]int `json:"sources"` // news count by source
+
+	newsSet map[uint]struct{}
+}
+
+// NewRetentionReport creates a new retention report.
+func NewRetentionReport(dryRun bool) *RetentionReport {
+	return &RetentionReport{
+		DryRun:    dryRun,
+		RecordIds: make([]uint, 0),
+		NewsIds:   make([]uint, 0),
+		Sources:   make(map[string]int),
+		newsSet:   make(map[uint]struct{}),
+	}
+}
+
+// AddNews adds the news to the report, ignoring duplicates.
+func (r *RetentionReport) AddNews(id uint, source string) {
+	if _, ok := r.newsSet[id]; ok {
+		return
+	}
+
+	r.newsSet[id] = struct{}{}
+	r.NewsIds = append(r.NewsIds, id)
+	r.NewsCount = len(r.NewsIds)
+	r.Sources[source]++
+}
+
+// HasNews checks whether the news is already in the report.
+func (r *RetentionReport) HasNews(id uint) bool {
+	_, ok := r.newsSet[id]
+
+	return ok
+}
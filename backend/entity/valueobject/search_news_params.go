@@ -0,0 +1,29 @@
+package valueobject
+
+const (
+	// defaultSearchLimit is the default number of news returned by the semantic search.
+	defaultSearchLimit = 20
+
+	// maxSearchLimit is the maximum number of news returned by the semantic search.
+	maxSearchLimit = 100
+)
+
+// SearchNewsParams represents the parameters of the semantic news search.
+type SearchNewsParams struct {
+	Query    string
+	Source   string
+	Topic    string
+	Limit    int
+	MinScore float64 // minimum cosine similarity
+}
+
+// Normalize fills the default values of the params.
+func (p *SearchNewsParams) Normalize() *SearchNewsParams {
+	if p.Limit <= 0 {
+		p.Limit = defaultSearchLimit
+	}
+
+	p.Limit = min(p.Limit, maxSearchLimit)
+
+	return p
+}
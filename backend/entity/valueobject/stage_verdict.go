@@ -0,0 +1,74 @@
+package valueobject
+
+import (
+	"strings"
+)
+
+// ApprovalVerdict is the verdict of the approval stage.
+type ApprovalVerdict string
+
+const (
+	ApprovalVerdictApproved ApprovalVerdict = "approved"
+	ApprovalVerdictRejected ApprovalVerdict = "rejected"
+)
+
+// ApprovalVerdicts is the list of the approval verdicts.
+var ApprovalVerdicts = []ApprovalVerdict{ApprovalVerdictApproved, ApprovalVerdictRejected}
+
+// ApprovalResult represents the structured output of the approval stage.
+type ApprovalResult struct {
+	Verdict       ApprovalVerdict `json:"verdict"`
+	Confidence    float64         `json:"confidence"` // [0, 1]
+	Reasons       []string        `json:"reasons"`
+	FlaggedClaims []string        `json:"flaggedClaims"` // the claims of the news doubted by the model
+}
+
+// Reason returns the reasons of the verdict in a line.
+func (r *ApprovalResult) Reason() string {
+	return strings.Join(r.Reasons, "; ")
+}
+
+// ClassifyResult represents the structured output of the classify stage.
+type ClassifyResult struct {
+	Index      int     `json:"index"`      // the index of the style prompt, starting from 1
+	Confidence float64 `json:"confidence"` // [0, 1]
+	Rationale  string  `json:"rationale"`
+}
+
+// PodcastReview represents when the podcast task pauses for human review.
+type PodcastReview struct {
+	// ConfidenceThreshold the classify and approval stages below the confidence wait for review, 0 never pauses.
+	ConfidenceThreshold float64 `json:"confidenceThreshold"`
+}
+
+// confidenceSchema is the json schema of the confidence
+var confidenceSchema = map[string]any{"type": "number", "minimum": 0, "maximum": 1}
+
+// ApprovalSchema returns the json schema of the approval result replied by the model
+func ApprovalSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"verdict":       map[string]any{"type": "string", "enum": ApprovalVerdicts},
+			"confidence":    confidenceSchema,
+			"reasons":       map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"flaggedClaims": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+		"required":             []string{"verdict", "confidence", "reasons", "flaggedClaims"},
+		"additionalProperties": false,
+	}
+}
+
+// ClassifySchema returns the json schema of the classify result replied by the model
+func ClassifySchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"index":      map[string]any{"type": "integer", "minimum": 1},
+			"confidence": confidenceSchema,
+			"rationale":  map[string]any{"type": "string"},
+		},
+		"required":             []string{"index", "confidence", "rationale"},
+		"additionalProperties": false,
+	}
+}
@@ -16,6 +16,15 @@ const (
 	TextAIKey                SystemConfigKey = "textAI"                 // openai
 	NewsCritiquePromptKey    SystemConfigKey = "newsCritiquePrompt"     // news critique prompt
 	PodcastScriptPromptKey   SystemConfigKey = "podcastScriptPrompt"    // podcast script prompt
+	RetentionPolicyKey       SystemConfigKey = "retentionPolicy"        // retention policy
+	EntityExtractionKey      SystemConfigKey = "entityExtraction"       // named entity extraction
+	NewsDigestKey            SystemConfigKey = "newsDigest"             // news digest
+	EmbeddingAIKey           SystemConfigKey = "embeddingAI"            // embeddings api
+	AiPricingKey             SystemConfigKey = "aiPricing"              // ai model prices and monthly budget
+	ModelRoutingKey          SystemConfigKey = "modelRouting"           // text ai model profiles of the task stages
+	PodcastReviewKey         SystemConfigKey = "podcastReview"          // human review of the podcast tasks
+	JobQueueKey              SystemConfigKey = "jobQueue"               // background jobs of the podcast tasks
+	DailyShowKey             SystemConfigKey = "dailyShow"              // daily podcast of the top news
 )
 
 func (s SystemConfigKey) String() string {
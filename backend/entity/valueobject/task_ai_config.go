@@ -10,6 +10,7 @@ type TaskAi struct {
 	SessionId string `json:"sessionId"`
 	Platform  string `json:"platform"`
 	Model     string `json:"model"`
+	Profile   string `json:"profile,omitempty"` // the model profile routed to, the default text ai if empty
 }
 
 // NewTaskAiFromTextAi creates a new TaskAi object from TextAiConfig
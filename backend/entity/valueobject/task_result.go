@@ -6,6 +6,7 @@ type TaskResult string
 const (
 	TaskResultCompleted TaskResult = "completed"
 	TaskResultFailed    TaskResult = "failed"
+	TaskResultCancelled TaskResult = "cancelled"
 )
 
 func (t TaskResult) IsCompleted() bool {
@@ -37,6 +37,8 @@ const (
 	StageStatusProcessing StageStatus = "processing"
 	StageStatusCompleted  StageStatus = "completed"
 	StageStatusFailed     StageStatus = "failed"
+	StageStatusReview     StageStatus = "review"    // paused for human review
+	StageStatusCancelled  StageStatus = "cancelled" // stopped by the user
 )
 
 func (s StageStatus) IsProcessing() bool {
@@ -49,6 +51,13 @@ type TaskStageExtra struct {
 	StageIds []uint
 }
 
+// StageFailure represents a failed or cancelled run of the task stage.
+type StageFailure struct {
+	Status   StageStatus `json:"status"`
+	Reason   string      `json:"reason,omitempty"`
+	FailedAt time.Time   `json:"failedAt"`
+}
+
 // TaskStage represents a task stage.
 type TaskStage struct {
 	Id        uint
@@ -62,6 +71,10 @@ type TaskStage struct {
 	Audio     *PodcastAudio
 	TaskAi    *TaskAi
 	Extra     *TaskStageExtra
+	Approval  *ApprovalResult // parsed output of the approval stage
+	Classify  *ClassifyResult // parsed output of the classify stage
+	Attempts  int             // runs of the stage, the retries included
+	Failures  []*StageFailure // the failed runs before the retries
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -73,6 +86,7 @@ func NewTaskStage(stage TaskStageName, prompt string, ai *TaskAi) *TaskStage {
 		Status:    StageStatusProcessing,
 		Prompt:    prompt,
 		TaskAi:    ai,
+		Attempts:  1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -92,6 +106,10 @@ func NewTaskStageFromModel(m *model.PodcastTask) (*TaskStage, error) {
 		Audio:     gokit.UnmarshalSafe[*PodcastAudio](m.Audio),
 		TaskAi:    gokit.UnmarshalSafe[*TaskAi](m.TaskAi),
 		Extra:     gokit.UnmarshalSafe[*TaskStageExtra](m.Extra),
+		Approval:  gokit.UnmarshalSafe[*ApprovalResult](m.Approval),
+		Classify:  gokit.UnmarshalSafe[*ClassifyResult](m.Classify),
+		Attempts:  m.Attempts,
+		Failures:  gokit.UnmarshalSafe[[]*StageFailure](m.Failures),
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}, nil
@@ -104,6 +122,37 @@ func (s *TaskStage) Fail(reason string) {
 	s.UpdatedAt = time.Now()
 }
 
+// Cancel marks the task stage as cancelled by the user.
+func (s *TaskStage) Cancel() {
+	s.Status = StageStatusCancelled
+	s.Reason = "cancelled by the user"
+	s.UpdatedAt = time.Now()
+}
+
+// IsRetryable checks whether the task stage can be run again.
+func (s *TaskStage) IsRetryable() bool {
+	return s.Status == StageStatusFailed || s.Status == StageStatusCancelled
+}
+
+// Retry resets the failed or cancelled task stage to processing with the same inputs, recording the failure.
+func (s *TaskStage) Retry() {
+	s.Failures = append(s.Failures, &StageFailure{Status: s.Status, Reason: s.Reason, FailedAt: s.UpdatedAt})
+	s.Attempts = max(s.Attempts, 1) + 1 // the stages created before the retries were counted
+	s.Status = StageStatusProcessing
+	s.Reason = ""
+	s.Output = ""
+	s.Approval = nil
+	s.Classify = nil
+	s.UpdatedAt = time.Now()
+}
+
+// Review pauses the task stage for human review.
+func (s *TaskStage) Review(reason string) {
+	s.Status = StageStatusReview
+	s.Reason = reason
+	s.UpdatedAt = time.Now()
+}
+
 // SetStatus sets the status of the task stage.
 func (s *TaskStage) SetStatus(status StageStatus) {
 	s.Status = status
@@ -19,11 +19,16 @@ var (
 	NewsWebsiteConfigNotFound = NewBasicError(101016, "error.newsWebsiteConfigNotFound")
 	PodcastPromptNotFound     = NewBasicError(101017, "error.podcastPromptNotFound")
 	PodcastVoiceNotFound      = NewBasicError(101018, "error.podcastVoiceNotFound")
+	EmbeddingConfigNotFound   = NewBasicError(101019, "error.embeddingConfigNotFound")
 )
 
 // news error
 var (
-	NewsNotFound = NewBasicError(102011, "error.newsNotFound")
+	NewsNotFound         = NewBasicError(102011, "error.newsNotFound")
+	ScrapeNewsFailed     = NewBasicError(102012, "error.scrapeNewsFailed")
+	NewsRevisionNotFound = NewBasicError(102013, "error.newsRevisionNotFound")
+	NewsSummaryNotFound  = NewBasicError(102014, "error.newsSummaryNotFound")
+	NewsAnalysisNotFound = NewBasicError(102015, "error.newsAnalysisNotFound")
 )
 
 // crawling error
@@ -38,4 +43,33 @@ var (
 	PodcastTaskNotFound     = NewBasicError(104011, "error.podcastTaskNotFound")
 	PodcastGenerationFailed = NewBasicError(104012, "error.podcastGenerationFailed")
 	PodcastScriptNotFound   = NewBasicError(104013, "error.podcastScriptNotFound")
+	TaskStageNotInReview    = NewBasicError(104014, "error.taskStageNotInReview")
+	TaskNotProcessing       = NewBasicError(104015, "error.taskNotProcessing")
+	TaskStageNotRetryable   = NewBasicError(104016, "error.taskStageNotRetryable")
+	PodcastNotFound         = NewBasicError(104017, "error.podcastNotFound")
+	TaskNotCompleted        = NewBasicError(104018, "error.taskNotCompleted")
+	PodcastPublished        = NewBasicError(104019, "error.podcastPublished")
+	JobLeaseLost            = NewBasicError(104020, "error.jobLeaseLost")
+)
+
+// digest error
+var (
+	NewsDigestNotFound   = NewBasicError(105011, "error.newsDigestNotFound")
+	NewsDigestProcessing = NewBasicError(105012, "error.newsDigestProcessing")
+)
+
+// chat error
+var (
+	ChatSessionNotFound = NewBasicError(106011, "error.chatSessionNotFound")
+)
+
+// prompt error
+var (
+	PromptTemplateNotFound = NewBasicError(107011, "error.promptTemplateNotFound")
+	PromptTemplateInvalid  = NewBasicError(107012, "error.promptTemplateInvalid")
+)
+
+// usage error
+var (
+	AiBudgetExceeded = NewBasicError(108011, "error.aiBudgetExceeded")
 )
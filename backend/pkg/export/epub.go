@@ -0,0 +1,308 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/logx"
+)
+
+// epub file templates
+var (
+	epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+	epubFuncs = template.FuncMap{"xml": xmlEscape}
+
+	epubPackage = template.Must(template.New("opf").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">{{.Identifier}}</dc:identifier>
+    <dc:title>{{xml .Title}}</dc:title>
+    <dc:language>{{.Language}}</dc:language>
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+{{- range .Chapters}}
+    <item id="{{.Id}}" href="{{.Href}}" media-type="application/xhtml+xml"/>
+{{- end}}
+{{- range .Images}}
+    <item id="{{.Id}}" href="{{.Href}}" media-type="{{.ContentType}}"/>
+{{- end}}
+  </manifest>
+  <spine>
+{{- range .Chapters}}
+    <itemref idref="{{.Id}}"/>
+{{- end}}
+  </spine>
+</package>
+`))
+
+	epubNav = template.Must(template.New("nav").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{.Language}}">
+<head><title>{{xml .Title}}</title></head>
+<body>
+<nav epub:type="toc" id="toc">
+<h1>{{xml .Title}}</h1>
+<ol>
+{{- range .Chapters}}
+<li><a href="{{.Href}}">{{xml .Title}}</a>
+<ol>
+{{- range .Articles}}
+<li><a href="{{.Href}}">{{xml .Title}}</a></li>
+{{- end}}
+</ol>
+</li>
+{{- end}}
+</ol>
+</nav>
+</body>
+</html>
+`))
+
+	epubChapter = template.Must(template.New("chapter").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="{{.Language}}">
+<head><title>{{xml .Title}}</title></head>
+<body>
+<h1>{{xml .Title}}</h1>
+{{- range .Articles}}
+<section id="{{.Id}}">
+<h2>{{xml .Title}}</h2>
+{{- if .Meta}}
+<p><em>{{xml .Meta}}</em></p>
+{{- end}}
+{{- range .Images}}
+<img src="{{.}}" alt=""/>
+{{- end}}
+{{- range .Contents}}
+<p>{{xml .}}</p>
+{{- end}}
+{{- if .Link}}
+<p><a href="{{xml .Link}}">{{xml .Link}}</a></p>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+)
+
+// epubExporter exports the document as epub 3
+type epubExporter struct {
+	loader ImageLoader
+}
+
+func newEpubExporter(loader ImageLoader) *epubExporter {
+	return &epubExporter{loader: loader}
+}
+
+// epubChapterData is the template data of the chapter
+type epubChapterData struct {
+	Id       string
+	Href     string
+	Title    string
+	Language string
+	Articles []*epubArticleData
+}
+
+// epubArticleData is the template data of the article
+type epubArticleData struct {
+	Id       string
+	Href     string
+	Title    string
+	Meta     string
+	Link     string
+	Contents []string
+	Images   []string
+}
+
+// epubImageData is the template data of the image
+type epubImageData struct {
+	Id          string
+	Href        string
+	ContentType string
+	Data        []byte
+}
+
+// Export renders the document into epub with a table of contents
+func (e *epubExporter) Export(ctx context.Context, doc *Document) ([]byte, error) {
+	var (
+		chapters = make([]*epubChapterData, 0, len(doc.Chapters))
+		images   = make([]*epubImageData, 0)
+		language = doc.Language
+	)
+
+	if language == "" {
+		language = "en"
+	}
+
+	imageCtx, cancel := context.WithTimeout(ctx, maxImageLoadTime)
+	defer cancel()
+
+	for chapterIdx, chapter := range doc.Chapters {
+		item := &epubChapterData{
+			Id:       fmt.Sprintf("chapter%d", chapterIdx+1),
+			Href:     fmt.Sprintf("chapter%d.xhtml", chapterIdx+1),
+			Title:    chapter.Title,
+			Language: language,
+		}
+
+		if item.Title == "" {
+			item.Title = doc.Title
+		}
+
+		for articleIdx, article := range chapter.Articles {
+			articleId := fmt.Sprintf("article%d_%d", chapterIdx+1, articleIdx+1)
+
+			data := &epubArticleData{
+				Id:       articleId,
+				Href:     fmt.Sprintf("%s#%s", item.Href, articleId),
+				Title:    article.Title,
+				Meta:     articleMeta(article),
+				Link:     article.Link,
+				Contents: article.Contents,
+			}
+
+			for _, link := range article.Images {
+				if imageCtx.Err() != nil {
+					break
+				}
+
+				image, err := e.loader(imageCtx, link)
+				if err != nil {
+					logx.WithContext(ctx).Error("epubExporter.loadImage", err)
+
+					continue
+				}
+
+				imageData := &epubImageData{
+					Id:          fmt.Sprintf("image%d", len(images)+1),
+					ContentType: image.ContentType,
+					Data:        image.Data,
+				}
+				imageData.Href = fmt.Sprintf("images/%s.%s", imageData.Id, image.Extension())
+
+				images = append(images, imageData)
+				data.Images = append(data.Images, imageData.Href)
+			}
+
+			item.Articles = append(item.Articles, data)
+		}
+
+		chapters = append(chapters, item)
+	}
+
+	return e.writeArchive(doc, language, chapters, images)
+}
+
+// writeArchive writes the epub zip archive
+func (e *epubExporter) writeArchive(doc *Document, language string, chapters []*epubChapterData,
+	images []*epubImageData) ([]byte, error) {
+	var (
+		buf    bytes.Buffer
+		writer = zip.NewWriter(&buf)
+		meta   = map[string]any{
+			"Identifier": fmt.Sprintf("urn:world-news:%d", doc.CreatedAt.UnixNano()),
+			"Title":      doc.Title,
+			"Language":   language,
+			"Modified":   doc.CreatedAt.UTC().Format(time.RFC3339),
+			"Chapters":   chapters,
+			"Images":     images,
+		}
+	)
+
+	// the mimetype must be the first entry and stored without compression
+	mimetype, err := writer.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if _, err := mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := writeZipFile(writer, "META-INF/container.xml", []byte(epubContainer)); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipTemplate(writer, "OEBPS/content.opf", epubPackage, meta); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipTemplate(writer, "OEBPS/nav.xhtml", epubNav, meta); err != nil {
+		return nil, err
+	}
+
+	for _, chapter := range chapters {
+		if err := writeZipTemplate(writer, "OEBPS/"+chapter.Href, epubChapter, chapter); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, image := range images {
+		if err := writeZipFile(writer, "OEBPS/"+image.Href, image.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeZipFile writes a file into the zip archive
+func writeZipFile(writer *zip.Writer, name string, data []byte) error {
+	file, err := writer.Create(name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = file.Write(data)
+
+	return errors.WithStack(err)
+}
+
+// writeZipTemplate renders the template into the zip archive
+func writeZipTemplate(writer *zip.Writer, name string, tmpl *template.Template, data any) error {
+	file, err := writer.Create(name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(tmpl.Execute(file, data))
+}
+
+// xmlEscape escapes the text for xhtml
+func xmlEscape(text string) string {
+	var buf bytes.Buffer
+
+	template.HTMLEscape(&buf, []byte(text))
+
+	return buf.String()
+}
+
+// Extension returns the file extension of the exported file
+func (e *epubExporter) Extension() string {
+	return "epub"
+}
+
+// ContentType returns the mime type of the exported file
+func (e *epubExporter) ContentType() string {
+	return "application/epub+zip"
+}
@@ -0,0 +1,232 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/urlx"
+)
+
+const (
+	// maxImageSize is the maximum size of an embedded image.
+	maxImageSize = 5 << 20
+
+	// imageTimeout is the timeout of loading an image.
+	imageTimeout = 10 * time.Second
+
+	// maxImageLoadTime is the maximum time of loading the images of a document, the rest are left out.
+	maxImageLoadTime = 2 * time.Minute
+)
+
+// Exporter is the interface for the document exporter
+type Exporter interface {
+	// Export renders the document into a file
+	Export(ctx context.Context, doc *Document) ([]byte, error)
+	// Extension returns the file extension of the exported file
+	Extension() string
+	// ContentType returns the mime type of the exported file
+	ContentType() string
+}
+
+// Format is the export format
+type Format string
+
+const (
+	MarkdownFormat Format = "markdown"
+	HtmlFormat     Format = "html"
+	EpubFormat     Format = "epub"
+)
+
+// Document represents a collection of articles to export
+type Document struct {
+	Title     string
+	Language  string
+	Chapters  []*Chapter
+	CreatedAt time.Time
+}
+
+// Chapter represents a group of articles, such as a news source or topic
+type Chapter struct {
+	Title    string
+	Articles []*Article
+}
+
+// Article represents a single exported article
+type Article struct {
+	Title       string
+	Source      string
+	Topic       string
+	Author      string
+	Link        string
+	PublishedAt time.Time
+	Contents    []string
+	Images      []string
+}
+
+// Image represents a downloaded image
+type Image struct {
+	Data        []byte
+	ContentType string
+}
+
+// ImageLoader loads the image from the link
+type ImageLoader func(ctx context.Context, link string) (*Image, error)
+
+// NewExporter creates a new exporter
+func NewExporter(format Format, loader ImageLoader) (Exporter, error) {
+	if loader == nil {
+		loader = HttpImageLoader(&http.Client{Timeout: imageTimeout})
+	}
+
+	switch format {
+	case MarkdownFormat:
+		return newMarkdownExporter(), nil
+	case HtmlFormat:
+		return newHtmlExporter(loader), nil
+	case EpubFormat:
+		return newEpubExporter(loader), nil
+	default:
+		return nil, errorx.ParamsError.SetMessage(fmt.Sprintf("unsupported export format: %s", format))
+	}
+}
+
+// HttpImageLoader creates an image loader based on the http client
+func HttpImageLoader(client *http.Client) ImageLoader {
+	return func(ctx context.Context, link string) (*Image, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("load image %s, status: %d", link, resp.StatusCode)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return &Image{Data: data, ContentType: imageContentType(link, resp.Header.Get("Content-Type"), data)}, nil
+	}
+}
+
+// DataURI returns the image as a data uri
+func (i *Image) DataURI() string {
+	return fmt.Sprintf("data:%s;base64,%s", i.ContentType, base64.StdEncoding.EncodeToString(i.Data))
+}
+
+// Extension returns the file extension of the image
+func (i *Image) Extension() string {
+	switch i.ContentType {
+	case "image/png":
+		return "png"
+	case "image/gif":
+		return "gif"
+	case "image/webp":
+		return "webp"
+	case "image/svg+xml":
+		return "svg"
+	default:
+		return "jpg"
+	}
+}
+
+// imageContentType detects the content type of the image
+func imageContentType(link, header string, data []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(header); err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+
+	if contentType := mime.TypeByExtension(path.Ext(urlx.RemoveQueryParams(link))); strings.HasPrefix(contentType,
+		"image/") {
+		return contentType
+	}
+
+	if contentType := http.DetectContentType(data); strings.HasPrefix(contentType, "image/") {
+		return contentType
+	}
+
+	return "image/jpeg"
+}
+
+// FileName returns the file name of the exported document
+func FileName(doc *Document, exporter Exporter) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|`, r) {
+			return '_'
+		}
+
+		return r
+	}, strings.TrimSpace(doc.Title))
+
+	if name == "" {
+		name = "world-news"
+	}
+
+	return fmt.Sprintf("%s_%s.%s", name, doc.CreatedAt.Format("060102150405"), exporter.Extension())
+}
+
+// articleMeta returns the meta line of the article
+func articleMeta(article *Article) string {
+	items := make([]string, 0, 4)
+
+	for _, item := range []string{article.Source, article.Topic, article.Author} {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	if !article.PublishedAt.IsZero() {
+		items = append(items, article.PublishedAt.Format(time.DateOnly))
+	}
+
+	return strings.Join(items, " | ")
+}
+
+// File represents an exported file
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Save saves the exported file into the directory and returns the file path
+func (f *File) Save(dir string) (string, error) {
+	file := filepath.Join(dir, f.Name)
+
+	return file, errors.WithStack(os.WriteFile(file, f.Data, 0644))
+}
+
+// ExportFile renders the document into an exported file
+func ExportFile(ctx context.Context, format Format, doc *Document) (*File, error) {
+	exporter, err := NewExporter(format, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := exporter.Export(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{Name: FileName(doc, exporter), ContentType: exporter.ContentType(), Data: data}, nil
+}
@@ -0,0 +1,147 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDocument creates a document for testing
+func testDocument() *Document {
+	return &Document{
+		Title:     "World News",
+		Language:  "en",
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Chapters: []*Chapter{
+			{
+				Title: "example",
+				Articles: []*Article{
+					{
+						Title:       "Markets rally <again> & again",
+						Source:      "example",
+						Topic:       "business",
+						Link:        "https://www.example.com/news/1",
+						PublishedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						Contents:    []string{"First paragraph.", "Second paragraph."},
+						Images:      []string{"https://www.example.com/image.png"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testImageLoader returns a fixed image
+func testImageLoader(ctx context.Context, link string) (*Image, error) {
+	return &Image{Data: []byte("image"), ContentType: "image/png"}, nil
+}
+
+// TestMarkdownExport testing markdown export
+func TestMarkdownExport(t *testing.T) {
+	exporter, err := NewExporter(MarkdownFormat, testImageLoader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := exporter.Export(context.Background(), testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"# World News", "## example", "### Markets rally", "Second paragraph."} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("markdown missing %q", expected)
+		}
+	}
+}
+
+// TestHtmlExport testing html export
+func TestHtmlExport(t *testing.T) {
+	exporter, err := NewExporter(HtmlFormat, testImageLoader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := exporter.Export(context.Background(), testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), "data:image/png;base64,") {
+		t.Error("html image is not embedded")
+	}
+
+	if !strings.Contains(string(data), "&lt;again&gt; &amp; again") {
+		t.Error("html title is not escaped")
+	}
+}
+
+// TestEpubExport testing epub export
+func TestEpubExport(t *testing.T) {
+	exporter, err := NewExporter(EpubFormat, testImageLoader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := exporter.Export(context.Background(), testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reader.File[0].Name != "mimetype" || reader.File[0].Method != zip.Store {
+		t.Error("mimetype must be the first stored entry")
+	}
+
+	files := make(map[string]bool)
+	for _, file := range reader.File {
+		files[file.Name] = true
+	}
+
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml",
+		"OEBPS/chapter1.xhtml", "OEBPS/images/image1.png"} {
+		if !files[name] {
+			t.Errorf("epub missing %s", name)
+		}
+	}
+}
+
+// TestUnsupportedFormat testing unsupported format
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewExporter("pdf", nil); err == nil {
+		t.Error("expected unsupported format error")
+	}
+}
+
+// TestExportImageDeadline testing the images are left out once the load time is over
+func TestExportImageDeadline(t *testing.T) {
+	loaded := 0
+
+	exporter, err := NewExporter(HtmlFormat, func(ctx context.Context, link string) (*Image, error) {
+		loaded++
+
+		return testImageLoader(ctx, link)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	data, err := exporter.Export(ctx, testDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded != 0 || !strings.Contains(string(data), "Second paragraph.") {
+		t.Errorf("expected the article without images, loaded %d", loaded)
+	}
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/logx"
+)
+
+// htmlTemplate is the template of the standalone html document
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 800px; margin: 0 auto; padding: 24px; font-family: sans-serif; line-height: 1.6; }
+img { max-width: 100%; }
+.meta { color: #666; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- range .Chapters}}
+<section>
+{{- if .Title}}
+<h2>{{.Title}}</h2>
+{{- end}}
+{{- range .Articles}}
+<article>
+<h3>{{.Title}}</h3>
+{{- if .Meta}}
+<p class="meta">{{.Meta}}</p>
+{{- end}}
+{{- range .Images}}
+<img src="{{.}}" alt="">
+{{- end}}
+{{- range .Contents}}
+<p>{{.}}</p>
+{{- end}}
+{{- if .Link}}
+<p><a href="{{.Link}}">{{.Link}}</a></p>
+{{- end}}
+</article>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// htmlExporter exports the document as standalone html
+type htmlExporter struct {
+	loader ImageLoader
+}
+
+func newHtmlExporter(loader ImageLoader) *htmlExporter {
+	return &htmlExporter{loader: loader}
+}
+
+// htmlChapter is the template data of the chapter
+type htmlChapter struct {
+	Title    string
+	Articles []*htmlArticle
+}
+
+// htmlArticle is the template data of the article
+type htmlArticle struct {
+	Title    string
+	Meta     string
+	Link     string
+	Contents []string
+	Images   []template.URL
+}
+
+// Export renders the document into html with embedded images
+func (e *htmlExporter) Export(ctx context.Context, doc *Document) ([]byte, error) {
+	data := struct {
+		Title    string
+		Language string
+		Chapters []*htmlChapter
+	}{Title: doc.Title, Language: doc.Language}
+
+	imageCtx, cancel := context.WithTimeout(ctx, maxImageLoadTime)
+	defer cancel()
+
+	for _, chapter := range doc.Chapters {
+		item := &htmlChapter{Title: chapter.Title}
+
+		for _, article := range chapter.Articles {
+			item.Articles = append(item.Articles, &htmlArticle{
+				Title:    article.Title,
+				Meta:     articleMeta(article),
+				Link:     article.Link,
+				Contents: article.Contents,
+				Images:   e.embedImages(imageCtx, article.Images),
+			})
+		}
+
+		data.Chapters = append(data.Chapters, item)
+	}
+
+	var buf bytes.Buffer
+
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// embedImages converts the image links to data uris
+func (e *htmlExporter) embedImages(ctx context.Context, links []string) []template.URL {
+	images := make([]template.URL, 0, len(links))
+
+	for _, link := range links {
+		if ctx.Err() != nil {
+			break
+		}
+
+		image, err := e.loader(ctx, link)
+		if err != nil {
+			logx.WithContext(ctx).Error("htmlExporter.embedImages", err)
+
+			continue
+		}
+
+		images = append(images, template.URL(image.DataURI()))
+	}
+
+	return images
+}
+
+// Extension returns the file extension of the exported file
+func (e *htmlExporter) Extension() string {
+	return "html"
+}
+
+// ContentType returns the mime type of the exported file
+func (e *htmlExporter) ContentType() string {
+	return "text/html; charset=utf-8"
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// markdownExporter exports the document as markdown
+type markdownExporter struct{}
+
+func newMarkdownExporter() *markdownExporter {
+	return &markdownExporter{}
+}
+
+// Export renders the document into markdown
+func (e *markdownExporter) Export(ctx context.Context, doc *Document) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", doc.Title)
+
+	for _, chapter := range doc.Chapters {
+		if chapter.Title != "" {
+			fmt.Fprintf(&b, "## %s\n\n", chapter.Title)
+		}
+
+		for _, article := range chapter.Articles {
+			fmt.Fprintf(&b, "### %s\n\n", article.Title)
+
+			if meta := articleMeta(article); meta != "" {
+				fmt.Fprintf(&b, "*%s*\n\n", meta)
+			}
+
+			for _, image := range article.Images {
+				fmt.Fprintf(&b, "![](%s)\n\n", image)
+			}
+
+			for _, content := range article.Contents {
+				fmt.Fprintf(&b, "%s\n\n", content)
+			}
+
+			if article.Link != "" {
+				fmt.Fprintf(&b, "[%s](%s)\n\n", article.Link, article.Link)
+			}
+		}
+	}
+
+	return []byte(b.String()), nil
+}
+
+// Extension returns the file extension of the exported file
+func (e *markdownExporter) Extension() string {
+	return "md"
+}
+
+// ContentType returns the mime type of the exported file
+func (e *markdownExporter) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
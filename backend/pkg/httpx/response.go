@@ -4,6 +4,9 @@ import (
 	"github.com/mjiee/world-news/backend/pkg/errorx"
 )
 
+// StreamResultEvent is the name of the server-sent event carrying the response of a stream.
+const StreamResultEvent = "result"
+
 // Response is a public response struct.
 type Response struct {
 	Code    uint32 `json:"code"`
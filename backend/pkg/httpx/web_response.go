@@ -3,7 +3,9 @@
 package httpx
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/mjiee/world-news/backend/pkg/errorx"
 	"github.com/mjiee/world-news/backend/pkg/locale"
@@ -13,6 +15,22 @@ import (
 
 // WebResp is a function that handles the response of web application.
 func WebResp(c *gin.Context, result any, err error) {
+	c.JSON(http.StatusOK, webResponse(c, result, err))
+}
+
+// WebEvent is a function that writes a server-sent event and flushes it to the client.
+func WebEvent(c *gin.Context, name string, data any) {
+	c.SSEvent(name, data)
+	c.Writer.Flush()
+}
+
+// WebStreamResp is a function that ends the server-sent events with the response as the result event.
+func WebStreamResp(c *gin.Context, result any, err error) {
+	WebEvent(c, StreamResultEvent, webResponse(c, result, err))
+}
+
+// webResponse builds the response of the result and error.
+func webResponse(c *gin.Context, result any, err error) *Response {
 	var resp = Ok(result)
 
 	if err != nil {
@@ -31,5 +49,36 @@ func WebResp(c *gin.Context, result any, err error) {
 		}
 	}
 
-	c.JSON(http.StatusOK, resp)
+	return resp
+}
+
+// WebFile is a function that handles the file response of web application.
+func WebFile(c *gin.Context, name, contentType string, data []byte) {
+	c.Header("Content-Disposition", contentDisposition(name))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// contentDisposition builds the attachment header of the file name, an ascii fallback for the old clients
+// and the utf-8 encoded name (RFC 6266).
+func contentDisposition(name string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	var encoded strings.Builder
+
+	for _, b := range []byte(name) {
+		if ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9') ||
+			strings.IndexByte("!#$&+-.^_`|~", b) >= 0 {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, fallback, encoded.String())
 }
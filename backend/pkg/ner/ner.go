@@ -0,0 +1,223 @@
+package ner
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EntityType is the type of a named entity
+type EntityType string
+
+const (
+	Person       EntityType = "person"
+	Organization EntityType = "organization"
+	Location     EntityType = "location"
+)
+
+// IsValid checks if the entity type is supported
+func (t EntityType) IsValid() bool {
+	return t == Person || t == Organization || t == Location
+}
+
+// Entity represents a named entity found in the text
+type Entity struct {
+	Name  string     `json:"name"`
+	Type  EntityType `json:"type"`
+	Count int        `json:"count,omitempty"`
+}
+
+// Key returns the normalized key of the entity name
+func Key(name string) string {
+	return strings.ToLower(Normalize(name))
+}
+
+// Normalize trims the entity name and collapses the spaces
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(strings.Trim(name, " \t\n\"'“”‘’.,;:()[]")), " ")
+}
+
+// Merge merges the duplicate entities, summing the counts
+func Merge(entities []*Entity) []*Entity {
+	var (
+		result = make([]*Entity, 0, len(entities))
+		index  = make(map[string]*Entity, len(entities))
+	)
+
+	for _, item := range entities {
+		name := Normalize(item.Name)
+		if name == "" || !item.Type.IsValid() {
+			continue
+		}
+
+		key := string(item.Type) + ":" + Key(name)
+
+		if existing, ok := index[key]; ok {
+			existing.Count += max(item.Count, 1)
+
+			continue
+		}
+
+		entity := &Entity{Name: name, Type: item.Type, Count: max(item.Count, 1)}
+		index[key] = entity
+		result = append(result, entity)
+	}
+
+	return result
+}
+
+// Extract finds the people, organizations and locations of the text by capitalization rules.
+// It is a fallback for the language model and only supports latin text.
+func Extract(text string) []*Entity {
+	entities := make([]*Entity, 0)
+
+	for _, sentence := range splitSentences(text) {
+		words := strings.Fields(sentence)
+
+		for i := 0; i < len(words); {
+			span := capitalizedSpan(words, i)
+			if len(span) == 0 {
+				i++
+
+				continue
+			}
+
+			// the first word of the sentence is capitalized anyway
+			if i == 0 && len(span) == 1 && !isAcronym(span[0]) && !isKnownLocation(span[0]) {
+				i++
+
+				continue
+			}
+
+			// such as "Shares of Apple Inc" at the beginning of the sentence
+			if i == 0 && len(span) > 2 && connectors[span[1]] && !organizationWords[strings.ToLower(span[0])] {
+				i += 2
+
+				continue
+			}
+
+			var prev string
+			if i > 0 {
+				prev = strings.ToLower(strings.Trim(words[i-1], "\"'“‘("))
+			}
+
+			if entityType, ok := classify(span, prev); ok {
+				entities = append(entities, &Entity{Name: strings.Join(span, " "), Type: entityType})
+			}
+
+			i += len(span)
+		}
+	}
+
+	return Merge(entities)
+}
+
+// splitSentences splits the text into sentences
+func splitSentences(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?' || r == '\n' || r == ';' || r == ':' || r == '"' || r == '“' ||
+			r == '”' || r == '(' || r == ')'
+	})
+}
+
+// capitalizedSpan returns the capitalized words starting at the index, allowing connectors such as "of"
+func capitalizedSpan(words []string, start int) []string {
+	span := make([]string, 0)
+
+	for i := start; i < len(words); i++ {
+		word := cleanWord(words[i])
+
+		if isCapitalized(word) {
+			span = append(span, word)
+
+			// a trailing comma ends the entity
+			if strings.HasSuffix(words[i], ",") {
+				break
+			}
+
+			continue
+		}
+
+		// connectors inside an entity, such as "Bank of England"
+		if len(span) > 0 && connectors[word] && i+1 < len(words) && isCapitalized(cleanWord(words[i+1])) {
+			span = append(span, word)
+
+			continue
+		}
+
+		break
+	}
+
+	return span
+}
+
+// cleanWord removes the punctuation and the possessive suffix of the word
+func cleanWord(word string) string {
+	word = strings.Trim(word, ",\"'“”‘’()[]")
+	word = strings.TrimSuffix(word, "'s")
+
+	return strings.TrimSuffix(word, "’s")
+}
+
+// isCapitalized checks if the word starts with an upper case letter
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r) && !stopWords[strings.ToLower(word)]
+	}
+
+	return false
+}
+
+// isAcronym checks if the word is an upper case acronym, such as "NATO"
+func isAcronym(word string) bool {
+	letters := 0
+
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	return letters >= 2 && letters <= 6
+}
+
+// classify classifies the capitalized span
+func classify(span []string, prev string) (EntityType, bool) {
+	name := strings.Join(span, " ")
+
+	for _, word := range span {
+		if organizationWords[strings.ToLower(word)] {
+			return Organization, true
+		}
+	}
+
+	if isKnownLocation(name) {
+		return Location, true
+	}
+
+	if len(span) == 1 && isAcronym(span[0]) {
+		return Organization, true
+	}
+
+	if locationPrepositions[prev] && len(span) <= 3 {
+		return Location, true
+	}
+
+	if len(span) >= 2 && len(span) <= 3 && !connectors[strings.ToLower(span[1])] {
+		return Person, true
+	}
+
+	if len(span) == 1 && personTitles[prev] {
+		return Person, true
+	}
+
+	return "", false
+}
+
+// isKnownLocation checks if the name is a known country, region or city
+func isKnownLocation(name string) bool {
+	return locations[strings.ToLower(name)]
+}
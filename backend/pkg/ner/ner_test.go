@@ -0,0 +1,51 @@
+package ner
+
+import (
+	"testing"
+)
+
+// TestExtract testing rule-based entity extraction
+func TestExtract(t *testing.T) {
+	text := "President Joe Biden met Emmanuel Macron in Paris on Monday. " +
+		"The Bank of England raised rates, NATO said. Shares of Apple Inc fell in Tokyo."
+
+	expected := map[string]EntityType{
+		"Joe Biden":       Person,
+		"Emmanuel Macron": Person,
+		"Paris":           Location,
+		"Bank of England": Organization,
+		"NATO":            Organization,
+		"Apple Inc":       Organization,
+		"Tokyo":           Location,
+	}
+
+	found := make(map[string]EntityType)
+	for _, item := range Extract(text) {
+		found[item.Name] = item.Type
+	}
+
+	for name, entityType := range expected {
+		if found[name] != entityType {
+			t.Errorf("expected %s to be %s, got %q", name, entityType, found[name])
+		}
+	}
+
+	if _, ok := found["Monday"]; ok {
+		t.Error("weekday is not an entity")
+	}
+}
+
+// TestMerge testing entity merge
+func TestMerge(t *testing.T) {
+	entities := Merge([]*Entity{
+		{Name: "Paris", Type: Location},
+		{Name: " paris ", Type: Location},
+		{Name: "Paris", Type: Person},
+		{Name: "", Type: Location},
+		{Name: "Unknown", Type: "event"},
+	})
+
+	if len(entities) != 2 || entities[0].Count != 2 {
+		t.Errorf("unexpected merge result: %+v", entities)
+	}
+}
@@ -0,0 +1,62 @@
+package ner
+
+import "strings"
+
+// toSet converts the words to a set
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+
+	for _, word := range strings.Split(words, ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			set[word] = true
+		}
+	}
+
+	return set
+}
+
+var (
+	// connectors are the lower case words allowed inside an entity name
+	connectors = toSet("of, de, del, la, le, van, von, bin, al, and, for, the")
+
+	// stopWords are the capitalized words that are not entities
+	stopWords = toSet(`the, a, an, this, that, these, those, it, he, she, they, we, i, you, his, her, their, our,
+		but, and, or, if, when, while, after, before, as, at, in, on, for, by, with, from, to, of, about, over,
+		monday, tuesday, wednesday, thursday, friday, saturday, sunday, january, february, march, april, may, june,
+		july, august, september, october, november, december, mr, mrs, ms, dr, prof, president, minister, king,
+		queen, prince, pope, chancellor, senator, governor, mayor, ceo, chairman, general, sir, lord, new, breaking,
+		update, live, watch, video, opinion, analysis, exclusive, read, more, here, there, what, why, how, who`)
+
+	// personTitles are the words before a person name
+	personTitles = toSet(`mr, mrs, ms, dr, prof, president, minister, king, queen, prince, princess, pope,
+		chancellor, senator, governor, mayor, ceo, chairman, general, sir, lord, judge, coach, secretary`)
+
+	// locationPrepositions are the words before a location name
+	locationPrepositions = toSet("in, at, from, near, across, into, toward, towards, outside")
+
+	// organizationWords are the words of an organization name
+	organizationWords = toSet(`inc, corp, corporation, co, ltd, llc, plc, group, company, bank, ministry,
+		department, university, college, institute, agency, association, council, committee, commission, party,
+		union, federation, foundation, court, parliament, congress, senate, army, navy, police, fund, organization,
+		organisation, authority, airlines, airways, motors, technologies, holdings, news, times, post, journal,
+		network, club, fc, league, nations, bureau, office, administration, reserve, exchange, board, service`)
+
+	// locations are the known countries, regions and cities
+	locations = toSet(`afghanistan, albania, algeria, argentina, armenia, australia, austria, azerbaijan,
+		bangladesh, belarus, belgium, bolivia, brazil, bulgaria, cambodia, canada, chile, china, colombia, croatia,
+		cuba, cyprus, czechia, denmark, ecuador, egypt, estonia, ethiopia, finland, france, georgia, germany, ghana,
+		greece, hungary, iceland, india, indonesia, iran, iraq, ireland, israel, italy, japan, jordan, kazakhstan,
+		kenya, kosovo, kuwait, laos, latvia, lebanon, libya, lithuania, malaysia, mexico, moldova, mongolia,
+		morocco, myanmar, nepal, netherlands, new zealand, nigeria, north korea, norway, pakistan, palestine,
+		panama, peru, philippines, poland, portugal, qatar, romania, russia, rwanda, saudi arabia, serbia,
+		singapore, slovakia, slovenia, somalia, south africa, south korea, spain, sri lanka, sudan, sweden,
+		switzerland, syria, taiwan, thailand, tunisia, turkey, uganda, ukraine, united arab emirates,
+		united kingdom, united states, uruguay, uzbekistan, venezuela, vietnam, yemen, zambia, zimbabwe, britain,
+		england, scotland, wales, america, europe, asia, africa, antarctica, oceania, middle east, gaza,
+		west bank, crimea, hong kong, macau, beijing, shanghai, shenzhen, tokyo, seoul, delhi, new delhi, mumbai,
+		moscow, kyiv, kiev, london, paris, berlin, rome, madrid, brussels, vienna, warsaw, washington, new york,
+		los angeles, chicago, san francisco, toronto, ottawa, sydney, melbourne, dubai, tehran, jerusalem,
+		tel aviv, cairo, istanbul, ankara, riyadh, doha, bangkok, jakarta, manila, hanoi, singapore, geneva,
+		davos, brasilia, buenos aires, mexico city, lagos, nairobi, johannesburg, taipei, pyongyang, kabul,
+		baghdad, damascus, beirut, caracas, havana, lima, bogota, santiago`)
+)
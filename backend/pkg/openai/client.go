@@ -0,0 +1,239 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultTimeout is the default time to wait for the response of the model.
+	defaultTimeout = 120 * time.Second
+
+	// defaultMaxRetries is the default number of retries of a failed request.
+	defaultMaxRetries = 2
+
+	// maxRetryBackoff is the maximum time to wait before a retry.
+	maxRetryBackoff = 30 * time.Second
+)
+
+// retryBackoff is the time to wait before the first retry, doubled on every retry
+var retryBackoff = time.Second
+
+// sharedClient the http client shared by the models of an endpoint, built with the settings
+type sharedClient struct {
+	settings string
+	client   *http.Client
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = make(map[string]*sharedClient)
+)
+
+// newHTTPClient returns the http client of the config, shared by the models of the same endpoint and settings
+// so that the rate limit applies across the calls. A client is kept per endpoint and model, it is replaced once
+// the settings change. The client with a transport of its own is not shared.
+func newHTTPClient(config *Config) *http.Client {
+	var (
+		timeout    = time.Duration(config.Timeout) * time.Second
+		maxRetries = config.MaxRetries
+		key        = config.ApiUrl + "|" + config.Model
+		settings   = fmt.Sprintf("%d|%d|%d", config.Timeout, config.MaxRetries, config.RateLimit)
+	)
+
+	if config.Transport == nil {
+		clientsMu.Lock()
+		defer clientsMu.Unlock()
+
+		if shared, ok := clients[key]; ok && shared.settings == settings {
+			return shared.client
+		}
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	retry := &retryTransport{base: config.Transport, timeout: timeout, maxRetries: max(maxRetries, 0)}
+
+	if retry.base == nil {
+		retry.base = defaultTransport{}
+	}
+
+	if config.RateLimit > 0 {
+		retry.limiter = rate.NewLimiter(rate.Limit(float64(config.RateLimit)/60), 1)
+	}
+
+	client := &http.Client{Transport: retry}
+
+	if config.Transport == nil {
+		clients[key] = &sharedClient{settings: settings, client: client}
+	}
+
+	return client
+}
+
+// defaultTransport sends the requests with http.DefaultTransport at the time of the request
+type defaultTransport struct{}
+
+// RoundTrip sends the request with http.DefaultTransport
+func (defaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// errResponseTimeout is the error of the attempt without a response in time
+var errResponseTimeout = &timeoutError{}
+
+// timeoutError is a net.Error timing out, so that the attempt is retried
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "openai: timeout awaiting response" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+// retryTransport limits the request rate and retries the requests failed with 429, 5xx or a timeout
+type retryTransport struct {
+	base       http.RoundTripper
+	limiter    *rate.Limiter
+	timeout    time.Duration // the timeout of each attempt until the response starts
+	maxRetries int
+}
+
+// RoundTrip sends the request, retrying with an exponential backoff
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.roundTrip(attemptReq)
+		if attempt >= t.maxRetries || !retryable(ctx, resp, err) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		wait := backoff(attempt, resp)
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.WithStack(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// roundTrip sends the attempt, failing with a timeout if the response does not start in time.
+// The timeout stops once the response starts, a stream is not cut off.
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(t.timeout, cancel)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() {
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		cancel()
+
+		return nil, errResponseTimeout
+	}
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelBody releases the context of the attempt once the response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+// rewindRequest returns the request with a fresh body for the retry
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	retryReq := req.Clone(req.Context())
+	retryReq.Body = body
+
+	return retryReq, nil
+}
+
+// retryable checks whether the request failed with 429, 5xx or a timeout of the attempt
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		var netErr net.Error
+
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the time to wait before the retry, the Retry-After header takes precedence
+func backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			return min(time.Duration(seconds)*time.Second, maxRetryBackoff)
+		}
+	}
+
+	wait := min(retryBackoff<<attempt, maxRetryBackoff)
+
+	// jitter spreads the retries of the concurrent calls
+	return wait/2 + rand.N(wait/2+1)
+}
@@ -1,5 +1,7 @@
 package openai
 
+import "net/http"
+
 // Config is the configuration for the OpenAI API
 type Config struct {
 	Platform string `json:"platform"`
@@ -9,4 +11,65 @@ type Config struct {
 
 	Model     string `json:"model"`
 	MaxTokens int    `json:"maxTokens"`
+
+	Timeout    int       `json:"timeout,omitempty"`    // seconds to wait for a response, 120 by default
+	MaxRetries int       `json:"maxRetries,omitempty"` // retries on 429, 5xx and timeouts, 2 by default, -1 to disable
+	RateLimit  int       `json:"rateLimit,omitempty"`  // requests per minute, no limit by default
+	Fallbacks  []*Config `json:"fallbacks,omitempty"`  // models tried in order once the model fails
+	JSONSchema bool      `json:"jsonSchema,omitempty"` // the model supports the json schema response format
+
+	// Transport sends the requests, http.DefaultTransport if nil. It is set to capture the requests of the model.
+	Transport http.RoundTripper `json:"-"`
+}
+
+// fallback returns the fallback config, the empty fields are inherited from the config
+func (c *Config) fallback(fallback *Config) *Config {
+	config := *fallback
+	config.Fallbacks = nil
+
+	if config.Platform == "" {
+		config.Platform = c.Platform
+	}
+
+	if config.ApiKey == "" {
+		config.ApiKey = c.ApiKey
+	}
+
+	if config.ApiUrl == "" {
+		config.ApiUrl = c.ApiUrl
+	}
+
+	if config.MaxTokens == 0 {
+		config.MaxTokens = c.MaxTokens
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = c.Timeout
+	}
+
+	if config.MaxRetries == 0 {
+		config.MaxRetries = c.MaxRetries
+	}
+
+	if config.RateLimit == 0 {
+		config.RateLimit = c.RateLimit
+	}
+
+	if config.Transport == nil {
+		config.Transport = c.Transport
+	}
+
+	return &config
+}
+
+// EmbeddingConfig is the configuration for the OpenAI compatible embeddings API
+type EmbeddingConfig struct {
+	Platform string `json:"platform"`
+
+	ApiKey string `json:"apiKey"`
+	ApiUrl string `json:"apiUrl"`
+
+	Model      string `json:"model"`
+	Dimensions int    `json:"dimensions,omitempty"`
+	BatchSize  int    `json:"batchSize,omitempty"`
 }
@@ -0,0 +1,161 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/usagex"
+)
+
+const (
+	// defaultEmbeddingBatchSize is the default number of texts in an embeddings request.
+	defaultEmbeddingBatchSize = 16
+
+	// embeddingTimeout is the timeout of an embeddings request.
+	embeddingTimeout = 2 * time.Minute
+)
+
+// Embedder calls the OpenAI compatible /embeddings endpoint
+type Embedder struct {
+	config *EmbeddingConfig
+	client *http.Client
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// NewEmbedder creates a new embedder
+func NewEmbedder(config *EmbeddingConfig) *Embedder {
+	return &Embedder{config: config, client: &http.Client{
+		Timeout:   embeddingTimeout,
+		Transport: &retryTransport{base: http.DefaultTransport, maxRetries: defaultMaxRetries},
+	}}
+}
+
+// embeddingRequest is the request body of the embeddings api
+type embeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+	EncodingFormat string   `json:"encoding_format"`
+}
+
+// embeddingResponse is the response body of the embeddings api
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+	Usage *struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage,omitempty"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// EmbedStrings converts the texts into vectors, in the same order as the texts
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	var (
+		model     = e.config.Model
+		options   = embedding.GetCommonOptions(&embedding.Options{Model: &model}, opts...)
+		batchSize = e.config.BatchSize
+		result    = make([][]float64, 0, len(texts))
+	)
+
+	if batchSize <= 0 {
+		batchSize = defaultEmbeddingBatchSize
+	}
+
+	for batch := range slices.Chunk(texts, batchSize) {
+		vectors, err := e.embed(ctx, *options.Model, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, vectors...)
+	}
+
+	return result, nil
+}
+
+// embed requests the vectors of a batch of texts
+func (e *Embedder) embed(ctx context.Context, model string, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(&embeddingRequest{
+		Model:          model,
+		Input:          texts,
+		Dimensions:     e.config.Dimensions,
+		EncodingFormat: "float",
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(e.config.ApiUrl, "/")+"/embeddings",
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if e.config.ApiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.ApiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result embeddingResponse
+
+	if err := json.Unmarshal(data, &result); err != nil || resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("embeddings request failed, status: %d, body: %s", resp.StatusCode, data)
+	}
+
+	if result.Error != nil {
+		return nil, errors.Errorf("embeddings request failed: %s", result.Error.Message)
+	}
+
+	if len(result.Data) != len(texts) {
+		return nil, errors.Errorf("embeddings request returned %d vectors for %d texts", len(result.Data), len(texts))
+	}
+
+	vectors := make([][]float64, len(texts))
+
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, errors.Errorf("embeddings request returned invalid index: %d", item.Index)
+		}
+
+		vectors[item.Index] = item.Embedding
+	}
+
+	if result.Usage != nil {
+		usagex.Publish(ctx, &usagex.Record{
+			Kind:         usagex.KindEmbedding,
+			Platform:     e.config.Platform,
+			Model:        model,
+			PromptTokens: result.Usage.PromptTokens,
+			TotalTokens:  result.Usage.TotalTokens,
+		})
+	}
+
+	return vectors, nil
+}
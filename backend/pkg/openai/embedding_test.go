@@ -0,0 +1,54 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestEmbedStrings testing the embeddings request
+func TestEmbedStrings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		var req embeddingRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		resp := map[string]any{}
+		data := make([]map[string]any, 0)
+
+		// reply in reverse order to check the index mapping
+		for idx := len(req.Input) - 1; idx >= 0; idx-- {
+			data = append(data, map[string]any{"index": idx, "embedding": []float64{float64(len(req.Input[idx]))}})
+		}
+
+		resp["data"] = data
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	embedder := NewEmbedder(&EmbeddingConfig{ApiKey: "key", ApiUrl: server.URL + "/v1/", Model: "m", BatchSize: 2})
+
+	vectors, err := embedder.EmbedStrings(context.Background(), []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, expected := range []float64{1, 2, 3} {
+		if len(vectors[idx]) != 1 || vectors[idx][0] != expected {
+			t.Errorf("unexpected vector %d: %v", idx, vectors[idx])
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseJSON parses the json of the model output, ignoring the text around the json object or array
+func ParseJSON(content string, v any) error {
+	content = strings.TrimSpace(content)
+
+	if err := json.Unmarshal([]byte(content), v); err == nil {
+		return nil
+	}
+
+	start := strings.IndexAny(content, "{[")
+	if start == -1 {
+		return errors.Errorf("no json found in the model output: %s", content)
+	}
+
+	end := strings.LastIndexByte(content, map[byte]byte{'{': '}', '[': ']'}[content[start]])
+	if end < start {
+		return errors.Errorf("incomplete json in the model output: %s", content)
+	}
+
+	return errors.WithStack(json.Unmarshal([]byte(content[start:end+1]), v))
+}
@@ -2,17 +2,175 @@ package openai
 
 import (
 	"context"
+	"io"
 
 	eino_openai "github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/pkg/usagex"
 )
 
+// streamBuffer is the number of chunks buffered between the model stream and the reader
+const streamBuffer = 16
+
+// ChatModel wraps the eino chat model with retries, rate limiting and fallback models,
+// publishing the token usage of every call
+type ChatModel struct {
+	endpoints []*chatEndpoint
+}
+
+// chatEndpoint is a model tried by the chat model
+type chatEndpoint struct {
+	model  *eino_openai.ChatModel
+	config *Config
+}
+
+var _ model.BaseChatModel = (*ChatModel)(nil)
+
 // NewChatModel creates a new chat model
-func NewChatModel(ctx context.Context, config *Config) *eino_openai.ChatModel {
-	model, _ := eino_openai.NewChatModel(ctx, &eino_openai.ChatModelConfig{
-		APIKey:  config.ApiKey,
-		BaseURL: config.ApiUrl,
-		Model:   config.Model,
+func NewChatModel(ctx context.Context, config *Config) (*ChatModel, error) {
+	return newChatModel(ctx, config, nil)
+}
+
+// NewJSONChatModel creates a new chat model that responds with a json object
+func NewJSONChatModel(ctx context.Context, config *Config) (*ChatModel, error) {
+	return newChatModel(ctx, config, &eino_openai.ChatCompletionResponseFormat{
+		Type: eino_openai.ChatCompletionResponseFormatTypeJSONObject,
 	})
+}
+
+func newChatModel(ctx context.Context, config *Config, format *eino_openai.ChatCompletionResponseFormat) (
+	*ChatModel, error) {
+	if config == nil {
+		return nil, errors.New("openai config is nil")
+	}
+
+	configs := []*Config{config}
+
+	for _, item := range config.Fallbacks {
+		if item != nil {
+			configs = append(configs, config.fallback(item))
+		}
+	}
+
+	chatModel := &ChatModel{endpoints: make([]*chatEndpoint, 0, len(configs))}
+
+	for _, item := range configs {
+		modelConfig := &eino_openai.ChatModelConfig{
+			APIKey:         item.ApiKey,
+			BaseURL:        item.ApiUrl,
+			Model:          item.Model,
+			HTTPClient:     newHTTPClient(item),
+			ResponseFormat: format,
+		}
+
+		if item.MaxTokens > 0 {
+			modelConfig.MaxTokens = &item.MaxTokens
+		}
+
+		einoModel, err := eino_openai.NewChatModel(ctx, modelConfig)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		chatModel.endpoints = append(chatModel.endpoints, &chatEndpoint{model: einoModel, config: item})
+	}
+
+	return chatModel, nil
+}
+
+// Generate generates the complete reply, falling back to the next model on failure
+func (m *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (
+	*schema.Message, error) {
+	var err error
+
+	for _, endpoint := range m.endpoints {
+		var message *schema.Message
 
-	return model
+		message, err = endpoint.model.Generate(ctx, input, endpoint.endpointOptions(opts)...)
+		if err == nil {
+			endpoint.publishUsage(ctx, message.ResponseMeta)
+
+			return message, nil
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, err
+}
+
+// Stream generates the reply as a stream, falling back to the next model if the stream fails to start.
+// The usage is published once the stream ends.
+func (m *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (
+	*schema.StreamReader[*schema.Message], error) {
+	var err error
+
+	for _, endpoint := range m.endpoints {
+		var stream *schema.StreamReader[*schema.Message]
+
+		stream, err = endpoint.model.Stream(ctx, input, endpoint.endpointOptions(opts)...)
+		if err == nil {
+			return endpoint.pipe(ctx, stream), nil
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, err
+}
+
+// pipe forwards the stream to a new reader, publishing the usage once the stream ends
+func (e *chatEndpoint) pipe(ctx context.Context,
+	stream *schema.StreamReader[*schema.Message]) *schema.StreamReader[*schema.Message] {
+	reader, writer := schema.Pipe[*schema.Message](streamBuffer)
+
+	go func() {
+		defer stream.Close()
+		defer writer.Close()
+
+		var meta *schema.ResponseMeta
+
+		defer func() { e.publishUsage(ctx, meta) }()
+
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err == nil && chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil {
+				meta = chunk.ResponseMeta
+			}
+
+			// stop when the reader is closed
+			if writer.Send(chunk, err) || err != nil {
+				return
+			}
+		}
+	}()
+
+	return reader
+}
+
+// publishUsage publishes the token usage of the response
+func (e *chatEndpoint) publishUsage(ctx context.Context, meta *schema.ResponseMeta) {
+	if meta == nil || meta.Usage == nil {
+		return
+	}
+
+	usagex.Publish(ctx, &usagex.Record{
+		Kind:             usagex.KindText,
+		Platform:         e.config.Platform,
+		Model:            e.config.Model,
+		PromptTokens:     meta.Usage.PromptTokens,
+		CompletionTokens: meta.Usage.CompletionTokens,
+		TotalTokens:      meta.Usage.TotalTokens,
+	})
 }
@@ -0,0 +1,196 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/mjiee/world-news/backend/pkg/usagex"
+)
+
+// TestChatModelUsage testing the usage published by the streamed chat completion
+func TestChatModelUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		for _, chunk := range []string{
+			`"choices":[{"index":0,"delta":{"role":"assistant","content":"hel"}}]`,
+			`"choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]`,
+			`"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}`,
+		} {
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"model\":\"m\",%s}\n\n", chunk)
+		}
+
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	records := make([]*usagex.Record, 0)
+
+	usagex.Listen(func(_ context.Context, record *usagex.Record) { records = append(records, record) })
+
+	ctx := usagex.WithLabel(context.Background(), &usagex.Label{Feature: "chat"})
+
+	model, err := NewChatModel(ctx, &Config{Platform: "p", ApiUrl: server.URL, Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := model.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ReadStream(stream, nil)
+	if err != nil || content != "hello" {
+		t.Fatalf("unexpected stream result: %q, %v", content, err)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("expected 1 usage record, got %d", len(records))
+	}
+
+	if record := records[0]; record.Feature != "chat" || record.Platform != "p" || record.PromptTokens != 3 ||
+		record.CompletionTokens != 2 || record.TotalTokens != 5 {
+		t.Fatalf("unexpected usage record: %+v", record)
+	}
+}
+
+// TestChatModelRetry testing the retries on 429 and the fallback model once the retries are exhausted
+func TestChatModelRetry(t *testing.T) {
+	retryBackoff = time.Millisecond
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model     string `json:"model"`
+			MaxTokens int    `json:"max_tokens"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		// the primary model is rate limited, the fallback model replies
+		if calls.Add(1); body.Model == "primary" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":"1","object":"chat.completion","model":%q,"choices":[{"index":0,`+
+			`"message":{"role":"assistant","content":"%d"},"finish_reason":"stop"}]}`, body.Model, body.MaxTokens)
+	}))
+	defer server.Close()
+
+	model, err := NewChatModel(context.Background(), &Config{ApiUrl: server.URL, Model: "primary", MaxTokens: 64,
+		MaxRetries: 2, Fallbacks: []*Config{{Model: "fallback"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := model.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 3 attempts of the primary model, 1 of the fallback model with the inherited max tokens
+	if message.Content != "64" || calls.Load() != 4 {
+		t.Fatalf("unexpected result: %q after %d calls", message.Content, calls.Load())
+	}
+}
+
+// slowTransport is a transport of its own, the first call hangs until it is timed out
+type slowTransport struct {
+	base  http.RoundTripper
+	calls atomic.Int32
+}
+
+func (t *slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.calls.Add(1) == 1 {
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// TestChatModelTimeout testing the attempt timed out and retried with the transport of the config
+func TestChatModelTimeout(t *testing.T) {
+	retryBackoff = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"m","choices":[{"index":0,`+
+			`"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	transport := &slowTransport{base: server.Client().Transport}
+
+	model, err := NewChatModel(context.Background(), &Config{ApiUrl: server.URL, Model: "m", Timeout: 1,
+		MaxRetries: 1, Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := model.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Content != "hi" || transport.calls.Load() != 2 {
+		t.Fatalf("unexpected result: %q after %d calls", message.Content, transport.calls.Load())
+	}
+}
+
+// TestChatModelResponseSchema testing the response schema sent only to the models supporting it
+func TestChatModelResponseSchema(t *testing.T) {
+	formats := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model          string `json:"model"`
+			ResponseFormat *struct {
+				Type string `json:"type"`
+			} `json:"response_format"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.ResponseFormat != nil {
+			formats[body.Model] = body.ResponseFormat.Type
+		}
+
+		fmt.Fprintf(w, `{"id":"1","object":"chat.completion","model":%q,"choices":[{"index":0,`+
+			`"message":{"role":"assistant","content":"{}"},"finish_reason":"stop"}]}`, body.Model)
+	}))
+	defer server.Close()
+
+	option := WithResponseSchema(&ResponseSchema{Name: "result", Schema: map[string]any{"type": "object"}})
+
+	for _, config := range []*Config{
+		{ApiUrl: server.URL, Model: "structured", JSONSchema: true},
+		{ApiUrl: server.URL, Model: "plain"},
+	} {
+		model, err := NewChatModel(context.Background(), config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messages := []*schema.Message{schema.UserMessage("hi")}
+
+		if _, err := model.Generate(context.Background(), messages, option); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if formats["structured"] != "json_schema" || formats["plain"] != "" {
+		t.Fatalf("unexpected response formats: %v", formats)
+	}
+}
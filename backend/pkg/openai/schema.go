@@ -0,0 +1,46 @@
+package openai
+
+import (
+	"slices"
+
+	eino_openai "github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/components/model"
+)
+
+// ResponseSchema is the json schema the reply conforms to
+type ResponseSchema struct {
+	Name   string
+	Schema map[string]any
+}
+
+// options are the options of the chat model
+type options struct {
+	schema *ResponseSchema
+}
+
+// WithResponseSchema requires the reply to conform to the json schema on the models supporting it,
+// the other models reply as prompted
+func WithResponseSchema(schema *ResponseSchema) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *options) {
+		o.schema = schema
+	})
+}
+
+// endpointOptions returns the options of the call on the endpoint
+func (e *chatEndpoint) endpointOptions(opts []model.Option) []model.Option {
+	o := model.GetImplSpecificOptions(&options{}, opts...)
+	if o.schema == nil || !e.config.JSONSchema {
+		return opts
+	}
+
+	return append(slices.Clone(opts), eino_openai.WithExtraFields(map[string]any{
+		"response_format": map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name":   o.schema.Name,
+				"schema": o.schema.Schema,
+				"strict": true,
+			},
+		},
+	}))
+}
@@ -0,0 +1,33 @@
+package openai
+
+import (
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkg/errors"
+)
+
+// ReadStream reads the message stream until the end, calling onDelta with each chunk, and returns the full text
+func ReadStream(stream *schema.StreamReader[*schema.Message], onDelta func(content string)) (string, error) {
+	defer stream.Close()
+
+	var content strings.Builder
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return content.String(), nil
+		}
+
+		if err != nil {
+			return content.String(), errors.WithStack(err)
+		}
+
+		content.WriteString(chunk.Content)
+
+		if onDelta != nil {
+			onDelta(chunk.Content)
+		}
+	}
+}
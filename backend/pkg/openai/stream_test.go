@@ -0,0 +1,26 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestReadStream(t *testing.T) {
+	var (
+		stream = schema.StreamReaderFromArray([]*schema.Message{
+			schema.AssistantMessage("Hello", nil),
+			schema.AssistantMessage(", world", nil),
+		})
+		deltas []string
+	)
+
+	content, err := ReadStream(stream, func(delta string) { deltas = append(deltas, delta) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if content != "Hello, world" || len(deltas) != 2 {
+		t.Fatalf("unexpected content %q, deltas %v", content, deltas)
+	}
+}
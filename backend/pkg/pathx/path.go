@@ -11,9 +11,10 @@ import (
 )
 
 const (
-	LogsDir  = "logs"
-	AudioDir = "audio"
-	TempDir  = "temp"
+	LogsDir    = "logs"
+	AudioDir   = "audio"
+	TempDir    = "temp"
+	PodcastDir = "podcasts"
 )
 
 // GetAppBasePath returns the base path for the application based on the operating system
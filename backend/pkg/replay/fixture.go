@@ -0,0 +1,109 @@
+package replay
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// base64Encoding marks the body stored in base64, for the body which is not valid utf-8
+const base64Encoding = "base64"
+
+// recordedHeaders are the response headers kept in the fixture, the other headers may carry credentials
+var recordedHeaders = []string{"Content-Type", "X-Tt-Logid", "Retry-After"}
+
+// Fixture the http interactions recorded in order
+type Fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction a recorded request and its response
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request the recorded request, without the query and the headers which may carry credentials
+type Request struct {
+	Method string `json:"method"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response the recorded response
+type Response struct {
+	Status       int               `json:"status"`
+	Header       map[string]string `json:"header,omitempty"`
+	Body         string            `json:"body"`
+	BodyEncoding string            `json:"bodyEncoding,omitempty"` // base64 for the binary body
+}
+
+// newResponse creates the recorded response
+func newResponse(status int, header http.Header, body []byte) *Response {
+	resp := &Response{Status: status, Header: make(map[string]string), Body: string(body)}
+
+	for _, key := range recordedHeaders {
+		if value := header.Get(key); value != "" {
+			resp.Header[key] = value
+		}
+	}
+
+	if !utf8.Valid(body) {
+		resp.Body = base64.StdEncoding.EncodeToString(body)
+		resp.BodyEncoding = base64Encoding
+	}
+
+	return resp
+}
+
+// body returns the raw body of the response
+func (r *Response) body() ([]byte, error) {
+	if r.BodyEncoding != base64Encoding {
+		return []byte(r.Body), nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(r.Body)
+
+	return data, errors.WithStack(err)
+}
+
+// match checks whether the recorded request is sent to the same endpoint
+func (r *Request) match(method, host, path string) bool {
+	return r.Method == method && r.Host == host && r.Path == path
+}
+
+// LoadFixture loads the fixture file
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var fixture Fixture
+
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &fixture, nil
+}
+
+// Save writes the fixture file
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(os.WriteFile(path, append(data, '\n'), 0644))
+}
@@ -0,0 +1,63 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Proxy a local http proxy sending the requests with the transport, for the clients which build their own transport
+// and only take a proxy, e.g. the aliyun sdk. The clients send plain http requests to the proxy, which are sent on
+// with https.
+type Proxy struct {
+	server    *httptest.Server
+	transport http.RoundTripper
+}
+
+// NewProxy starts the proxy sending the requests with the transport
+func NewProxy(transport http.RoundTripper) *Proxy {
+	p := &Proxy{transport: transport}
+	p.server = httptest.NewServer(http.HandlerFunc(p.serve))
+
+	return p
+}
+
+// serve sends the proxied request and writes its response
+func (p *Proxy) serve(w http.ResponseWriter, r *http.Request) {
+	req := r.Clone(r.Context())
+	req.RequestURI = ""
+	req.URL.Scheme = "https"
+	req.Header.Del("Proxy-Connection")
+	req.Header.Del("Proxy-Authorization")
+
+	if req.URL.Host == "" {
+		req.URL.Host = r.Host
+	}
+
+	resp, err := p.transport.RoundTrip(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// URL returns the url of the proxy
+func (p *Proxy) URL() string {
+	return p.server.URL
+}
+
+// Close shuts down the proxy
+func (p *Proxy) Close() {
+	p.server.Close()
+}
@@ -0,0 +1,71 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Recorder a http transport sending the requests with the base transport and recording the interactions
+type Recorder struct {
+	base    http.RoundTripper
+	mu      sync.Mutex
+	fixture *Fixture
+}
+
+// NewRecorder creates a recorder sending the requests with the base transport
+func NewRecorder(base http.RoundTripper) *Recorder {
+	return &Recorder{base: base, fixture: &Fixture{}}
+}
+
+// RoundTrip sends the request and records the interaction, the response body is read fully before it is returned
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		req.Body.Close()
+
+		reqBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fixture.Interactions = append(r.fixture.Interactions, &Interaction{
+		Request:  &Request{Method: req.Method, Host: req.URL.Host, Path: req.URL.Path, Body: string(reqBody)},
+		Response: newResponse(resp.StatusCode, resp.Header, respBody),
+	})
+
+	return resp, nil
+}
+
+// Fixture returns the recorded interactions
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Fixture{Interactions: append([]*Interaction(nil), r.fixture.Interactions...)}
+}
@@ -0,0 +1,74 @@
+// Package replay records the http interactions with the ai services, e.g. the text ai models, the tts and the
+// translators, into fixture files, and replays them from a local stub so that the callers are tested offline.
+//
+// The interactions are captured at http.DefaultTransport, which covers the clients sending their requests with the
+// default transport. The clients building their own transport are captured by passing them a transport of the
+// config (the google translator), or the proxy of the session (the aliyun translator).
+package replay
+
+import (
+	"net/http"
+	"os"
+	"testing"
+)
+
+// RecordEnv the environment variable which records the fixtures from the live services instead of replaying them
+const RecordEnv = "REPLAY_RECORD"
+
+// Session the recording or the replay of a test
+type Session struct {
+	proxy *Proxy
+}
+
+// ProxyURL returns the url of the http proxy capturing the requests of the clients which only take a proxy
+func (s *Session) ProxyURL() string {
+	return s.proxy.URL()
+}
+
+// Use serves the requests of the test sent with http.DefaultTransport from the fixture file.
+// With RecordEnv set, the requests are sent to the live services and the fixture file is rewritten.
+// The tests using it must not run in parallel, since http.DefaultTransport is replaced until the test ends.
+func Use(t testing.TB, fixture string) *Session {
+	t.Helper()
+
+	base := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = base })
+
+	if os.Getenv(RecordEnv) != "" {
+		recorder := NewRecorder(base)
+		http.DefaultTransport = recorder
+
+		session := &Session{proxy: NewProxy(recorder)}
+
+		t.Cleanup(func() {
+			session.proxy.Close()
+
+			if err := recorder.Fixture().Save(fixture); err != nil {
+				t.Error(err)
+			}
+		})
+
+		return session
+	}
+
+	data, err := LoadFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewServer(data)
+	http.DefaultTransport = server.Transport()
+
+	session := &Session{proxy: NewProxy(server.Transport())}
+
+	t.Cleanup(func() {
+		session.proxy.Close()
+		server.Close()
+
+		for _, miss := range server.Misses() {
+			t.Errorf("replay: %s", miss)
+		}
+	})
+
+	return session
+}
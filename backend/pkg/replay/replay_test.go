@@ -0,0 +1,116 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Authorization", "secret")
+		_, _ = w.Write([]byte("echo " + string(body)))
+	}))
+	defer live.Close()
+
+	// record
+	recorder := NewRecorder(http.DefaultTransport)
+	client := &http.Client{Transport: recorder}
+
+	for _, text := range []string{"a", "b"} {
+		resp, err := client.Post(live.URL+"/echo?key=secret", "text/plain", strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp.Body.Close()
+	}
+
+	fixture := filepath.Join(t.TempDir(), "echo.json")
+
+	if err := recorder.Fixture().Save(fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	live.Close()
+
+	// replay
+	data, err := LoadFixture(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header := data.Interactions[0].Response.Header; header["Authorization"] != "" {
+		t.Fatalf("unexpected recorded header %v", header)
+	}
+
+	server := NewServer(data)
+	defer server.Close()
+
+	client = &http.Client{Transport: server.Transport()}
+
+	for _, want := range []string{"echo a", "echo b"} {
+		resp, err := client.Post(live.URL+"/echo", "text/plain", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if string(body) != want || resp.Header.Get("Content-Type") != "text/plain" {
+			t.Fatalf("unexpected response %q, want %q", body, want)
+		}
+	}
+
+	resp, err := client.Get(live.URL + "/echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotImplemented || len(server.Misses()) != 1 {
+		t.Fatalf("unexpected status %d, misses %v", resp.StatusCode, server.Misses())
+	}
+}
+
+func TestProxy(t *testing.T) {
+	live := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("proxied " + r.URL.Path))
+	}))
+	defer live.Close()
+
+	// the client sends plain http to the proxy, which is sent on with https
+	recorder := NewRecorder(live.Client().Transport)
+
+	proxy := NewProxy(recorder)
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	resp, err := client.Get(strings.Replace(live.URL, "https://", "http://", 1) + "/text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	interactions := recorder.Fixture().Interactions
+	if string(body) != "proxied /text" || len(interactions) != 1 || interactions[0].Request.Path != "/text" {
+		t.Fatalf("unexpected response %q, interactions %d", body, len(interactions))
+	}
+}
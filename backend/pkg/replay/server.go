@@ -0,0 +1,112 @@
+package replay
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+// hostHeader carries the host of the original request to the stub
+const hostHeader = "X-Replay-Host"
+
+// Server a local http stub serving the recorded responses.
+// A request is served by the first unused interaction recorded for the same method, host and path.
+type Server struct {
+	server  *httptest.Server
+	mu      sync.Mutex
+	fixture *Fixture
+	used    []bool
+	misses  []string
+}
+
+// NewServer starts the stub serving the fixture
+func NewServer(fixture *Fixture) *Server {
+	s := &Server{fixture: fixture, used: make([]bool, len(fixture.Interactions))}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// serve writes the recorded response of the request
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	host := r.Header.Get(hostHeader)
+
+	resp := s.take(r.Method, host, r.URL.Path)
+	if resp == nil {
+		http.Error(w, fmt.Sprintf("replay: no recorded response for %s %s%s", r.Method, host, r.URL.Path),
+			http.StatusNotImplemented)
+
+		return
+	}
+
+	body, err := resp.body()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	for key, value := range resp.Header {
+		w.Header().Set(key, value)
+	}
+
+	w.WriteHeader(resp.Status)
+	_, _ = w.Write(body)
+}
+
+// take marks the first unused interaction of the endpoint as used and returns its response, nil if none
+func (s *Server) take(method, host, path string) *Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for idx, interaction := range s.fixture.Interactions {
+		if !s.used[idx] && interaction.Request.match(method, host, path) {
+			s.used[idx] = true
+
+			return interaction.Response
+		}
+	}
+
+	s.misses = append(s.misses, fmt.Sprintf("no recorded response for %s %s%s", method, host, path))
+
+	return nil
+}
+
+// Transport returns the http transport sending every request to the stub
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.server.URL)
+
+	return &stubTransport{base: s.server.Client().Transport, target: target}
+}
+
+// Misses returns the requests without a recorded response
+func (s *Server) Misses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.misses...)
+}
+
+// Close shuts down the stub
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// stubTransport redirects the requests to the stub, keeping the original host in a header
+type stubTransport struct {
+	base   http.RoundTripper
+	target *url.URL
+}
+
+// RoundTrip sends the request to the stub
+func (t *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	stubReq := req.Clone(req.Context())
+	stubReq.Header.Set(hostHeader, req.URL.Host)
+	stubReq.URL.Scheme = t.target.Scheme
+	stubReq.URL.Host = t.target.Host
+	stubReq.Host = t.target.Host
+
+	return t.base.RoundTrip(stubReq)
+}
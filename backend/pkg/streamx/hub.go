@@ -0,0 +1,66 @@
+package streamx
+
+import "sync"
+
+// subscriberBuffer is the number of events buffered for a slow subscriber, the newer events are dropped when full.
+const subscriberBuffer = 1024
+
+// hub dispatches the published events to the listeners and the subscribers of the stream.
+var hub = &eventHub{subscribers: make(map[string]map[chan *Event]struct{})}
+
+// eventHub holds the listeners of all events and the subscribers by stream id.
+type eventHub struct {
+	mu          sync.RWMutex
+	listeners   []Sink
+	subscribers map[string]map[chan *Event]struct{}
+}
+
+// Listen registers a sink receiving every published event.
+func Listen(sink Sink) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.listeners = append(hub.listeners, sink)
+}
+
+// Subscribe receives the published events of the stream until the returned function is called.
+func Subscribe(id string) (<-chan *Event, func()) {
+	ch := make(chan *Event, subscriberBuffer)
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.subscribers[id] == nil {
+		hub.subscribers[id] = make(map[chan *Event]struct{})
+	}
+
+	hub.subscribers[id][ch] = struct{}{}
+
+	return ch, func() {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+
+		delete(hub.subscribers[id], ch)
+
+		if len(hub.subscribers[id]) == 0 {
+			delete(hub.subscribers, id)
+		}
+	}
+}
+
+// Publish sends the event to the listeners and the subscribers of its stream, it is a Sink.
+func Publish(event *Event) {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+
+	for _, listener := range hub.listeners {
+		listener(event)
+	}
+
+	for ch := range hub.subscribers[event.StreamId] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package streamx
+
+import (
+	"context"
+	"sync"
+)
+
+// EventName is the name of the desktop runtime event carrying the stream events.
+const EventName = "aiStream"
+
+// EventType is the type of the stream event.
+type EventType string
+
+const (
+	EventDelta EventType = "delta" // a chunk of the generated text
+	EventDone  EventType = "done"  // the generation is completed
+	EventError EventType = "error" // the generation failed or was cancelled
+)
+
+// Event is an event of an ai response stream.
+type Event struct {
+	StreamId string    `json:"streamId"`
+	Stage    string    `json:"stage,omitempty"` // the task stage generating the text
+	Type     EventType `json:"type"`
+	Content  string    `json:"content,omitempty"`
+}
+
+// Sink receives the events of a stream.
+type Sink func(event *Event)
+
+// Stream emits the events of an ai response, the methods of a nil stream do nothing.
+type Stream struct {
+	id    string
+	stage string
+	sink  Sink
+}
+
+// NewStream creates a new stream.
+func NewStream(id string, sink Sink) *Stream {
+	if id == "" || sink == nil {
+		return nil
+	}
+
+	return &Stream{id: id, sink: sink}
+}
+
+// WithStage returns a stream emitting the events of the stage.
+func (s *Stream) WithStage(stage string) *Stream {
+	if s == nil {
+		return nil
+	}
+
+	return &Stream{id: s.id, stage: stage, sink: s.sink}
+}
+
+// Start returns the context of the generation, cancelled by Cancel with the stream id.
+// The returned function must be called once the generation ends.
+func (s *Stream) Start(ctx context.Context) (context.Context, func()) {
+	if s == nil {
+		return context.WithCancel(ctx)
+	}
+
+	return WithCancel(ctx, s.id)
+}
+
+// Delta emits a chunk of the generated text.
+func (s *Stream) Delta(content string) {
+	if s != nil && content != "" {
+		s.sink(&Event{StreamId: s.id, Stage: s.stage, Type: EventDelta, Content: content})
+	}
+}
+
+// Done emits the end of the generation.
+func (s *Stream) Done() {
+	if s != nil {
+		s.sink(&Event{StreamId: s.id, Stage: s.stage, Type: EventDone})
+	}
+}
+
+// Fail emits the failure of the generation.
+func (s *Stream) Fail(err error) {
+	if s != nil {
+		s.sink(&Event{StreamId: s.id, Stage: s.stage, Type: EventError, Content: err.Error()})
+	}
+}
+
+// WithCancel returns a context cancelled by Cancel with the stream id, e.g. the run of a task generating the streams.
+// The returned function must be called once the run ends.
+func WithCancel(ctx context.Context, id string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	key := registry.add(id, cancel)
+
+	return ctx, func() {
+		registry.remove(id, key)
+		cancel()
+	}
+}
+
+// Cancel aborts the in-flight generations of the stream, returning false if there is none.
+func Cancel(id string) bool {
+	return registry.cancel(id)
+}
+
+// registry holds the cancel functions of the in-flight generations.
+var registry = &cancelRegistry{streams: make(map[string]map[uint64]context.CancelFunc)}
+
+// cancelRegistry holds the cancel functions by stream id.
+type cancelRegistry struct {
+	mu      sync.Mutex
+	next    uint64
+	streams map[string]map[uint64]context.CancelFunc
+}
+
+// add registers the cancel function, returning its key.
+func (r *cancelRegistry) add(id string, cancel context.CancelFunc) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.next++
+
+	if r.streams[id] == nil {
+		r.streams[id] = make(map[uint64]context.CancelFunc)
+	}
+
+	r.streams[id][r.next] = cancel
+
+	return r.next
+}
+
+// remove unregisters the cancel function.
+func (r *cancelRegistry) remove(id string, key uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.streams[id], key)
+
+	if len(r.streams[id]) == 0 {
+		delete(r.streams, id)
+	}
+}
+
+// cancel calls the cancel functions of the stream.
+func (r *cancelRegistry) cancel(id string) bool {
+	r.mu.Lock()
+	cancels := r.streams[id]
+	delete(r.streams, id)
+	r.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	return len(cancels) > 0
+}
@@ -0,0 +1,84 @@
+package streamx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	var events []*Event
+
+	stream := NewStream("s1", func(event *Event) { events = append(events, event) }).WithStage("rewrite")
+
+	ctx, release := stream.Start(context.Background())
+
+	stream.Delta("hello")
+	stream.Delta("")
+
+	if !Cancel("s1") {
+		t.Fatal("expected the stream to be cancelled")
+	}
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected cancelled context, got %v", ctx.Err())
+	}
+
+	release()
+	stream.Fail(ctx.Err())
+
+	if Cancel("s1") {
+		t.Fatal("expected no in-flight generation")
+	}
+
+	if len(events) != 2 || events[0].Content != "hello" || events[0].Stage != "rewrite" ||
+		events[1].Type != EventError {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// a nil stream does nothing
+	var empty *Stream
+
+	empty.Delta("ignored")
+	empty.Done()
+
+	if _, release := empty.Start(context.Background()); release == nil {
+		t.Fatal("expected a release function")
+	}
+}
+
+func TestWithCancel(t *testing.T) {
+	// a task run and a generation of the run share the id
+	run, release := WithCancel(context.Background(), "task")
+	defer release()
+
+	generation, done := NewStream("task", Publish).Start(run)
+	defer done()
+
+	if !Cancel("task") {
+		t.Fatal("expected the run to be cancelled")
+	}
+
+	if run.Err() == nil || generation.Err() == nil {
+		t.Fatalf("expected cancelled contexts, got %v and %v", run.Err(), generation.Err())
+	}
+}
+
+func TestHub(t *testing.T) {
+	events, unsubscribe := Subscribe("s2")
+
+	Publish(&Event{StreamId: "s2", Type: EventDelta, Content: "a"})
+	Publish(&Event{StreamId: "other", Type: EventDelta, Content: "b"})
+
+	unsubscribe()
+
+	Publish(&Event{StreamId: "s2", Type: EventDone})
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	if event := <-events; event.Content != "a" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
@@ -0,0 +1,83 @@
+package textx
+
+// Similarity returns the jaccard similarity of the two term sets.
+func Similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]struct{}, len(a))
+
+	for _, term := range a {
+		set[term] = struct{}{}
+	}
+
+	var (
+		shared int
+		seen   = make(map[string]struct{}, len(b))
+	)
+
+	for _, term := range b {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+
+		seen[term] = struct{}{}
+
+		if _, ok := set[term]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(set)+len(seen)-shared)
+}
+
+// Cluster groups the documents whose term similarity reaches the threshold.
+// Documents are linked transitively, and the clusters hold the document indexes in the input order.
+func Cluster(docs [][]string, threshold float64) [][]int {
+	parent := make([]int, len(docs))
+
+	for idx := range parent {
+		parent[idx] = idx
+	}
+
+	var find func(int) int
+
+	find = func(idx int) int {
+		if parent[idx] != idx {
+			parent[idx] = find(parent[idx])
+		}
+
+		return parent[idx]
+	}
+
+	for i := range docs {
+		for j := i + 1; j < len(docs); j++ {
+			if find(i) == find(j) || Similarity(docs[i], docs[j]) < threshold {
+				continue
+			}
+
+			parent[find(j)] = find(i)
+		}
+	}
+
+	var (
+		clusters = make([][]int, 0)
+		index    = make(map[int]int)
+	)
+
+	for idx := range docs {
+		root := find(idx)
+
+		pos, ok := index[root]
+		if !ok {
+			pos = len(clusters)
+			index[root] = pos
+			clusters = append(clusters, nil)
+		}
+
+		clusters[pos] = append(clusters[pos], idx)
+	}
+
+	return clusters
+}
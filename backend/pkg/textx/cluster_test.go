@@ -0,0 +1,32 @@
+package textx
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCluster testing document clustering
+func TestCluster(t *testing.T) {
+	var docs [][]string
+
+	for _, title := range []string{
+		"Central bank raises interest rates again",
+		"Storm hits coastal towns overnight",
+		"Interest rates raised again by central bank",
+		"Coastal towns clean up after storm",
+		"Football final ends in penalties",
+	} {
+		terms, _ := Keywords(title)
+		docs = append(docs, terms)
+	}
+
+	clusters := Cluster(docs, 0.3)
+
+	if expected := [][]int{{0, 2}, {1, 3}, {4}}; !reflect.DeepEqual(clusters, expected) {
+		t.Errorf("unexpected clusters: %v", clusters)
+	}
+
+	if Similarity(nil, docs[0]) != 0 {
+		t.Error("empty document must not be similar")
+	}
+}
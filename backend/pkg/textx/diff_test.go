@@ -0,0 +1,41 @@
+package textx
+
+import (
+	"testing"
+)
+
+// TestDiff testing line diff
+func TestDiff(t *testing.T) {
+	var (
+		a = []string{"title", "first", "second", "third"}
+		b = []string{"title", "second", "changed", "third", "fourth"}
+	)
+
+	expected := []DiffLine{
+		{DiffEqual, "title"},
+		{DiffDelete, "first"},
+		{DiffEqual, "second"},
+		{DiffInsert, "changed"},
+		{DiffEqual, "third"},
+		{DiffInsert, "fourth"},
+	}
+
+	result := Diff(a, b)
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(result))
+	}
+
+	for idx, line := range result {
+		if *line != expected[idx] {
+			t.Errorf("line %d: expected %v, got %v", idx, expected[idx], *line)
+		}
+	}
+
+	if !HasDiff(result) {
+		t.Error("expected changes")
+	}
+
+	if HasDiff(Diff(a, a)) {
+		t.Error("expected no changes")
+	}
+}
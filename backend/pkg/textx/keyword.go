@@ -0,0 +1,123 @@
+package textx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minTermLength is the minimum length of a latin term.
+const minTermLength = 3
+
+// stopWords are the common english words ignored by keyword extraction.
+var stopWords = map[string]struct{}{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		a about above after again against all also am an and any are as at be because been before being below
+		between both but by can could did do does doing down during each few for from further had has have having
+		he her here hers him his how i if in into is it its itself just may me might more most must my new news
+		no nor not now of off on once only or other our ours out over own said same says she should so some such
+		than that the their theirs them then there these they this those through to too under until up upon us
+		very via was we were what when where which while who whom why will with would you your yours year years
+		day days week weeks today yesterday tomorrow first last one two three get gets got make makes made take
+		takes see sees amid across against still back like live update updates video watch report reports`) {
+		stopWords[word] = struct{}{}
+	}
+}
+
+// Keywords extracts the terms and the two-word phrases from the text.
+// Latin words are lowercased and filtered by stop words, han characters are split into bigrams.
+func Keywords(text string) (terms []string, phrases []string) {
+	var (
+		word   []rune
+		han    []rune
+		prev   string
+		finish = func() {
+			if len(han) > 0 {
+				terms = append(terms, hanBigrams(han)...)
+				han = han[:0]
+				prev = ""
+			}
+
+			if len(word) == 0 {
+				return
+			}
+
+			term, ok := normalizeTerm(string(word))
+			word = word[:0]
+
+			if !ok {
+				prev = ""
+
+				return
+			}
+
+			terms = append(terms, term)
+
+			if prev != "" {
+				phrases = append(phrases, prev+" "+term)
+			}
+
+			prev = term
+		}
+	)
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if len(word) > 0 {
+				finish()
+			}
+
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || (r == '\'' && len(word) > 0):
+			if len(han) > 0 {
+				finish()
+			}
+
+			word = append(word, r)
+		default:
+			finish()
+
+			// punctuation breaks the phrase
+			if !unicode.IsSpace(r) {
+				prev = ""
+			}
+		}
+	}
+
+	finish()
+
+	return terms, phrases
+}
+
+// normalizeTerm lowercases the word and checks if it is a meaningful term.
+func normalizeTerm(word string) (string, bool) {
+	word = strings.ToLower(word)
+	word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "'")
+
+	if len(word) < minTermLength {
+		return "", false
+	}
+
+	if _, ok := stopWords[word]; ok {
+		return "", false
+	}
+
+	return word, strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
+
+// hanBigrams splits the han characters into overlapping bigrams.
+func hanBigrams(han []rune) []string {
+	if len(han) < 2 {
+		return nil
+	}
+
+	result := make([]string, 0, len(han)-1)
+
+	for idx := range han[:len(han)-1] {
+		result = append(result, string(han[idx:idx+2]))
+	}
+
+	return result
+}
@@ -0,0 +1,34 @@
+package textx
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestKeywords testing keyword extraction
+func TestKeywords(t *testing.T) {
+	terms, phrases := Keywords("Central bank's board raises interest rates, markets fall in Asia")
+
+	for _, term := range []string{"central", "bank", "board", "raises", "interest", "rates", "markets", "fall", "asia"} {
+		if !slices.Contains(terms, term) {
+			t.Errorf("terms missing %q", term)
+		}
+	}
+
+	if slices.Contains(terms, "in") {
+		t.Error("stop word is not removed")
+	}
+
+	if !slices.Contains(phrases, "interest rates") {
+		t.Error("phrases missing \"interest rates\"")
+	}
+
+	if slices.Contains(phrases, "rates markets") {
+		t.Error("punctuation must break the phrase")
+	}
+
+	terms, _ = Keywords("全球股市")
+	if !slices.Equal(terms, []string{"全球", "球股", "股市"}) {
+		t.Errorf("unexpected han terms: %v", terms)
+	}
+}
@@ -74,3 +74,13 @@ func SimilarText(a, b string) bool {
 
 	return (float64(matchLen) / float64(maxLen)) >= 0.9
 }
+
+// Truncate truncates the text to the maximum number of characters
+func Truncate(text string, maxLen int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+
+	return string(runes[:maxLen])
+}
@@ -18,13 +18,19 @@ type aliyunTranslator struct {
 }
 
 // newAliyunTranslater creates a new aliyun translator
-func newAliyunTranslater(accessKey, accessSecret string) (*aliyunTranslator, error) {
+func newAliyunTranslater(accessKey, accessSecret, proxy string) (*aliyunTranslator, error) {
 	config := &openapi.Config{
 		AccessKeyId:     tea.String(accessKey),
 		AccessKeySecret: tea.String(accessSecret),
 		Endpoint:        tea.String("mt.aliyuncs.com"),
 	}
 
+	// the proxy sends the requests on with https
+	if proxy != "" {
+		config.Protocol = tea.String("HTTP")
+		config.HttpProxy = tea.String(proxy)
+	}
+
 	client, err := alimt20181012.NewClient(config)
 	if err != nil {
 		return nil, err
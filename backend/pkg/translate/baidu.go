@@ -41,11 +41,11 @@ type baiduTranslater struct {
 }
 
 // newBaiduTranslater creates a new baidu translater
-func newBaiduTranslater(appID, secretKey string) (*baiduTranslater, error) {
+func newBaiduTranslater(appID, secretKey string, transport http.RoundTripper) (*baiduTranslater, error) {
 	return &baiduTranslater{
 		appID:     appID,
 		secretKey: secretKey,
-		client:    http.DefaultClient,
+		client:    &http.Client{Transport: transport},
 	}, nil
 }
 
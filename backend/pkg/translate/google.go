@@ -4,31 +4,44 @@ package translate
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	goTranslate "cloud.google.com/go/translate/apiv3"
 	"cloud.google.com/go/translate/apiv3/translatepb"
 	"github.com/pkg/errors"
+	"google.golang.org/api/option"
 )
 
 // googleTranslater is the translator for google
 type googleTranslater struct {
 	projectID string
+	transport http.RoundTripper
 }
 
 // newGoogleTranslater creates a new google translater
-func newGoogleTranslater(projectID string) (*googleTranslater, error) {
+func newGoogleTranslater(projectID string, transport http.RoundTripper) (*googleTranslater, error) {
 	return &googleTranslater{
 		projectID: projectID,
+		transport: transport,
 	}, nil
 }
 
+// newClient creates the translation client, the rest client sending with the transport if it is set
+func (g *googleTranslater) newClient(ctx context.Context) (*goTranslate.TranslationClient, error) {
+	if g.transport == nil {
+		return goTranslate.NewTranslationClient(ctx)
+	}
+
+	return goTranslate.NewTranslationRESTClient(ctx, option.WithHTTPClient(&http.Client{Transport: g.transport}))
+}
+
 // Translate translates the given texts to the target language
 func (g *googleTranslater) Translate(ctx context.Context, toLang string, data ...string) ([]string, error) {
 	if len(data) == 0 {
 		return data, nil
 	}
 
-	client, err := goTranslate.NewTranslationClient(ctx)
+	client, err := g.newClient(ctx)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -48,8 +61,8 @@ func (g *googleTranslater) Translate(ctx context.Context, toLang string, data ..
 
 	results := make([]string, 0, len(resp.GetTranslations()))
 
-	for idx, translation := range resp.GetTranslations() {
-		results[idx] = translation.GetTranslatedText()
+	for _, translation := range resp.GetTranslations() {
+		results = append(results, translation.GetTranslatedText())
 	}
 
 	return results, nil
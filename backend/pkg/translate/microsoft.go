@@ -30,10 +30,10 @@ type microsoftTranslater struct {
 }
 
 // newMicrosoftTranslater creates a new microsoft translater
-func newMicrosoftTranslater(apiKey string) (*microsoftTranslater, error) {
+func newMicrosoftTranslater(apiKey string, transport http.RoundTripper) (*microsoftTranslater, error) {
 	return &microsoftTranslater{
 		apiKey: apiKey,
-		client: &http.Client{},
+		client: &http.Client{Transport: transport},
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package translate
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/mjiee/world-news/backend/pkg/errorx"
 )
@@ -27,19 +28,25 @@ type Config struct {
 	Platform  platform `json:"platform"`
 	AppId     string   `json:"appId"`
 	AppSecret string   `json:"appSecret"`
+
+	// Transport sends the requests, http.DefaultTransport if nil. The google translator sends its requests with
+	// the rest api once it is set, the transport adds the credentials.
+	Transport http.RoundTripper `json:"-"`
+	// Proxy is the http proxy of the aliyun sdk, which builds its own transport.
+	Proxy string `json:"-"`
 }
 
 // NewTranslater creates a new translator
 func NewTranslater(cfg *Config) (Translater, error) {
 	switch cfg.Platform {
 	case baiduPlatform:
-		return newBaiduTranslater(cfg.AppId, cfg.AppSecret)
+		return newBaiduTranslater(cfg.AppId, cfg.AppSecret, cfg.Transport)
 	case googlePlatform:
-		return newGoogleTranslater(cfg.AppId)
+		return newGoogleTranslater(cfg.AppId, cfg.Transport)
 	case microsoftPlatform:
-		return newMicrosoftTranslater(cfg.AppId)
+		return newMicrosoftTranslater(cfg.AppId, cfg.Transport)
 	case aliyunPlatform:
-		return newAliyunTranslater(cfg.AppId, cfg.AppSecret)
+		return newAliyunTranslater(cfg.AppId, cfg.AppSecret, cfg.Proxy)
 	default:
 		return nil, errorx.InternalError.SetMessage(fmt.Sprintf("unsupported translation platform: %s", cfg.Platform))
 	}
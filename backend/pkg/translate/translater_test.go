@@ -2,61 +2,100 @@ package translate
 
 import (
 	"context"
-	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/mjiee/world-news/backend/pkg/replay"
 )
 
-func testTranslate(cfg *Config) {
-	translater, err := NewTranslater(cfg)
-	if err != nil {
-		fmt.Println(err)
+// testGoogleProject the google cloud project of the recorded fixture
+const testGoogleProject = "world-news-test"
+
+// bearerTransport adds the access token to the requests of the google rest api
+type bearerTransport struct {
+	token string
+}
+
+func (b *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
 
-		return
+// envOr returns the environment variable, or the fallback for the sdk refusing the empty credentials in the replay
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// testTranslate replays the fixture of the test from testdata and translates the texts with the config
+func testTranslate(t *testing.T, cfg func(session *replay.Session) *Config) {
+	t.Helper()
+
+	session := replay.Use(t, filepath.Join("testdata", t.Name()+".json"))
+
+	translater, err := NewTranslater(cfg(session))
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	results, err := translater.Translate(context.Background(), "zh", "apple", "banana")
 	if err != nil {
-		fmt.Println(err)
-		return
+		t.Fatal(err)
 	}
 
-	fmt.Println(results)
+	if want := []string{"苹果", "香蕉"}; !reflect.DeepEqual(results, want) {
+		t.Errorf("got %v, want %v", results, want)
+	}
 }
 
+// TestAliyunTranslater the recording needs the access key in ALIYUN_ACCESS_KEY_ID and ALIYUN_ACCESS_KEY_SECRET
 func TestAliyunTranslater(t *testing.T) {
-	cfg := &Config{
-		Platform:  aliyunPlatform,
-		AppId:     "Access_Key_ID",
-		AppSecret: "Access_Key_Secret",
-	}
-
-	testTranslate(cfg)
+	testTranslate(t, func(session *replay.Session) *Config {
+		return &Config{
+			Platform:  aliyunPlatform,
+			AppId:     envOr("ALIYUN_ACCESS_KEY_ID", "replay"),
+			AppSecret: envOr("ALIYUN_ACCESS_KEY_SECRET", "replay"),
+			Proxy:     session.ProxyURL(),
+		}
+	})
 }
 
+// TestBaiduTranslater the recording needs the app in BAIDU_APP_ID and BAIDU_APP_SECRET
 func TestBaiduTranslater(t *testing.T) {
-	cfg := &Config{
-		Platform:  baiduPlatform,
-		AppId:     "app_id",
-		AppSecret: "app_secret",
-	}
-
-	testTranslate(cfg)
+	testTranslate(t, func(*replay.Session) *Config {
+		return &Config{
+			Platform:  baiduPlatform,
+			AppId:     os.Getenv("BAIDU_APP_ID"),
+			AppSecret: os.Getenv("BAIDU_APP_SECRET"),
+		}
+	})
 }
 
-func TestMicrosoft(t *testing.T) {
-	cfg := &Config{
-		Platform: microsoftPlatform,
-		AppId:    "subscription_key",
-	}
-
-	testTranslate(cfg)
+// TestMicrosoftTranslater the recording needs the subscription key in MICROSOFT_TRANSLATOR_KEY
+func TestMicrosoftTranslater(t *testing.T) {
+	testTranslate(t, func(*replay.Session) *Config {
+		return &Config{
+			Platform: microsoftPlatform,
+			AppId:    os.Getenv("MICROSOFT_TRANSLATOR_KEY"),
+		}
+	})
 }
 
+// TestGoogleTranslater the recording needs an access token of the test project in GOOGLE_ACCESS_TOKEN
 func TestGoogleTranslater(t *testing.T) {
-	cfg := &Config{
-		Platform: googlePlatform,
-		AppId:    "project_id",
-	}
-
-	testTranslate(cfg)
+	testTranslate(t, func(*replay.Session) *Config {
+		return &Config{
+			Platform:  googlePlatform,
+			AppId:     testGoogleProject,
+			Transport: &bearerTransport{token: os.Getenv("GOOGLE_ACCESS_TOKEN")},
+		}
+	})
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/mjiee/world-news/backend/pkg/audio"
 	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/usagex"
 )
 
 const (
@@ -33,10 +35,11 @@ var (
 
 // Doubao TTS client
 type DoubaoTTSClient struct {
-	apiKey string
-	model  string
-	voices []*Voice
-	client *http.Client
+	platform string
+	apiKey   string
+	model    string
+	voices   []*Voice
+	client   *http.Client
 }
 
 func NewDoubaoTTSClient(config *Config) (*DoubaoTTSClient, error) {
@@ -45,10 +48,11 @@ func NewDoubaoTTSClient(config *Config) (*DoubaoTTSClient, error) {
 	}
 
 	return &DoubaoTTSClient{
-		apiKey: config.ApiKey,
-		model:  config.Model,
-		voices: config.Voices,
-		client: http.DefaultClient,
+		platform: config.Platform,
+		apiKey:   config.ApiKey,
+		model:    config.Model,
+		voices:   config.Voices,
+		client:   http.DefaultClient,
 	}, nil
 }
 
@@ -132,6 +136,13 @@ func (c *DoubaoTTSClient) TextToSpeech(ctx context.Context, script *TtsScript) (
 		return nil, err
 	}
 
+	usagex.Publish(ctx, &usagex.Record{
+		Kind:       usagex.KindTts,
+		Platform:   c.platform,
+		Model:      header["X-Api-Resource-Id"],
+		Characters: utf8.RuneCountInString(script.Text),
+	})
+
 	return &TtsTask{
 		AudioId:   data.Header.Get("X-Tt-Logid"),
 		AudioData: audioData,
@@ -2,14 +2,21 @@ package ttsai
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/mjiee/world-news/backend/pkg/audio"
+	"github.com/mjiee/world-news/backend/pkg/replay"
 )
 
+// TestDoubaoTextToSpeech replays the fixture of the test from testdata. The recording needs the api key in
+// DOUBAO_API_KEY.
 func TestDoubaoTextToSpeech(t *testing.T) {
+	replay.Use(t, filepath.Join("testdata", t.Name()+".json"))
+
 	client, err := NewDoubaoTTSClient(&Config{
-		ApiKey: "xxx",
+		ApiKey: os.Getenv("DOUBAO_API_KEY"),
 		Model:  "seed-icl-2.0",
 		Voices: []*Voice{
 			{
@@ -23,45 +30,45 @@ func TestDoubaoTextToSpeech(t *testing.T) {
 		},
 	})
 	if err != nil {
-		t.Error(err)
-		return
+		t.Fatal(err)
 	}
 
-	ctx := context.Background()
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+	)
 
-	dataA, err := client.TextToSpeech(ctx, &TtsScript{
-		Text:    "It helps you understand large codebases, automate tedious work, and ship faster.",
-		Speaker: "zh_male_xudong_conversation_wvae_bigtts",
-		Emotion: "radio",
-		Speed:   1.1,
-		Volume:  100,
-		Silence: 0.2,
-	})
-	if err != nil {
-		t.Error(err)
-		return
+	scripts := []*TtsScript{
+		{
+			Text:    "It helps you understand large codebases, automate tedious work, and ship faster.",
+			Speaker: "zh_male_xudong_conversation_wvae_bigtts",
+			Emotion: "radio",
+			Speed:   1.1,
+			Volume:  100,
+			Silence: 0.2,
+		},
+		{
+			Text:    "It helps you understand large codebases, automate tedious work, and ship faster.",
+			Speaker: "zh_female_wanwanxiaohe_moon_bigtts",
+			Emotion: "neutral",
+			Speed:   1.1,
+			Volume:  100,
+			Silence: 0.2,
+		},
 	}
 
-	if err = audio.Transcode(dataA.AudioData, "./axu_reference.wav"); err != nil {
-		t.Error(err)
-		return
-	}
+	for _, script := range scripts {
+		data, err := client.TextToSpeech(ctx, script)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	dataB, err := client.TextToSpeech(ctx, &TtsScript{
-		Text:    "It helps you understand large codebases, automate tedious work, and ship faster.",
-		Speaker: "zh_female_wanwanxiaohe_moon_bigtts",
-		Emotion: "neutral",
-		Speed:   1.1,
-		Volume:  100,
-		Silence: 0.2,
-	})
-	if err != nil {
-		t.Error(err)
-		return
-	}
+		if len(data.AudioData) == 0 {
+			t.Fatalf("no audio of the speaker %s", script.Speaker)
+		}
 
-	if err = audio.Transcode(dataB.AudioData, "./yuyuan_reference.wav"); err != nil {
-		t.Error(err)
-		return
+		if err = audio.Transcode(data.AudioData, filepath.Join(dir, script.Speaker+".wav")); err != nil {
+			t.Fatal(err)
+		}
 	}
 }
@@ -0,0 +1,111 @@
+package ttsai
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/gokit"
+)
+
+// the ranges of the script params
+const (
+	MinSpeed   float32 = 0
+	MaxSpeed   float32 = 2
+	MinVolume          = 0
+	MaxVolume          = 100
+	MinSilence float32 = 0
+	MaxSilence float32 = 5
+)
+
+// ValidateScripts validates the scripts against the voices, the error lists every invalid field
+func ValidateScripts(scripts []*TtsScript, voices []*Voice) error {
+	if len(scripts) == 0 {
+		return errors.New("no scripts")
+	}
+
+	var (
+		speakers = gokit.SliceMap(voices, func(v *Voice) string { return v.Id })
+		problems = make([]string, 0)
+	)
+
+	for idx, script := range scripts {
+		if script == nil {
+			problems = append(problems, fmt.Sprintf("script %d: empty script", idx+1))
+			continue
+		}
+
+		for _, problem := range script.validate(speakers) {
+			problems = append(problems, fmt.Sprintf("script %d: %s", idx+1, problem))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(problems, "\n"))
+}
+
+// validate returns the problems of the script
+func (s *TtsScript) validate(speakers []string) []string {
+	problems := make([]string, 0)
+
+	if strings.TrimSpace(s.Text) == "" {
+		problems = append(problems, "text is empty")
+	}
+
+	if len(speakers) > 0 && !slices.Contains(speakers, s.Speaker) {
+		problems = append(problems, fmt.Sprintf("speaker %q is not one of %q", s.Speaker, speakers))
+	}
+
+	if s.Emotion != "" && !slices.Contains(AudioEmotions, s.Emotion) {
+		problems = append(problems, fmt.Sprintf("emotion %q is not one of %q", s.Emotion, AudioEmotions))
+	}
+
+	if s.Speed < MinSpeed || s.Speed > MaxSpeed {
+		problems = append(problems, fmt.Sprintf("speed %v is out of [%v, %v]", s.Speed, MinSpeed, MaxSpeed))
+	}
+
+	if s.Volume < MinVolume || s.Volume > MaxVolume {
+		problems = append(problems, fmt.Sprintf("volume %d is out of [%d, %d]", s.Volume, MinVolume, MaxVolume))
+	}
+
+	if s.Silence < MinSilence || s.Silence > MaxSilence {
+		problems = append(problems, fmt.Sprintf("silence %v is out of [%v, %v]", s.Silence, MinSilence, MaxSilence))
+	}
+
+	return problems
+}
+
+// ScriptsSchema returns the json schema of the scripts object replied by the model
+func ScriptsSchema(voices []*Voice) map[string]any {
+	speaker := map[string]any{"type": "string"}
+
+	if speakers := gokit.SliceMap(voices, func(v *Voice) string { return v.Id }); len(speakers) > 0 {
+		speaker["enum"] = speakers
+	}
+
+	script := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"text":    map[string]any{"type": "string"},
+			"speaker": speaker,
+			"emotion": map[string]any{"type": "string", "enum": AudioEmotions},
+			"speed":   map[string]any{"type": "number", "minimum": MinSpeed, "maximum": MaxSpeed},
+			"volume":  map[string]any{"type": "integer", "minimum": MinVolume, "maximum": MaxVolume},
+			"silence": map[string]any{"type": "number", "minimum": MinSilence, "maximum": MaxSilence},
+		},
+		"required":             []string{"text", "speaker", "emotion", "speed", "volume", "silence"},
+		"additionalProperties": false,
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"scripts": map[string]any{"type": "array", "items": script}},
+		"required":             []string{"scripts"},
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,41 @@
+package ttsai
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidateScripts testing the validation of the scripts against the voices
+func TestValidateScripts(t *testing.T) {
+	voices := []*Voice{{Id: "a"}, {Id: "b"}}
+
+	valid := []*TtsScript{
+		{Text: "hello", Speaker: "a", Emotion: "news", Speed: 1.1, Volume: 100, Silence: 0.2},
+		{Text: "world", Speaker: "b", Speed: 1, Volume: 80},
+	}
+
+	if err := ValidateScripts(valid, voices); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := []*TtsScript{
+		{Text: "hello", Speaker: "c", Emotion: "bored", Speed: 3, Volume: 120, Silence: 6},
+		{Text: " ", Speaker: "a"},
+	}
+
+	err := ValidateScripts(invalid, voices)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, problem := range []string{"script 1: speaker", "script 1: emotion", "script 1: speed", "script 1: volume",
+		"script 1: silence", "script 2: text"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("missing %q in %q", problem, err.Error())
+		}
+	}
+
+	if err := ValidateScripts(nil, voices); err == nil {
+		t.Error("expected an error for no scripts")
+	}
+}
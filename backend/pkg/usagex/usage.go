@@ -0,0 +1,83 @@
+package usagex
+
+import (
+	"context"
+	"sync"
+)
+
+// Kind is the kind of the ai call.
+type Kind string
+
+const (
+	KindText      Kind = "text"      // chat completion, billed by tokens
+	KindEmbedding Kind = "embedding" // embeddings, billed by prompt tokens
+	KindTts       Kind = "tts"       // text to speech, billed by characters
+)
+
+// Label describes the caller of the ai calls made with the context.
+type Label struct {
+	Feature string
+	BatchNo string
+	StageId uint
+	NewsId  uint
+}
+
+// Record is the usage of an ai call.
+type Record struct {
+	Label
+
+	Kind             Kind
+	Platform         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Characters       int
+}
+
+// Recorder receives the usage records.
+type Recorder func(ctx context.Context, record *Record)
+
+// labelKey is the context key of the label.
+type labelKey struct{}
+
+// WithLabel returns the context labelling the ai calls made with it.
+func WithLabel(ctx context.Context, label *Label) context.Context {
+	return context.WithValue(ctx, labelKey{}, label)
+}
+
+// LabelFrom returns the label of the context, an empty label if not set.
+func LabelFrom(ctx context.Context) Label {
+	if label, ok := ctx.Value(labelKey{}).(*Label); ok && label != nil {
+		return *label
+	}
+
+	return Label{}
+}
+
+var (
+	mu        sync.RWMutex
+	recorders []Recorder
+)
+
+// Listen registers a recorder receiving every published record.
+func Listen(recorder Recorder) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	recorders = append(recorders, recorder)
+}
+
+// Publish labels the record with the context and sends it to the recorders.
+// The recorders get a context without cancel, a cancelled call is still billed.
+func Publish(ctx context.Context, record *Record) {
+	record.Label = LabelFrom(ctx)
+	ctx = context.WithoutCancel(ctx)
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, recorder := range recorders {
+		recorder(ctx, record)
+	}
+}
@@ -0,0 +1,36 @@
+package usagex
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	var records []*Record
+
+	Listen(func(ctx context.Context, record *Record) {
+		if ctx.Err() != nil {
+			t.Errorf("expected a context without cancel, got %v", ctx.Err())
+		}
+
+		records = append(records, record)
+	})
+
+	ctx, cancel := context.WithCancel(WithLabel(context.Background(), &Label{Feature: "podcast", BatchNo: "b1"}))
+	cancel()
+
+	Publish(ctx, &Record{Kind: KindText, Model: "gpt", TotalTokens: 3})
+	Publish(context.Background(), &Record{Kind: KindTts, Characters: 5})
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Feature != "podcast" || records[0].BatchNo != "b1" || records[0].TotalTokens != 3 {
+		t.Fatalf("unexpected labelled record: %+v", records[0])
+	}
+
+	if records[1].Feature != "" || records[1].Characters != 5 {
+		t.Fatalf("unexpected record: %+v", records[1])
+	}
+}
@@ -0,0 +1,58 @@
+package vectorx
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// float32Size is the byte size of an encoded vector component.
+const float32Size = 4
+
+// Encode encodes the vector as little endian float32 values
+func Encode(vector []float64) []byte {
+	data := make([]byte, len(vector)*float32Size)
+
+	for idx, value := range vector {
+		binary.LittleEndian.PutUint32(data[idx*float32Size:], math.Float32bits(float32(value)))
+	}
+
+	return data
+}
+
+// Decode decodes the vector encoded by Encode
+func Decode(data []byte) ([]float64, error) {
+	if len(data)%float32Size != 0 {
+		return nil, errors.Errorf("invalid vector length: %d", len(data))
+	}
+
+	vector := make([]float64, len(data)/float32Size)
+
+	for idx := range vector {
+		vector[idx] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[idx*float32Size:])))
+	}
+
+	return vector, nil
+}
+
+// Cosine returns the cosine similarity of the two vectors, 0 if the dimensions differ
+func Cosine(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+
+	for idx := range a {
+		dot += a[idx] * b[idx]
+		normA += a[idx] * a[idx]
+		normB += b[idx] * b[idx]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
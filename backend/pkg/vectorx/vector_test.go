@@ -0,0 +1,41 @@
+package vectorx
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEncode testing vector encoding
+func TestEncode(t *testing.T) {
+	vector := []float64{0.5, -1.25, 3}
+
+	decoded, err := Decode(Encode(vector))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx := range vector {
+		if decoded[idx] != vector[idx] {
+			t.Errorf("unexpected value %d: %v", idx, decoded[idx])
+		}
+	}
+
+	if _, err := Decode([]byte{1, 2, 3}); err == nil {
+		t.Error("invalid data must fail")
+	}
+}
+
+// TestCosine testing cosine similarity
+func TestCosine(t *testing.T) {
+	if score := Cosine([]float64{1, 0}, []float64{2, 0}); math.Abs(score-1) > 1e-9 {
+		t.Errorf("unexpected score: %v", score)
+	}
+
+	if score := Cosine([]float64{1, 0}, []float64{0, 1}); score != 0 {
+		t.Errorf("unexpected score: %v", score)
+	}
+
+	if score := Cosine([]float64{1}, []float64{1, 0}); score != 0 {
+		t.Errorf("different dimensions must not match: %v", score)
+	}
+}
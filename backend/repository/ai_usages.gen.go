@@ -0,0 +1,379 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newAiUsage(db *gorm.DB, opts ...gen.DOOption) aiUsage {
+	_aiUsage := aiUsage{}
+
+	_aiUsage.aiUsageDo.UseDB(db, opts...)
+	_aiUsage.aiUsageDo.UseModel(&model.AiUsage{})
+
+	tableName := _aiUsage.aiUsageDo.TableName()
+	_aiUsage.ALL = field.NewAsterisk(tableName)
+	_aiUsage.ID = field.NewUint(tableName, "id")
+	_aiUsage.Feature = field.NewString(tableName, "feature")
+	_aiUsage.Kind = field.NewString(tableName, "kind")
+	_aiUsage.Platform = field.NewString(tableName, "platform")
+	_aiUsage.Model = field.NewString(tableName, "model")
+	_aiUsage.BatchNo = field.NewString(tableName, "batch_no")
+	_aiUsage.StageId = field.NewUint(tableName, "stage_id")
+	_aiUsage.NewsId = field.NewUint(tableName, "news_id")
+	_aiUsage.PromptTokens = field.NewInt(tableName, "prompt_tokens")
+	_aiUsage.CompletionTokens = field.NewInt(tableName, "completion_tokens")
+	_aiUsage.TotalTokens = field.NewInt(tableName, "total_tokens")
+	_aiUsage.Characters = field.NewInt(tableName, "characters")
+	_aiUsage.Cost = field.NewFloat64(tableName, "cost")
+	_aiUsage.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_aiUsage.fillFieldMap()
+
+	return _aiUsage
+}
+
+type aiUsage struct {
+	aiUsageDo aiUsageDo
+
+	ALL              field.Asterisk
+	ID               field.Uint
+	Feature          field.String
+	Kind             field.String
+	Platform         field.String
+	Model            field.String
+	BatchNo          field.String
+	StageId          field.Uint
+	NewsId           field.Uint
+	PromptTokens     field.Int
+	CompletionTokens field.Int
+	TotalTokens      field.Int
+	Characters       field.Int
+	Cost             field.Float64
+	CreatedAt        field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (a aiUsage) Table(newTableName string) *aiUsage {
+	a.aiUsageDo.UseTable(newTableName)
+	return a.updateTableName(newTableName)
+}
+
+func (a aiUsage) As(alias string) *aiUsage {
+	a.aiUsageDo.DO = *(a.aiUsageDo.As(alias).(*gen.DO))
+	return a.updateTableName(alias)
+}
+
+func (a *aiUsage) updateTableName(table string) *aiUsage {
+	a.ALL = field.NewAsterisk(table)
+	a.ID = field.NewUint(table, "id")
+	a.Feature = field.NewString(table, "feature")
+	a.Kind = field.NewString(table, "kind")
+	a.Platform = field.NewString(table, "platform")
+	a.Model = field.NewString(table, "model")
+	a.BatchNo = field.NewString(table, "batch_no")
+	a.StageId = field.NewUint(table, "stage_id")
+	a.NewsId = field.NewUint(table, "news_id")
+	a.PromptTokens = field.NewInt(table, "prompt_tokens")
+	a.CompletionTokens = field.NewInt(table, "completion_tokens")
+	a.TotalTokens = field.NewInt(table, "total_tokens")
+	a.Characters = field.NewInt(table, "characters")
+	a.Cost = field.NewFloat64(table, "cost")
+	a.CreatedAt = field.NewTime(table, "created_at")
+
+	a.fillFieldMap()
+
+	return a
+}
+
+func (a *aiUsage) WithContext(ctx context.Context) *aiUsageDo { return a.aiUsageDo.WithContext(ctx) }
+
+func (a aiUsage) TableName() string { return a.aiUsageDo.TableName() }
+
+func (a aiUsage) Alias() string { return a.aiUsageDo.Alias() }
+
+func (a aiUsage) Columns(cols ...field.Expr) gen.Columns { return a.aiUsageDo.Columns(cols...) }
+
+func (a *aiUsage) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := a.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (a *aiUsage) fillFieldMap() {
+	a.fieldMap = make(map[string]field.Expr, 14)
+	a.fieldMap["id"] = a.ID
+	a.fieldMap["feature"] = a.Feature
+	a.fieldMap["kind"] = a.Kind
+	a.fieldMap["platform"] = a.Platform
+	a.fieldMap["model"] = a.Model
+	a.fieldMap["batch_no"] = a.BatchNo
+	a.fieldMap["stage_id"] = a.StageId
+	a.fieldMap["news_id"] = a.NewsId
+	a.fieldMap["prompt_tokens"] = a.PromptTokens
+	a.fieldMap["completion_tokens"] = a.CompletionTokens
+	a.fieldMap["total_tokens"] = a.TotalTokens
+	a.fieldMap["characters"] = a.Characters
+	a.fieldMap["cost"] = a.Cost
+	a.fieldMap["created_at"] = a.CreatedAt
+}
+
+func (a aiUsage) clone(db *gorm.DB) aiUsage {
+	a.aiUsageDo.ReplaceConnPool(db.Statement.ConnPool)
+	return a
+}
+
+func (a aiUsage) replaceDB(db *gorm.DB) aiUsage {
+	a.aiUsageDo.ReplaceDB(db)
+	return a
+}
+
+type aiUsageDo struct{ gen.DO }
+
+func (a aiUsageDo) Debug() *aiUsageDo {
+	return a.withDO(a.DO.Debug())
+}
+
+func (a aiUsageDo) WithContext(ctx context.Context) *aiUsageDo {
+	return a.withDO(a.DO.WithContext(ctx))
+}
+
+func (a aiUsageDo) ReadDB() *aiUsageDo {
+	return a.Clauses(dbresolver.Read)
+}
+
+func (a aiUsageDo) WriteDB() *aiUsageDo {
+	return a.Clauses(dbresolver.Write)
+}
+
+func (a aiUsageDo) Session(config *gorm.Session) *aiUsageDo {
+	return a.withDO(a.DO.Session(config))
+}
+
+func (a aiUsageDo) Clauses(conds ...clause.Expression) *aiUsageDo {
+	return a.withDO(a.DO.Clauses(conds...))
+}
+
+func (a aiUsageDo) Returning(value interface{}, columns ...string) *aiUsageDo {
+	return a.withDO(a.DO.Returning(value, columns...))
+}
+
+func (a aiUsageDo) Not(conds ...gen.Condition) *aiUsageDo {
+	return a.withDO(a.DO.Not(conds...))
+}
+
+func (a aiUsageDo) Or(conds ...gen.Condition) *aiUsageDo {
+	return a.withDO(a.DO.Or(conds...))
+}
+
+func (a aiUsageDo) Select(conds ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Select(conds...))
+}
+
+func (a aiUsageDo) Where(conds ...gen.Condition) *aiUsageDo {
+	return a.withDO(a.DO.Where(conds...))
+}
+
+func (a aiUsageDo) Order(conds ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Order(conds...))
+}
+
+func (a aiUsageDo) Distinct(cols ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Distinct(cols...))
+}
+
+func (a aiUsageDo) Omit(cols ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Omit(cols...))
+}
+
+func (a aiUsageDo) Join(table schema.Tabler, on ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Join(table, on...))
+}
+
+func (a aiUsageDo) LeftJoin(table schema.Tabler, on ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.LeftJoin(table, on...))
+}
+
+func (a aiUsageDo) RightJoin(table schema.Tabler, on ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.RightJoin(table, on...))
+}
+
+func (a aiUsageDo) Group(cols ...field.Expr) *aiUsageDo {
+	return a.withDO(a.DO.Group(cols...))
+}
+
+func (a aiUsageDo) Having(conds ...gen.Condition) *aiUsageDo {
+	return a.withDO(a.DO.Having(conds...))
+}
+
+func (a aiUsageDo) Limit(limit int) *aiUsageDo {
+	return a.withDO(a.DO.Limit(limit))
+}
+
+func (a aiUsageDo) Offset(offset int) *aiUsageDo {
+	return a.withDO(a.DO.Offset(offset))
+}
+
+func (a aiUsageDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *aiUsageDo {
+	return a.withDO(a.DO.Scopes(funcs...))
+}
+
+func (a aiUsageDo) Unscoped() *aiUsageDo {
+	return a.withDO(a.DO.Unscoped())
+}
+
+func (a aiUsageDo) Create(values ...*model.AiUsage) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return a.DO.Create(values)
+}
+
+func (a aiUsageDo) CreateInBatches(values []*model.AiUsage, batchSize int) error {
+	return a.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (a aiUsageDo) Save(values ...*model.AiUsage) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return a.DO.Save(values)
+}
+
+func (a aiUsageDo) First() (*model.AiUsage, error) {
+	if result, err := a.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.AiUsage), nil
+	}
+}
+
+func (a aiUsageDo) Take() (*model.AiUsage, error) {
+	if result, err := a.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.AiUsage), nil
+	}
+}
+
+func (a aiUsageDo) Last() (*model.AiUsage, error) {
+	if result, err := a.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.AiUsage), nil
+	}
+}
+
+func (a aiUsageDo) Find() ([]*model.AiUsage, error) {
+	result, err := a.DO.Find()
+	return result.([]*model.AiUsage), err
+}
+
+func (a aiUsageDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.AiUsage, err error) {
+	buf := make([]*model.AiUsage, 0, batchSize)
+	err = a.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (a aiUsageDo) FindInBatches(result *[]*model.AiUsage, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return a.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (a aiUsageDo) Attrs(attrs ...field.AssignExpr) *aiUsageDo {
+	return a.withDO(a.DO.Attrs(attrs...))
+}
+
+func (a aiUsageDo) Assign(attrs ...field.AssignExpr) *aiUsageDo {
+	return a.withDO(a.DO.Assign(attrs...))
+}
+
+func (a aiUsageDo) Joins(fields ...field.RelationField) *aiUsageDo {
+	for _, _f := range fields {
+		a = *a.withDO(a.DO.Joins(_f))
+	}
+	return &a
+}
+
+func (a aiUsageDo) Preload(fields ...field.RelationField) *aiUsageDo {
+	for _, _f := range fields {
+		a = *a.withDO(a.DO.Preload(_f))
+	}
+	return &a
+}
+
+func (a aiUsageDo) FirstOrInit() (*model.AiUsage, error) {
+	if result, err := a.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.AiUsage), nil
+	}
+}
+
+func (a aiUsageDo) FirstOrCreate() (*model.AiUsage, error) {
+	if result, err := a.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.AiUsage), nil
+	}
+}
+
+func (a aiUsageDo) FindByPage(offset int, limit int) (result []*model.AiUsage, count int64, err error) {
+	result, err = a.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = a.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (a aiUsageDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = a.Count()
+	if err != nil {
+		return
+	}
+
+	err = a.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (a aiUsageDo) Scan(result interface{}) (err error) {
+	return a.DO.Scan(result)
+}
+
+func (a aiUsageDo) Delete(models ...*model.AiUsage) (result gen.ResultInfo, err error) {
+	return a.DO.Delete(models)
+}
+
+func (a *aiUsageDo) withDO(do gen.Dao) *aiUsageDo {
+	a.DO = *do.(*gen.DO)
+	return a
+}
@@ -16,54 +16,119 @@ import (
 )
 
 var (
-	Q              = new(Query)
-	CrawlingRecord *crawlingRecord
-	NewsDetail     *newsDetail
-	Podcast        *podcast
-	PodcastTask    *podcastTask
-	SystemConfig   *systemConfig
+	Q                 = new(Query)
+	AiUsage           *aiUsage
+	ChatMessage       *chatMessage
+	ChatSession       *chatSession
+	CrawlingRecord    *crawlingRecord
+	NamedEntity       *namedEntity
+	NewsAnalysis      *newsAnalysis
+	NewsAnalysisLink  *newsAnalysisLink
+	NewsDetail        *newsDetail
+	NewsDigest        *newsDigest
+	NewsEmbedding     *newsEmbedding
+	NewsEntityMention *newsEntityMention
+	NewsRevision      *newsRevision
+	NewsSummary       *newsSummary
+	Podcast           *podcast
+	PodcastJob        *podcastJob
+	PodcastTask       *podcastTask
+	PromptTemplate    *promptTemplate
+	SystemConfig      *systemConfig
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AiUsage = &Q.AiUsage
+	ChatMessage = &Q.ChatMessage
+	ChatSession = &Q.ChatSession
 	CrawlingRecord = &Q.CrawlingRecord
+	NamedEntity = &Q.NamedEntity
+	NewsAnalysis = &Q.NewsAnalysis
+	NewsAnalysisLink = &Q.NewsAnalysisLink
 	NewsDetail = &Q.NewsDetail
+	NewsDigest = &Q.NewsDigest
+	NewsEmbedding = &Q.NewsEmbedding
+	NewsEntityMention = &Q.NewsEntityMention
+	NewsRevision = &Q.NewsRevision
+	NewsSummary = &Q.NewsSummary
 	Podcast = &Q.Podcast
+	PodcastJob = &Q.PodcastJob
 	PodcastTask = &Q.PodcastTask
+	PromptTemplate = &Q.PromptTemplate
 	SystemConfig = &Q.SystemConfig
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:             db,
-		CrawlingRecord: newCrawlingRecord(db, opts...),
-		NewsDetail:     newNewsDetail(db, opts...),
-		Podcast:        newPodcast(db, opts...),
-		PodcastTask:    newPodcastTask(db, opts...),
-		SystemConfig:   newSystemConfig(db, opts...),
+		db:                db,
+		AiUsage:           newAiUsage(db, opts...),
+		ChatMessage:       newChatMessage(db, opts...),
+		ChatSession:       newChatSession(db, opts...),
+		CrawlingRecord:    newCrawlingRecord(db, opts...),
+		NamedEntity:       newNamedEntity(db, opts...),
+		NewsAnalysis:      newNewsAnalysis(db, opts...),
+		NewsAnalysisLink:  newNewsAnalysisLink(db, opts...),
+		NewsDetail:        newNewsDetail(db, opts...),
+		NewsDigest:        newNewsDigest(db, opts...),
+		NewsEmbedding:     newNewsEmbedding(db, opts...),
+		NewsEntityMention: newNewsEntityMention(db, opts...),
+		NewsRevision:      newNewsRevision(db, opts...),
+		NewsSummary:       newNewsSummary(db, opts...),
+		Podcast:           newPodcast(db, opts...),
+		PodcastJob:        newPodcastJob(db, opts...),
+		PodcastTask:       newPodcastTask(db, opts...),
+		PromptTemplate:    newPromptTemplate(db, opts...),
+		SystemConfig:      newSystemConfig(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	CrawlingRecord crawlingRecord
-	NewsDetail     newsDetail
-	Podcast        podcast
-	PodcastTask    podcastTask
-	SystemConfig   systemConfig
+	AiUsage           aiUsage
+	ChatMessage       chatMessage
+	ChatSession       chatSession
+	CrawlingRecord    crawlingRecord
+	NamedEntity       namedEntity
+	NewsAnalysis      newsAnalysis
+	NewsAnalysisLink  newsAnalysisLink
+	NewsDetail        newsDetail
+	NewsDigest        newsDigest
+	NewsEmbedding     newsEmbedding
+	NewsEntityMention newsEntityMention
+	NewsRevision      newsRevision
+	NewsSummary       newsSummary
+	Podcast           podcast
+	PodcastJob        podcastJob
+	PodcastTask       podcastTask
+	PromptTemplate    promptTemplate
+	SystemConfig      systemConfig
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		CrawlingRecord: q.CrawlingRecord.clone(db),
-		NewsDetail:     q.NewsDetail.clone(db),
-		Podcast:        q.Podcast.clone(db),
-		PodcastTask:    q.PodcastTask.clone(db),
-		SystemConfig:   q.SystemConfig.clone(db),
+		db:                db,
+		AiUsage:           q.AiUsage.clone(db),
+		ChatMessage:       q.ChatMessage.clone(db),
+		ChatSession:       q.ChatSession.clone(db),
+		CrawlingRecord:    q.CrawlingRecord.clone(db),
+		NamedEntity:       q.NamedEntity.clone(db),
+		NewsAnalysis:      q.NewsAnalysis.clone(db),
+		NewsAnalysisLink:  q.NewsAnalysisLink.clone(db),
+		NewsDetail:        q.NewsDetail.clone(db),
+		NewsDigest:        q.NewsDigest.clone(db),
+		NewsEmbedding:     q.NewsEmbedding.clone(db),
+		NewsEntityMention: q.NewsEntityMention.clone(db),
+		NewsRevision:      q.NewsRevision.clone(db),
+		NewsSummary:       q.NewsSummary.clone(db),
+		Podcast:           q.Podcast.clone(db),
+		PodcastJob:        q.PodcastJob.clone(db),
+		PodcastTask:       q.PodcastTask.clone(db),
+		PromptTemplate:    q.PromptTemplate.clone(db),
+		SystemConfig:      q.SystemConfig.clone(db),
 	}
 }
 
@@ -77,30 +142,69 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:             db,
-		CrawlingRecord: q.CrawlingRecord.replaceDB(db),
-		NewsDetail:     q.NewsDetail.replaceDB(db),
-		Podcast:        q.Podcast.replaceDB(db),
-		PodcastTask:    q.PodcastTask.replaceDB(db),
-		SystemConfig:   q.SystemConfig.replaceDB(db),
+		db:                db,
+		AiUsage:           q.AiUsage.replaceDB(db),
+		ChatMessage:       q.ChatMessage.replaceDB(db),
+		ChatSession:       q.ChatSession.replaceDB(db),
+		CrawlingRecord:    q.CrawlingRecord.replaceDB(db),
+		NamedEntity:       q.NamedEntity.replaceDB(db),
+		NewsAnalysis:      q.NewsAnalysis.replaceDB(db),
+		NewsAnalysisLink:  q.NewsAnalysisLink.replaceDB(db),
+		NewsDetail:        q.NewsDetail.replaceDB(db),
+		NewsDigest:        q.NewsDigest.replaceDB(db),
+		NewsEmbedding:     q.NewsEmbedding.replaceDB(db),
+		NewsEntityMention: q.NewsEntityMention.replaceDB(db),
+		NewsRevision:      q.NewsRevision.replaceDB(db),
+		NewsSummary:       q.NewsSummary.replaceDB(db),
+		Podcast:           q.Podcast.replaceDB(db),
+		PodcastJob:        q.PodcastJob.replaceDB(db),
+		PodcastTask:       q.PodcastTask.replaceDB(db),
+		PromptTemplate:    q.PromptTemplate.replaceDB(db),
+		SystemConfig:      q.SystemConfig.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	CrawlingRecord *crawlingRecordDo
-	NewsDetail     *newsDetailDo
-	Podcast        *podcastDo
-	PodcastTask    *podcastTaskDo
-	SystemConfig   *systemConfigDo
+	AiUsage           *aiUsageDo
+	ChatMessage       *chatMessageDo
+	ChatSession       *chatSessionDo
+	CrawlingRecord    *crawlingRecordDo
+	NamedEntity       *namedEntityDo
+	NewsAnalysis      *newsAnalysisDo
+	NewsAnalysisLink  *newsAnalysisLinkDo
+	NewsDetail        *newsDetailDo
+	NewsDigest        *newsDigestDo
+	NewsEmbedding     *newsEmbeddingDo
+	NewsEntityMention *newsEntityMentionDo
+	NewsRevision      *newsRevisionDo
+	NewsSummary       *newsSummaryDo
+	Podcast           *podcastDo
+	PodcastJob        *podcastJobDo
+	PodcastTask       *podcastTaskDo
+	PromptTemplate    *promptTemplateDo
+	SystemConfig      *systemConfigDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		CrawlingRecord: q.CrawlingRecord.WithContext(ctx),
-		NewsDetail:     q.NewsDetail.WithContext(ctx),
-		Podcast:        q.Podcast.WithContext(ctx),
-		PodcastTask:    q.PodcastTask.WithContext(ctx),
-		SystemConfig:   q.SystemConfig.WithContext(ctx),
+		AiUsage:           q.AiUsage.WithContext(ctx),
+		ChatMessage:       q.ChatMessage.WithContext(ctx),
+		ChatSession:       q.ChatSession.WithContext(ctx),
+		CrawlingRecord:    q.CrawlingRecord.WithContext(ctx),
+		NamedEntity:       q.NamedEntity.WithContext(ctx),
+		NewsAnalysis:      q.NewsAnalysis.WithContext(ctx),
+		NewsAnalysisLink:  q.NewsAnalysisLink.WithContext(ctx),
+		NewsDetail:        q.NewsDetail.WithContext(ctx),
+		NewsDigest:        q.NewsDigest.WithContext(ctx),
+		NewsEmbedding:     q.NewsEmbedding.WithContext(ctx),
+		NewsEntityMention: q.NewsEntityMention.WithContext(ctx),
+		NewsRevision:      q.NewsRevision.WithContext(ctx),
+		NewsSummary:       q.NewsSummary.WithContext(ctx),
+		Podcast:           q.Podcast.WithContext(ctx),
+		PodcastJob:        q.PodcastJob.WithContext(ctx),
+		PodcastTask:       q.PodcastTask.WithContext(ctx),
+		PromptTemplate:    q.PromptTemplate.WithContext(ctx),
+		SystemConfig:      q.SystemConfig.WithContext(ctx),
 	}
 }
 
@@ -24,7 +24,10 @@ func main() {
 
 	g.UseDB(db)
 
-	g.ApplyBasic(model.NewsDetail{}, model.SystemConfig{}, model.CrawlingRecord{}, model.PodcastTask{}, model.Podcast{})
+	g.ApplyBasic(model.NewsDetail{}, model.SystemConfig{}, model.CrawlingRecord{}, model.PodcastTask{}, model.Podcast{},
+		model.NewsRevision{}, model.NamedEntity{}, model.NewsEntityMention{}, model.NewsDigest{}, model.NewsSummary{},
+		model.NewsEmbedding{}, model.NewsAnalysis{}, model.NewsAnalysisLink{},
+		model.ChatSession{}, model.ChatMessage{}, model.PromptTemplate{}, model.AiUsage{}, model.PodcastJob{})
 
 	g.Execute()
 }
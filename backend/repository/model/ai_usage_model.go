@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// AiUsage represents the token or character usage of an ai call.
+type AiUsage struct {
+	ID               uint   `gorm:"primaryKey"`
+	Feature          string `gorm:"index"`
+	Kind             string
+	Platform         string
+	Model            string
+	BatchNo          string `gorm:"index"`
+	StageId          uint
+	NewsId           uint
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Characters       int
+	Cost             float64
+	CreatedAt        time.Time `gorm:"index"`
+}
+
+func (a *AiUsage) TableName() string {
+	return "ai_usages"
+}
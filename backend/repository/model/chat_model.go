@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// ChatSession represents a conversation about one or more news.
+type ChatSession struct {
+	ID        uint `gorm:"primaryKey"`
+	Title     string
+	NewsIds   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *ChatSession) TableName() string {
+	return "chat_sessions"
+}
+
+// ChatMessage represents a message of the chat session.
+type ChatMessage struct {
+	ID        uint   `gorm:"primaryKey"`
+	SessionId uint   `gorm:"index;not null"`
+	Role      string `gorm:"not null"`
+	Content   string
+	CreatedAt time.Time
+}
+
+func (c *ChatMessage) TableName() string {
+	return "chat_messages"
+}
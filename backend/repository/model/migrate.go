@@ -4,5 +4,8 @@ import "gorm.io/gorm"
 
 // AutoMigrate will migrate all models to database
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(&NewsDetail{}, &CrawlingRecord{}, &SystemConfig{}, &PodcastTask{}, &Podcast{})
+	return db.AutoMigrate(&NewsDetail{}, &CrawlingRecord{}, &SystemConfig{}, &PodcastTask{}, &Podcast{},
+		&NewsRevision{}, &NamedEntity{}, &NewsEntityMention{}, &NewsDigest{}, &NewsSummary{},
+		&NewsEmbedding{}, &NewsAnalysis{}, &NewsAnalysisLink{}, &ChatSession{}, &ChatMessage{}, &PromptTemplate{},
+		&AiUsage{}, &PodcastJob{})
 }
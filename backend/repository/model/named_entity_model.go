@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// NamedEntity represents a person, organization or location mentioned by the news.
+type NamedEntity struct {
+	ID        uint   `gorm:"primaryKey"`
+	Type      string `gorm:"uniqueIndex:idx_named_entity_key;not null"`
+	Key       string `gorm:"uniqueIndex:idx_named_entity_key;not null"` // lower case name
+	Name      string
+	CreatedAt time.Time
+}
+
+func (n *NamedEntity) TableName() string {
+	return "named_entities"
+}
+
+// NewsEntityMention represents a named entity mentioned by a news.
+type NewsEntityMention struct {
+	ID        uint `gorm:"primaryKey"`
+	NewsId    uint `gorm:"index;not null"`
+	EntityId  uint `gorm:"index;not null"`
+	Count     int
+	CreatedAt time.Time
+}
+
+func (n *NewsEntityMention) TableName() string {
+	return "news_entity_mentions"
+}
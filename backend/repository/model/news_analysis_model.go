@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// NewsAnalysis represents the ai analysis of one or more news.
+type NewsAnalysis struct {
+	ID        uint   `gorm:"primaryKey"`
+	Type      string `gorm:"index;not null"`
+	Title     string
+	Language  string
+	NewsIds   string
+	Result    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (n *NewsAnalysis) TableName() string {
+	return "news_analyses"
+}
+
+// NewsAnalysisLink links the news analysis to an analyzed news.
+type NewsAnalysisLink struct {
+	ID         uint `gorm:"primaryKey"`
+	AnalysisId uint `gorm:"uniqueIndex:idx_news_analysis_link;not null"`
+	NewsId     uint `gorm:"uniqueIndex:idx_news_analysis_link;index;not null"`
+	CreatedAt  time.Time
+}
+
+func (n *NewsAnalysisLink) TableName() string {
+	return "news_analysis_links"
+}
@@ -18,6 +18,11 @@ type NewsDetail struct {
 	Scraped     bool
 	Favorited   bool
 	CreatedAt   time.Time
+
+	ScrapeAttempts int        // consecutive failed scrapes
+	NextScrapeAt   *time.Time // next retry time of the failed scrape
+
+	EntityExtracted bool `gorm:"index"` // named entities extracted
 }
 
 func (n *NewsDetail) TableName() string {
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// NewsDigest represents the daily digest of the news.
+type NewsDigest struct {
+	ID        uint   `gorm:"primaryKey"`
+	Date      string `gorm:"uniqueIndex;not null"`
+	Title     string
+	Language  string
+	Status    string
+	Sections  string
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (n *NewsDigest) TableName() string {
+	return "news_digests"
+}
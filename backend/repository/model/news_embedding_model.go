@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// NewsEmbedding represents the embedding vector of the news.
+type NewsEmbedding struct {
+	ID         uint   `gorm:"primaryKey"`
+	NewsId     uint   `gorm:"uniqueIndex;not null"`
+	Model      string `gorm:"index"`
+	Dimensions int
+	Vector     []byte // little endian float32 values
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (n *NewsEmbedding) TableName() string {
+	return "news_embeddings"
+}
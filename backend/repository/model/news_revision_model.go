@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// NewsRevision represents a scraped revision of the news content.
+type NewsRevision struct {
+	ID        uint `gorm:"primaryKey"`
+	NewsId    uint `gorm:"index;not null"`
+	Revision  int
+	Title     string
+	Author    string
+	Contents  string
+	CreatedAt time.Time
+}
+
+func (n *NewsRevision) TableName() string {
+	return "news_revisions"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// NewsSummary represents the ai summary of the news in a language.
+type NewsSummary struct {
+	ID        uint   `gorm:"primaryKey"`
+	NewsId    uint   `gorm:"uniqueIndex:idx_news_summary_language;not null"`
+	Language  string `gorm:"uniqueIndex:idx_news_summary_language;not null"`
+	Short     string
+	Long      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (n *NewsSummary) TableName() string {
+	return "news_summaries"
+}
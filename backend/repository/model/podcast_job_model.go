@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// PodcastJob represents a queued background job of a podcast task.
+type PodcastJob struct {
+	ID         uint `gorm:"primaryKey"`
+	Kind       string
+	BatchNo    string `gorm:"index"`
+	Status     string `gorm:"index"`
+	Attempts   int
+	Worker     string
+	LeaseUntil time.Time
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (p *PodcastJob) TableName() string {
+	return "podcast_jobs"
+}
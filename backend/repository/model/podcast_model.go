@@ -2,14 +2,20 @@ package model
 
 import "time"
 
-// Podcast is the model for podcast
+// Podcast is the model for the published podcast episode
 type Podcast struct {
-	ID        uint `gorm:"primaryKey"`
-	NewsId    uint `gorm:"index,not null"`
-	Script    string
-	Language  string
-	Audio     string
-	Style     string
-	TtsAi     string
-	CreatedAt time.Time
+	ID          uint   `gorm:"primaryKey"`
+	BatchNo     string `gorm:"uniqueIndex;not null"`
+	NewsId      uint   `gorm:"index,not null"`
+	NewsIds     string
+	Title       string
+	Description string
+	Script      string
+	Language    string
+	Audio       string
+	Duration    int
+	Style       string
+	TtsAi       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
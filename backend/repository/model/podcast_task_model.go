@@ -19,6 +19,10 @@ type PodcastTask struct {
 	Audio     string
 	TaskAi    string
 	Extra     string
+	Approval  string
+	Classify  string
+	Attempts  int
+	Failures  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
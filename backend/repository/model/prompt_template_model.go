@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// PromptTemplate represents a version of a prompt template variant, the latest version is in use.
+type PromptTemplate struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"uniqueIndex:idx_prompt_template_version;not null"`
+	Language  string `gorm:"uniqueIndex:idx_prompt_template_version"` // empty for the default variant
+	Version   int    `gorm:"uniqueIndex:idx_prompt_template_version;not null"`
+	Content   string
+	Note      string
+	CreatedAt time.Time
+}
+
+func (p *PromptTemplate) TableName() string {
+	return "prompt_templates"
+}
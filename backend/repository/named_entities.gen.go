@@ -0,0 +1,345 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNamedEntity(db *gorm.DB, opts ...gen.DOOption) namedEntity {
+	_namedEntity := namedEntity{}
+
+	_namedEntity.namedEntityDo.UseDB(db, opts...)
+	_namedEntity.namedEntityDo.UseModel(&model.NamedEntity{})
+
+	tableName := _namedEntity.namedEntityDo.TableName()
+	_namedEntity.ALL = field.NewAsterisk(tableName)
+	_namedEntity.ID = field.NewUint(tableName, "id")
+	_namedEntity.Type = field.NewString(tableName, "type")
+	_namedEntity.Key = field.NewString(tableName, "key")
+	_namedEntity.Name = field.NewString(tableName, "name")
+	_namedEntity.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_namedEntity.fillFieldMap()
+
+	return _namedEntity
+}
+
+type namedEntity struct {
+	namedEntityDo namedEntityDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	Type      field.String
+	Key       field.String
+	Name      field.String
+	CreatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n namedEntity) Table(newTableName string) *namedEntity {
+	n.namedEntityDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n namedEntity) As(alias string) *namedEntity {
+	n.namedEntityDo.DO = *(n.namedEntityDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *namedEntity) updateTableName(table string) *namedEntity {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.Type = field.NewString(table, "type")
+	n.Key = field.NewString(table, "key")
+	n.Name = field.NewString(table, "name")
+	n.CreatedAt = field.NewTime(table, "created_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *namedEntity) WithContext(ctx context.Context) *namedEntityDo {
+	return n.namedEntityDo.WithContext(ctx)
+}
+
+func (n namedEntity) TableName() string { return n.namedEntityDo.TableName() }
+
+func (n namedEntity) Alias() string { return n.namedEntityDo.Alias() }
+
+func (n namedEntity) Columns(cols ...field.Expr) gen.Columns { return n.namedEntityDo.Columns(cols...) }
+
+func (n *namedEntity) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *namedEntity) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 5)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["type"] = n.Type
+	n.fieldMap["key"] = n.Key
+	n.fieldMap["name"] = n.Name
+	n.fieldMap["created_at"] = n.CreatedAt
+}
+
+func (n namedEntity) clone(db *gorm.DB) namedEntity {
+	n.namedEntityDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n namedEntity) replaceDB(db *gorm.DB) namedEntity {
+	n.namedEntityDo.ReplaceDB(db)
+	return n
+}
+
+type namedEntityDo struct{ gen.DO }
+
+func (n namedEntityDo) Debug() *namedEntityDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n namedEntityDo) WithContext(ctx context.Context) *namedEntityDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n namedEntityDo) ReadDB() *namedEntityDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n namedEntityDo) WriteDB() *namedEntityDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n namedEntityDo) Session(config *gorm.Session) *namedEntityDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n namedEntityDo) Clauses(conds ...clause.Expression) *namedEntityDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n namedEntityDo) Returning(value interface{}, columns ...string) *namedEntityDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n namedEntityDo) Not(conds ...gen.Condition) *namedEntityDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n namedEntityDo) Or(conds ...gen.Condition) *namedEntityDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n namedEntityDo) Select(conds ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n namedEntityDo) Where(conds ...gen.Condition) *namedEntityDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n namedEntityDo) Order(conds ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n namedEntityDo) Distinct(cols ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n namedEntityDo) Omit(cols ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n namedEntityDo) Join(table schema.Tabler, on ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n namedEntityDo) LeftJoin(table schema.Tabler, on ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n namedEntityDo) RightJoin(table schema.Tabler, on ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n namedEntityDo) Group(cols ...field.Expr) *namedEntityDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n namedEntityDo) Having(conds ...gen.Condition) *namedEntityDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n namedEntityDo) Limit(limit int) *namedEntityDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n namedEntityDo) Offset(offset int) *namedEntityDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n namedEntityDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *namedEntityDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n namedEntityDo) Unscoped() *namedEntityDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n namedEntityDo) Create(values ...*model.NamedEntity) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n namedEntityDo) CreateInBatches(values []*model.NamedEntity, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n namedEntityDo) Save(values ...*model.NamedEntity) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n namedEntityDo) First() (*model.NamedEntity, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NamedEntity), nil
+	}
+}
+
+func (n namedEntityDo) Take() (*model.NamedEntity, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NamedEntity), nil
+	}
+}
+
+func (n namedEntityDo) Last() (*model.NamedEntity, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NamedEntity), nil
+	}
+}
+
+func (n namedEntityDo) Find() ([]*model.NamedEntity, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NamedEntity), err
+}
+
+func (n namedEntityDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NamedEntity, err error) {
+	buf := make([]*model.NamedEntity, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n namedEntityDo) FindInBatches(result *[]*model.NamedEntity, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n namedEntityDo) Attrs(attrs ...field.AssignExpr) *namedEntityDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n namedEntityDo) Assign(attrs ...field.AssignExpr) *namedEntityDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n namedEntityDo) Joins(fields ...field.RelationField) *namedEntityDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n namedEntityDo) Preload(fields ...field.RelationField) *namedEntityDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n namedEntityDo) FirstOrInit() (*model.NamedEntity, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NamedEntity), nil
+	}
+}
+
+func (n namedEntityDo) FirstOrCreate() (*model.NamedEntity, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NamedEntity), nil
+	}
+}
+
+func (n namedEntityDo) FindByPage(offset int, limit int) (result []*model.NamedEntity, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n namedEntityDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n namedEntityDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n namedEntityDo) Delete(models ...*model.NamedEntity) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *namedEntityDo) withDO(do gen.Dao) *namedEntityDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
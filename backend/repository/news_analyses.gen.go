@@ -0,0 +1,359 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsAnalysis(db *gorm.DB, opts ...gen.DOOption) newsAnalysis {
+	_newsAnalysis := newsAnalysis{}
+
+	_newsAnalysis.newsAnalysisDo.UseDB(db, opts...)
+	_newsAnalysis.newsAnalysisDo.UseModel(&model.NewsAnalysis{})
+
+	tableName := _newsAnalysis.newsAnalysisDo.TableName()
+	_newsAnalysis.ALL = field.NewAsterisk(tableName)
+	_newsAnalysis.ID = field.NewUint(tableName, "id")
+	_newsAnalysis.Type = field.NewString(tableName, "type")
+	_newsAnalysis.Title = field.NewString(tableName, "title")
+	_newsAnalysis.Language = field.NewString(tableName, "language")
+	_newsAnalysis.NewsIds = field.NewString(tableName, "news_ids")
+	_newsAnalysis.Result = field.NewString(tableName, "result")
+	_newsAnalysis.CreatedAt = field.NewTime(tableName, "created_at")
+	_newsAnalysis.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_newsAnalysis.fillFieldMap()
+
+	return _newsAnalysis
+}
+
+type newsAnalysis struct {
+	newsAnalysisDo newsAnalysisDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	Type      field.String
+	Title     field.String
+	Language  field.String
+	NewsIds   field.String
+	Result    field.String
+	CreatedAt field.Time
+	UpdatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsAnalysis) Table(newTableName string) *newsAnalysis {
+	n.newsAnalysisDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsAnalysis) As(alias string) *newsAnalysis {
+	n.newsAnalysisDo.DO = *(n.newsAnalysisDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsAnalysis) updateTableName(table string) *newsAnalysis {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.Type = field.NewString(table, "type")
+	n.Title = field.NewString(table, "title")
+	n.Language = field.NewString(table, "language")
+	n.NewsIds = field.NewString(table, "news_ids")
+	n.Result = field.NewString(table, "result")
+	n.CreatedAt = field.NewTime(table, "created_at")
+	n.UpdatedAt = field.NewTime(table, "updated_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsAnalysis) WithContext(ctx context.Context) *newsAnalysisDo {
+	return n.newsAnalysisDo.WithContext(ctx)
+}
+
+func (n newsAnalysis) TableName() string { return n.newsAnalysisDo.TableName() }
+
+func (n newsAnalysis) Alias() string { return n.newsAnalysisDo.Alias() }
+
+func (n newsAnalysis) Columns(cols ...field.Expr) gen.Columns {
+	return n.newsAnalysisDo.Columns(cols...)
+}
+
+func (n *newsAnalysis) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsAnalysis) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 8)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["type"] = n.Type
+	n.fieldMap["title"] = n.Title
+	n.fieldMap["language"] = n.Language
+	n.fieldMap["news_ids"] = n.NewsIds
+	n.fieldMap["result"] = n.Result
+	n.fieldMap["created_at"] = n.CreatedAt
+	n.fieldMap["updated_at"] = n.UpdatedAt
+}
+
+func (n newsAnalysis) clone(db *gorm.DB) newsAnalysis {
+	n.newsAnalysisDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsAnalysis) replaceDB(db *gorm.DB) newsAnalysis {
+	n.newsAnalysisDo.ReplaceDB(db)
+	return n
+}
+
+type newsAnalysisDo struct{ gen.DO }
+
+func (n newsAnalysisDo) Debug() *newsAnalysisDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsAnalysisDo) WithContext(ctx context.Context) *newsAnalysisDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsAnalysisDo) ReadDB() *newsAnalysisDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsAnalysisDo) WriteDB() *newsAnalysisDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsAnalysisDo) Session(config *gorm.Session) *newsAnalysisDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsAnalysisDo) Clauses(conds ...clause.Expression) *newsAnalysisDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsAnalysisDo) Returning(value interface{}, columns ...string) *newsAnalysisDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsAnalysisDo) Not(conds ...gen.Condition) *newsAnalysisDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsAnalysisDo) Or(conds ...gen.Condition) *newsAnalysisDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsAnalysisDo) Select(conds ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsAnalysisDo) Where(conds ...gen.Condition) *newsAnalysisDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsAnalysisDo) Order(conds ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsAnalysisDo) Distinct(cols ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsAnalysisDo) Omit(cols ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsAnalysisDo) Join(table schema.Tabler, on ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsAnalysisDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsAnalysisDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsAnalysisDo) Group(cols ...field.Expr) *newsAnalysisDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsAnalysisDo) Having(conds ...gen.Condition) *newsAnalysisDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsAnalysisDo) Limit(limit int) *newsAnalysisDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsAnalysisDo) Offset(offset int) *newsAnalysisDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsAnalysisDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsAnalysisDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsAnalysisDo) Unscoped() *newsAnalysisDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsAnalysisDo) Create(values ...*model.NewsAnalysis) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsAnalysisDo) CreateInBatches(values []*model.NewsAnalysis, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsAnalysisDo) Save(values ...*model.NewsAnalysis) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsAnalysisDo) First() (*model.NewsAnalysis, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysis), nil
+	}
+}
+
+func (n newsAnalysisDo) Take() (*model.NewsAnalysis, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysis), nil
+	}
+}
+
+func (n newsAnalysisDo) Last() (*model.NewsAnalysis, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysis), nil
+	}
+}
+
+func (n newsAnalysisDo) Find() ([]*model.NewsAnalysis, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsAnalysis), err
+}
+
+func (n newsAnalysisDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsAnalysis, err error) {
+	buf := make([]*model.NewsAnalysis, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsAnalysisDo) FindInBatches(result *[]*model.NewsAnalysis, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsAnalysisDo) Attrs(attrs ...field.AssignExpr) *newsAnalysisDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsAnalysisDo) Assign(attrs ...field.AssignExpr) *newsAnalysisDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsAnalysisDo) Joins(fields ...field.RelationField) *newsAnalysisDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsAnalysisDo) Preload(fields ...field.RelationField) *newsAnalysisDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsAnalysisDo) FirstOrInit() (*model.NewsAnalysis, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysis), nil
+	}
+}
+
+func (n newsAnalysisDo) FirstOrCreate() (*model.NewsAnalysis, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysis), nil
+	}
+}
+
+func (n newsAnalysisDo) FindByPage(offset int, limit int) (result []*model.NewsAnalysis, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsAnalysisDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsAnalysisDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsAnalysisDo) Delete(models ...*model.NewsAnalysis) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsAnalysisDo) withDO(do gen.Dao) *newsAnalysisDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
@@ -0,0 +1,343 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsAnalysisLink(db *gorm.DB, opts ...gen.DOOption) newsAnalysisLink {
+	_newsAnalysisLink := newsAnalysisLink{}
+
+	_newsAnalysisLink.newsAnalysisLinkDo.UseDB(db, opts...)
+	_newsAnalysisLink.newsAnalysisLinkDo.UseModel(&model.NewsAnalysisLink{})
+
+	tableName := _newsAnalysisLink.newsAnalysisLinkDo.TableName()
+	_newsAnalysisLink.ALL = field.NewAsterisk(tableName)
+	_newsAnalysisLink.ID = field.NewUint(tableName, "id")
+	_newsAnalysisLink.AnalysisId = field.NewUint(tableName, "analysis_id")
+	_newsAnalysisLink.NewsId = field.NewUint(tableName, "news_id")
+	_newsAnalysisLink.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_newsAnalysisLink.fillFieldMap()
+
+	return _newsAnalysisLink
+}
+
+type newsAnalysisLink struct {
+	newsAnalysisLinkDo newsAnalysisLinkDo
+
+	ALL        field.Asterisk
+	ID         field.Uint
+	AnalysisId field.Uint
+	NewsId     field.Uint
+	CreatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsAnalysisLink) Table(newTableName string) *newsAnalysisLink {
+	n.newsAnalysisLinkDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsAnalysisLink) As(alias string) *newsAnalysisLink {
+	n.newsAnalysisLinkDo.DO = *(n.newsAnalysisLinkDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsAnalysisLink) updateTableName(table string) *newsAnalysisLink {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.AnalysisId = field.NewUint(table, "analysis_id")
+	n.NewsId = field.NewUint(table, "news_id")
+	n.CreatedAt = field.NewTime(table, "created_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsAnalysisLink) WithContext(ctx context.Context) *newsAnalysisLinkDo {
+	return n.newsAnalysisLinkDo.WithContext(ctx)
+}
+
+func (n newsAnalysisLink) TableName() string { return n.newsAnalysisLinkDo.TableName() }
+
+func (n newsAnalysisLink) Alias() string { return n.newsAnalysisLinkDo.Alias() }
+
+func (n newsAnalysisLink) Columns(cols ...field.Expr) gen.Columns {
+	return n.newsAnalysisLinkDo.Columns(cols...)
+}
+
+func (n *newsAnalysisLink) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsAnalysisLink) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 4)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["analysis_id"] = n.AnalysisId
+	n.fieldMap["news_id"] = n.NewsId
+	n.fieldMap["created_at"] = n.CreatedAt
+}
+
+func (n newsAnalysisLink) clone(db *gorm.DB) newsAnalysisLink {
+	n.newsAnalysisLinkDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsAnalysisLink) replaceDB(db *gorm.DB) newsAnalysisLink {
+	n.newsAnalysisLinkDo.ReplaceDB(db)
+	return n
+}
+
+type newsAnalysisLinkDo struct{ gen.DO }
+
+func (n newsAnalysisLinkDo) Debug() *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsAnalysisLinkDo) WithContext(ctx context.Context) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsAnalysisLinkDo) ReadDB() *newsAnalysisLinkDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsAnalysisLinkDo) WriteDB() *newsAnalysisLinkDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsAnalysisLinkDo) Session(config *gorm.Session) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsAnalysisLinkDo) Clauses(conds ...clause.Expression) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsAnalysisLinkDo) Returning(value interface{}, columns ...string) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsAnalysisLinkDo) Not(conds ...gen.Condition) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsAnalysisLinkDo) Or(conds ...gen.Condition) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsAnalysisLinkDo) Select(conds ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsAnalysisLinkDo) Where(conds ...gen.Condition) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsAnalysisLinkDo) Order(conds ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsAnalysisLinkDo) Distinct(cols ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsAnalysisLinkDo) Omit(cols ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsAnalysisLinkDo) Join(table schema.Tabler, on ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsAnalysisLinkDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsAnalysisLinkDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsAnalysisLinkDo) Group(cols ...field.Expr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsAnalysisLinkDo) Having(conds ...gen.Condition) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsAnalysisLinkDo) Limit(limit int) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsAnalysisLinkDo) Offset(offset int) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsAnalysisLinkDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsAnalysisLinkDo) Unscoped() *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsAnalysisLinkDo) Create(values ...*model.NewsAnalysisLink) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsAnalysisLinkDo) CreateInBatches(values []*model.NewsAnalysisLink, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsAnalysisLinkDo) Save(values ...*model.NewsAnalysisLink) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsAnalysisLinkDo) First() (*model.NewsAnalysisLink, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysisLink), nil
+	}
+}
+
+func (n newsAnalysisLinkDo) Take() (*model.NewsAnalysisLink, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysisLink), nil
+	}
+}
+
+func (n newsAnalysisLinkDo) Last() (*model.NewsAnalysisLink, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysisLink), nil
+	}
+}
+
+func (n newsAnalysisLinkDo) Find() ([]*model.NewsAnalysisLink, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsAnalysisLink), err
+}
+
+func (n newsAnalysisLinkDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsAnalysisLink, err error) {
+	buf := make([]*model.NewsAnalysisLink, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsAnalysisLinkDo) FindInBatches(result *[]*model.NewsAnalysisLink, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsAnalysisLinkDo) Attrs(attrs ...field.AssignExpr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsAnalysisLinkDo) Assign(attrs ...field.AssignExpr) *newsAnalysisLinkDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsAnalysisLinkDo) Joins(fields ...field.RelationField) *newsAnalysisLinkDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsAnalysisLinkDo) Preload(fields ...field.RelationField) *newsAnalysisLinkDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsAnalysisLinkDo) FirstOrInit() (*model.NewsAnalysisLink, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysisLink), nil
+	}
+}
+
+func (n newsAnalysisLinkDo) FirstOrCreate() (*model.NewsAnalysisLink, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsAnalysisLink), nil
+	}
+}
+
+func (n newsAnalysisLinkDo) FindByPage(offset int, limit int) (result []*model.NewsAnalysisLink, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsAnalysisLinkDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsAnalysisLinkDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsAnalysisLinkDo) Delete(models ...*model.NewsAnalysisLink) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsAnalysisLinkDo) withDO(do gen.Dao) *newsAnalysisLinkDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
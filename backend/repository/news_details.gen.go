@@ -41,6 +41,9 @@ func newNewsDetail(db *gorm.DB, opts ...gen.DOOption) newsDetail {
 	_newsDetail.Scraped = field.NewBool(tableName, "scraped")
 	_newsDetail.Favorited = field.NewBool(tableName, "favorited")
 	_newsDetail.CreatedAt = field.NewTime(tableName, "created_at")
+	_newsDetail.ScrapeAttempts = field.NewInt(tableName, "scrape_attempts")
+	_newsDetail.NextScrapeAt = field.NewTime(tableName, "next_scrape_at")
+	_newsDetail.EntityExtracted = field.NewBool(tableName, "entity_extracted")
 
 	_newsDetail.fillFieldMap()
 
@@ -50,21 +53,24 @@ func newNewsDetail(db *gorm.DB, opts ...gen.DOOption) newsDetail {
 type newsDetail struct {
 	newsDetailDo newsDetailDo
 
-	ALL         field.Asterisk
-	ID          field.Uint
-	RecordId    field.Uint
-	Source      field.String
-	Topic       field.String
-	Title       field.String
-	Author      field.String
-	PublishedAt field.Time
-	Link        field.String
-	Contents    field.String
-	Images      field.String
-	Video       field.String
-	Scraped     field.Bool
-	Favorited   field.Bool
-	CreatedAt   field.Time
+	ALL             field.Asterisk
+	ID              field.Uint
+	RecordId        field.Uint
+	Source          field.String
+	Topic           field.String
+	Title           field.String
+	Author          field.String
+	PublishedAt     field.Time
+	Link            field.String
+	Contents        field.String
+	Images          field.String
+	Video           field.String
+	Scraped         field.Bool
+	Favorited       field.Bool
+	CreatedAt       field.Time
+	ScrapeAttempts  field.Int
+	NextScrapeAt    field.Time
+	EntityExtracted field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -95,6 +101,9 @@ func (n *newsDetail) updateTableName(table string) *newsDetail {
 	n.Scraped = field.NewBool(table, "scraped")
 	n.Favorited = field.NewBool(table, "favorited")
 	n.CreatedAt = field.NewTime(table, "created_at")
+	n.ScrapeAttempts = field.NewInt(table, "scrape_attempts")
+	n.NextScrapeAt = field.NewTime(table, "next_scrape_at")
+	n.EntityExtracted = field.NewBool(table, "entity_extracted")
 
 	n.fillFieldMap()
 
@@ -121,7 +130,7 @@ func (n *newsDetail) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (n *newsDetail) fillFieldMap() {
-	n.fieldMap = make(map[string]field.Expr, 14)
+	n.fieldMap = make(map[string]field.Expr, 17)
 	n.fieldMap["id"] = n.ID
 	n.fieldMap["record_id"] = n.RecordId
 	n.fieldMap["source"] = n.Source
@@ -136,6 +145,9 @@ func (n *newsDetail) fillFieldMap() {
 	n.fieldMap["scraped"] = n.Scraped
 	n.fieldMap["favorited"] = n.Favorited
 	n.fieldMap["created_at"] = n.CreatedAt
+	n.fieldMap["scrape_attempts"] = n.ScrapeAttempts
+	n.fieldMap["next_scrape_at"] = n.NextScrapeAt
+	n.fieldMap["entity_extracted"] = n.EntityExtracted
 }
 
 func (n newsDetail) clone(db *gorm.DB) newsDetail {
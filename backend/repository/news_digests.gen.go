@@ -0,0 +1,361 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsDigest(db *gorm.DB, opts ...gen.DOOption) newsDigest {
+	_newsDigest := newsDigest{}
+
+	_newsDigest.newsDigestDo.UseDB(db, opts...)
+	_newsDigest.newsDigestDo.UseModel(&model.NewsDigest{})
+
+	tableName := _newsDigest.newsDigestDo.TableName()
+	_newsDigest.ALL = field.NewAsterisk(tableName)
+	_newsDigest.ID = field.NewUint(tableName, "id")
+	_newsDigest.Date = field.NewString(tableName, "date")
+	_newsDigest.Title = field.NewString(tableName, "title")
+	_newsDigest.Language = field.NewString(tableName, "language")
+	_newsDigest.Status = field.NewString(tableName, "status")
+	_newsDigest.Sections = field.NewString(tableName, "sections")
+	_newsDigest.Reason = field.NewString(tableName, "reason")
+	_newsDigest.CreatedAt = field.NewTime(tableName, "created_at")
+	_newsDigest.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_newsDigest.fillFieldMap()
+
+	return _newsDigest
+}
+
+type newsDigest struct {
+	newsDigestDo newsDigestDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	Date      field.String
+	Title     field.String
+	Language  field.String
+	Status    field.String
+	Sections  field.String
+	Reason    field.String
+	CreatedAt field.Time
+	UpdatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsDigest) Table(newTableName string) *newsDigest {
+	n.newsDigestDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsDigest) As(alias string) *newsDigest {
+	n.newsDigestDo.DO = *(n.newsDigestDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsDigest) updateTableName(table string) *newsDigest {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.Date = field.NewString(table, "date")
+	n.Title = field.NewString(table, "title")
+	n.Language = field.NewString(table, "language")
+	n.Status = field.NewString(table, "status")
+	n.Sections = field.NewString(table, "sections")
+	n.Reason = field.NewString(table, "reason")
+	n.CreatedAt = field.NewTime(table, "created_at")
+	n.UpdatedAt = field.NewTime(table, "updated_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsDigest) WithContext(ctx context.Context) *newsDigestDo {
+	return n.newsDigestDo.WithContext(ctx)
+}
+
+func (n newsDigest) TableName() string { return n.newsDigestDo.TableName() }
+
+func (n newsDigest) Alias() string { return n.newsDigestDo.Alias() }
+
+func (n newsDigest) Columns(cols ...field.Expr) gen.Columns { return n.newsDigestDo.Columns(cols...) }
+
+func (n *newsDigest) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsDigest) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 9)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["date"] = n.Date
+	n.fieldMap["title"] = n.Title
+	n.fieldMap["language"] = n.Language
+	n.fieldMap["status"] = n.Status
+	n.fieldMap["sections"] = n.Sections
+	n.fieldMap["reason"] = n.Reason
+	n.fieldMap["created_at"] = n.CreatedAt
+	n.fieldMap["updated_at"] = n.UpdatedAt
+}
+
+func (n newsDigest) clone(db *gorm.DB) newsDigest {
+	n.newsDigestDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsDigest) replaceDB(db *gorm.DB) newsDigest {
+	n.newsDigestDo.ReplaceDB(db)
+	return n
+}
+
+type newsDigestDo struct{ gen.DO }
+
+func (n newsDigestDo) Debug() *newsDigestDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsDigestDo) WithContext(ctx context.Context) *newsDigestDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsDigestDo) ReadDB() *newsDigestDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsDigestDo) WriteDB() *newsDigestDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsDigestDo) Session(config *gorm.Session) *newsDigestDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsDigestDo) Clauses(conds ...clause.Expression) *newsDigestDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsDigestDo) Returning(value interface{}, columns ...string) *newsDigestDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsDigestDo) Not(conds ...gen.Condition) *newsDigestDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsDigestDo) Or(conds ...gen.Condition) *newsDigestDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsDigestDo) Select(conds ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsDigestDo) Where(conds ...gen.Condition) *newsDigestDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsDigestDo) Order(conds ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsDigestDo) Distinct(cols ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsDigestDo) Omit(cols ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsDigestDo) Join(table schema.Tabler, on ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsDigestDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsDigestDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsDigestDo) Group(cols ...field.Expr) *newsDigestDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsDigestDo) Having(conds ...gen.Condition) *newsDigestDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsDigestDo) Limit(limit int) *newsDigestDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsDigestDo) Offset(offset int) *newsDigestDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsDigestDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsDigestDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsDigestDo) Unscoped() *newsDigestDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsDigestDo) Create(values ...*model.NewsDigest) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsDigestDo) CreateInBatches(values []*model.NewsDigest, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsDigestDo) Save(values ...*model.NewsDigest) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsDigestDo) First() (*model.NewsDigest, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsDigest), nil
+	}
+}
+
+func (n newsDigestDo) Take() (*model.NewsDigest, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsDigest), nil
+	}
+}
+
+func (n newsDigestDo) Last() (*model.NewsDigest, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsDigest), nil
+	}
+}
+
+func (n newsDigestDo) Find() ([]*model.NewsDigest, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsDigest), err
+}
+
+func (n newsDigestDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsDigest, err error) {
+	buf := make([]*model.NewsDigest, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsDigestDo) FindInBatches(result *[]*model.NewsDigest, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsDigestDo) Attrs(attrs ...field.AssignExpr) *newsDigestDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsDigestDo) Assign(attrs ...field.AssignExpr) *newsDigestDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsDigestDo) Joins(fields ...field.RelationField) *newsDigestDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsDigestDo) Preload(fields ...field.RelationField) *newsDigestDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsDigestDo) FirstOrInit() (*model.NewsDigest, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsDigest), nil
+	}
+}
+
+func (n newsDigestDo) FirstOrCreate() (*model.NewsDigest, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsDigest), nil
+	}
+}
+
+func (n newsDigestDo) FindByPage(offset int, limit int) (result []*model.NewsDigest, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsDigestDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsDigestDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsDigestDo) Delete(models ...*model.NewsDigest) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsDigestDo) withDO(do gen.Dao) *newsDigestDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
@@ -0,0 +1,355 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsEmbedding(db *gorm.DB, opts ...gen.DOOption) newsEmbedding {
+	_newsEmbedding := newsEmbedding{}
+
+	_newsEmbedding.newsEmbeddingDo.UseDB(db, opts...)
+	_newsEmbedding.newsEmbeddingDo.UseModel(&model.NewsEmbedding{})
+
+	tableName := _newsEmbedding.newsEmbeddingDo.TableName()
+	_newsEmbedding.ALL = field.NewAsterisk(tableName)
+	_newsEmbedding.ID = field.NewUint(tableName, "id")
+	_newsEmbedding.NewsId = field.NewUint(tableName, "news_id")
+	_newsEmbedding.Model = field.NewString(tableName, "model")
+	_newsEmbedding.Dimensions = field.NewInt(tableName, "dimensions")
+	_newsEmbedding.Vector = field.NewBytes(tableName, "vector")
+	_newsEmbedding.CreatedAt = field.NewTime(tableName, "created_at")
+	_newsEmbedding.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_newsEmbedding.fillFieldMap()
+
+	return _newsEmbedding
+}
+
+type newsEmbedding struct {
+	newsEmbeddingDo newsEmbeddingDo
+
+	ALL        field.Asterisk
+	ID         field.Uint
+	NewsId     field.Uint
+	Model      field.String
+	Dimensions field.Int
+	Vector     field.Bytes
+	CreatedAt  field.Time
+	UpdatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsEmbedding) Table(newTableName string) *newsEmbedding {
+	n.newsEmbeddingDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsEmbedding) As(alias string) *newsEmbedding {
+	n.newsEmbeddingDo.DO = *(n.newsEmbeddingDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsEmbedding) updateTableName(table string) *newsEmbedding {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.NewsId = field.NewUint(table, "news_id")
+	n.Model = field.NewString(table, "model")
+	n.Dimensions = field.NewInt(table, "dimensions")
+	n.Vector = field.NewBytes(table, "vector")
+	n.CreatedAt = field.NewTime(table, "created_at")
+	n.UpdatedAt = field.NewTime(table, "updated_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsEmbedding) WithContext(ctx context.Context) *newsEmbeddingDo {
+	return n.newsEmbeddingDo.WithContext(ctx)
+}
+
+func (n newsEmbedding) TableName() string { return n.newsEmbeddingDo.TableName() }
+
+func (n newsEmbedding) Alias() string { return n.newsEmbeddingDo.Alias() }
+
+func (n newsEmbedding) Columns(cols ...field.Expr) gen.Columns {
+	return n.newsEmbeddingDo.Columns(cols...)
+}
+
+func (n *newsEmbedding) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsEmbedding) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 7)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["news_id"] = n.NewsId
+	n.fieldMap["model"] = n.Model
+	n.fieldMap["dimensions"] = n.Dimensions
+	n.fieldMap["vector"] = n.Vector
+	n.fieldMap["created_at"] = n.CreatedAt
+	n.fieldMap["updated_at"] = n.UpdatedAt
+}
+
+func (n newsEmbedding) clone(db *gorm.DB) newsEmbedding {
+	n.newsEmbeddingDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsEmbedding) replaceDB(db *gorm.DB) newsEmbedding {
+	n.newsEmbeddingDo.ReplaceDB(db)
+	return n
+}
+
+type newsEmbeddingDo struct{ gen.DO }
+
+func (n newsEmbeddingDo) Debug() *newsEmbeddingDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsEmbeddingDo) WithContext(ctx context.Context) *newsEmbeddingDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsEmbeddingDo) ReadDB() *newsEmbeddingDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsEmbeddingDo) WriteDB() *newsEmbeddingDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsEmbeddingDo) Session(config *gorm.Session) *newsEmbeddingDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsEmbeddingDo) Clauses(conds ...clause.Expression) *newsEmbeddingDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsEmbeddingDo) Returning(value interface{}, columns ...string) *newsEmbeddingDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsEmbeddingDo) Not(conds ...gen.Condition) *newsEmbeddingDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsEmbeddingDo) Or(conds ...gen.Condition) *newsEmbeddingDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsEmbeddingDo) Select(conds ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsEmbeddingDo) Where(conds ...gen.Condition) *newsEmbeddingDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsEmbeddingDo) Order(conds ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsEmbeddingDo) Distinct(cols ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsEmbeddingDo) Omit(cols ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsEmbeddingDo) Join(table schema.Tabler, on ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsEmbeddingDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsEmbeddingDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsEmbeddingDo) Group(cols ...field.Expr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsEmbeddingDo) Having(conds ...gen.Condition) *newsEmbeddingDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsEmbeddingDo) Limit(limit int) *newsEmbeddingDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsEmbeddingDo) Offset(offset int) *newsEmbeddingDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsEmbeddingDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsEmbeddingDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsEmbeddingDo) Unscoped() *newsEmbeddingDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsEmbeddingDo) Create(values ...*model.NewsEmbedding) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsEmbeddingDo) CreateInBatches(values []*model.NewsEmbedding, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsEmbeddingDo) Save(values ...*model.NewsEmbedding) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsEmbeddingDo) First() (*model.NewsEmbedding, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEmbedding), nil
+	}
+}
+
+func (n newsEmbeddingDo) Take() (*model.NewsEmbedding, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEmbedding), nil
+	}
+}
+
+func (n newsEmbeddingDo) Last() (*model.NewsEmbedding, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEmbedding), nil
+	}
+}
+
+func (n newsEmbeddingDo) Find() ([]*model.NewsEmbedding, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsEmbedding), err
+}
+
+func (n newsEmbeddingDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsEmbedding, err error) {
+	buf := make([]*model.NewsEmbedding, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsEmbeddingDo) FindInBatches(result *[]*model.NewsEmbedding, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsEmbeddingDo) Attrs(attrs ...field.AssignExpr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsEmbeddingDo) Assign(attrs ...field.AssignExpr) *newsEmbeddingDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsEmbeddingDo) Joins(fields ...field.RelationField) *newsEmbeddingDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsEmbeddingDo) Preload(fields ...field.RelationField) *newsEmbeddingDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsEmbeddingDo) FirstOrInit() (*model.NewsEmbedding, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEmbedding), nil
+	}
+}
+
+func (n newsEmbeddingDo) FirstOrCreate() (*model.NewsEmbedding, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEmbedding), nil
+	}
+}
+
+func (n newsEmbeddingDo) FindByPage(offset int, limit int) (result []*model.NewsEmbedding, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsEmbeddingDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsEmbeddingDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsEmbeddingDo) Delete(models ...*model.NewsEmbedding) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsEmbeddingDo) withDO(do gen.Dao) *newsEmbeddingDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
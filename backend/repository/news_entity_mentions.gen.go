@@ -0,0 +1,347 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsEntityMention(db *gorm.DB, opts ...gen.DOOption) newsEntityMention {
+	_newsEntityMention := newsEntityMention{}
+
+	_newsEntityMention.newsEntityMentionDo.UseDB(db, opts...)
+	_newsEntityMention.newsEntityMentionDo.UseModel(&model.NewsEntityMention{})
+
+	tableName := _newsEntityMention.newsEntityMentionDo.TableName()
+	_newsEntityMention.ALL = field.NewAsterisk(tableName)
+	_newsEntityMention.ID = field.NewUint(tableName, "id")
+	_newsEntityMention.NewsId = field.NewUint(tableName, "news_id")
+	_newsEntityMention.EntityId = field.NewUint(tableName, "entity_id")
+	_newsEntityMention.Count = field.NewInt(tableName, "count")
+	_newsEntityMention.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_newsEntityMention.fillFieldMap()
+
+	return _newsEntityMention
+}
+
+type newsEntityMention struct {
+	newsEntityMentionDo newsEntityMentionDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	NewsId    field.Uint
+	EntityId  field.Uint
+	Count     field.Int
+	CreatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsEntityMention) Table(newTableName string) *newsEntityMention {
+	n.newsEntityMentionDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsEntityMention) As(alias string) *newsEntityMention {
+	n.newsEntityMentionDo.DO = *(n.newsEntityMentionDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsEntityMention) updateTableName(table string) *newsEntityMention {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.NewsId = field.NewUint(table, "news_id")
+	n.EntityId = field.NewUint(table, "entity_id")
+	n.Count = field.NewInt(table, "count")
+	n.CreatedAt = field.NewTime(table, "created_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsEntityMention) WithContext(ctx context.Context) *newsEntityMentionDo {
+	return n.newsEntityMentionDo.WithContext(ctx)
+}
+
+func (n newsEntityMention) TableName() string { return n.newsEntityMentionDo.TableName() }
+
+func (n newsEntityMention) Alias() string { return n.newsEntityMentionDo.Alias() }
+
+func (n newsEntityMention) Columns(cols ...field.Expr) gen.Columns {
+	return n.newsEntityMentionDo.Columns(cols...)
+}
+
+func (n *newsEntityMention) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsEntityMention) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 5)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["news_id"] = n.NewsId
+	n.fieldMap["entity_id"] = n.EntityId
+	n.fieldMap["count"] = n.Count
+	n.fieldMap["created_at"] = n.CreatedAt
+}
+
+func (n newsEntityMention) clone(db *gorm.DB) newsEntityMention {
+	n.newsEntityMentionDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsEntityMention) replaceDB(db *gorm.DB) newsEntityMention {
+	n.newsEntityMentionDo.ReplaceDB(db)
+	return n
+}
+
+type newsEntityMentionDo struct{ gen.DO }
+
+func (n newsEntityMentionDo) Debug() *newsEntityMentionDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsEntityMentionDo) WithContext(ctx context.Context) *newsEntityMentionDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsEntityMentionDo) ReadDB() *newsEntityMentionDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsEntityMentionDo) WriteDB() *newsEntityMentionDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsEntityMentionDo) Session(config *gorm.Session) *newsEntityMentionDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsEntityMentionDo) Clauses(conds ...clause.Expression) *newsEntityMentionDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsEntityMentionDo) Returning(value interface{}, columns ...string) *newsEntityMentionDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsEntityMentionDo) Not(conds ...gen.Condition) *newsEntityMentionDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsEntityMentionDo) Or(conds ...gen.Condition) *newsEntityMentionDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsEntityMentionDo) Select(conds ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsEntityMentionDo) Where(conds ...gen.Condition) *newsEntityMentionDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsEntityMentionDo) Order(conds ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsEntityMentionDo) Distinct(cols ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsEntityMentionDo) Omit(cols ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsEntityMentionDo) Join(table schema.Tabler, on ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsEntityMentionDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsEntityMentionDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsEntityMentionDo) Group(cols ...field.Expr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsEntityMentionDo) Having(conds ...gen.Condition) *newsEntityMentionDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsEntityMentionDo) Limit(limit int) *newsEntityMentionDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsEntityMentionDo) Offset(offset int) *newsEntityMentionDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsEntityMentionDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsEntityMentionDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsEntityMentionDo) Unscoped() *newsEntityMentionDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsEntityMentionDo) Create(values ...*model.NewsEntityMention) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsEntityMentionDo) CreateInBatches(values []*model.NewsEntityMention, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsEntityMentionDo) Save(values ...*model.NewsEntityMention) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsEntityMentionDo) First() (*model.NewsEntityMention, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEntityMention), nil
+	}
+}
+
+func (n newsEntityMentionDo) Take() (*model.NewsEntityMention, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEntityMention), nil
+	}
+}
+
+func (n newsEntityMentionDo) Last() (*model.NewsEntityMention, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEntityMention), nil
+	}
+}
+
+func (n newsEntityMentionDo) Find() ([]*model.NewsEntityMention, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsEntityMention), err
+}
+
+func (n newsEntityMentionDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsEntityMention, err error) {
+	buf := make([]*model.NewsEntityMention, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsEntityMentionDo) FindInBatches(result *[]*model.NewsEntityMention, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsEntityMentionDo) Attrs(attrs ...field.AssignExpr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsEntityMentionDo) Assign(attrs ...field.AssignExpr) *newsEntityMentionDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsEntityMentionDo) Joins(fields ...field.RelationField) *newsEntityMentionDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsEntityMentionDo) Preload(fields ...field.RelationField) *newsEntityMentionDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsEntityMentionDo) FirstOrInit() (*model.NewsEntityMention, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEntityMention), nil
+	}
+}
+
+func (n newsEntityMentionDo) FirstOrCreate() (*model.NewsEntityMention, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsEntityMention), nil
+	}
+}
+
+func (n newsEntityMentionDo) FindByPage(offset int, limit int) (result []*model.NewsEntityMention, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsEntityMentionDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsEntityMentionDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsEntityMentionDo) Delete(models ...*model.NewsEntityMention) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsEntityMentionDo) withDO(do gen.Dao) *newsEntityMentionDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
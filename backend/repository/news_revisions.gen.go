@@ -0,0 +1,355 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsRevision(db *gorm.DB, opts ...gen.DOOption) newsRevision {
+	_newsRevision := newsRevision{}
+
+	_newsRevision.newsRevisionDo.UseDB(db, opts...)
+	_newsRevision.newsRevisionDo.UseModel(&model.NewsRevision{})
+
+	tableName := _newsRevision.newsRevisionDo.TableName()
+	_newsRevision.ALL = field.NewAsterisk(tableName)
+	_newsRevision.ID = field.NewUint(tableName, "id")
+	_newsRevision.NewsId = field.NewUint(tableName, "news_id")
+	_newsRevision.Revision = field.NewInt(tableName, "revision")
+	_newsRevision.Title = field.NewString(tableName, "title")
+	_newsRevision.Author = field.NewString(tableName, "author")
+	_newsRevision.Contents = field.NewString(tableName, "contents")
+	_newsRevision.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_newsRevision.fillFieldMap()
+
+	return _newsRevision
+}
+
+type newsRevision struct {
+	newsRevisionDo newsRevisionDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	NewsId    field.Uint
+	Revision  field.Int
+	Title     field.String
+	Author    field.String
+	Contents  field.String
+	CreatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsRevision) Table(newTableName string) *newsRevision {
+	n.newsRevisionDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsRevision) As(alias string) *newsRevision {
+	n.newsRevisionDo.DO = *(n.newsRevisionDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsRevision) updateTableName(table string) *newsRevision {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.NewsId = field.NewUint(table, "news_id")
+	n.Revision = field.NewInt(table, "revision")
+	n.Title = field.NewString(table, "title")
+	n.Author = field.NewString(table, "author")
+	n.Contents = field.NewString(table, "contents")
+	n.CreatedAt = field.NewTime(table, "created_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsRevision) WithContext(ctx context.Context) *newsRevisionDo {
+	return n.newsRevisionDo.WithContext(ctx)
+}
+
+func (n newsRevision) TableName() string { return n.newsRevisionDo.TableName() }
+
+func (n newsRevision) Alias() string { return n.newsRevisionDo.Alias() }
+
+func (n newsRevision) Columns(cols ...field.Expr) gen.Columns {
+	return n.newsRevisionDo.Columns(cols...)
+}
+
+func (n *newsRevision) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsRevision) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 7)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["news_id"] = n.NewsId
+	n.fieldMap["revision"] = n.Revision
+	n.fieldMap["title"] = n.Title
+	n.fieldMap["author"] = n.Author
+	n.fieldMap["contents"] = n.Contents
+	n.fieldMap["created_at"] = n.CreatedAt
+}
+
+func (n newsRevision) clone(db *gorm.DB) newsRevision {
+	n.newsRevisionDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsRevision) replaceDB(db *gorm.DB) newsRevision {
+	n.newsRevisionDo.ReplaceDB(db)
+	return n
+}
+
+type newsRevisionDo struct{ gen.DO }
+
+func (n newsRevisionDo) Debug() *newsRevisionDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsRevisionDo) WithContext(ctx context.Context) *newsRevisionDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsRevisionDo) ReadDB() *newsRevisionDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsRevisionDo) WriteDB() *newsRevisionDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsRevisionDo) Session(config *gorm.Session) *newsRevisionDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsRevisionDo) Clauses(conds ...clause.Expression) *newsRevisionDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsRevisionDo) Returning(value interface{}, columns ...string) *newsRevisionDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsRevisionDo) Not(conds ...gen.Condition) *newsRevisionDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsRevisionDo) Or(conds ...gen.Condition) *newsRevisionDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsRevisionDo) Select(conds ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsRevisionDo) Where(conds ...gen.Condition) *newsRevisionDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsRevisionDo) Order(conds ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsRevisionDo) Distinct(cols ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsRevisionDo) Omit(cols ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsRevisionDo) Join(table schema.Tabler, on ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsRevisionDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsRevisionDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsRevisionDo) Group(cols ...field.Expr) *newsRevisionDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsRevisionDo) Having(conds ...gen.Condition) *newsRevisionDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsRevisionDo) Limit(limit int) *newsRevisionDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsRevisionDo) Offset(offset int) *newsRevisionDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsRevisionDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsRevisionDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsRevisionDo) Unscoped() *newsRevisionDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsRevisionDo) Create(values ...*model.NewsRevision) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsRevisionDo) CreateInBatches(values []*model.NewsRevision, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsRevisionDo) Save(values ...*model.NewsRevision) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsRevisionDo) First() (*model.NewsRevision, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsRevision), nil
+	}
+}
+
+func (n newsRevisionDo) Take() (*model.NewsRevision, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsRevision), nil
+	}
+}
+
+func (n newsRevisionDo) Last() (*model.NewsRevision, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsRevision), nil
+	}
+}
+
+func (n newsRevisionDo) Find() ([]*model.NewsRevision, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsRevision), err
+}
+
+func (n newsRevisionDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsRevision, err error) {
+	buf := make([]*model.NewsRevision, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsRevisionDo) FindInBatches(result *[]*model.NewsRevision, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsRevisionDo) Attrs(attrs ...field.AssignExpr) *newsRevisionDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsRevisionDo) Assign(attrs ...field.AssignExpr) *newsRevisionDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsRevisionDo) Joins(fields ...field.RelationField) *newsRevisionDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsRevisionDo) Preload(fields ...field.RelationField) *newsRevisionDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsRevisionDo) FirstOrInit() (*model.NewsRevision, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsRevision), nil
+	}
+}
+
+func (n newsRevisionDo) FirstOrCreate() (*model.NewsRevision, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsRevision), nil
+	}
+}
+
+func (n newsRevisionDo) FindByPage(offset int, limit int) (result []*model.NewsRevision, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsRevisionDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsRevisionDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsRevisionDo) Delete(models ...*model.NewsRevision) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsRevisionDo) withDO(do gen.Dao) *newsRevisionDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
@@ -0,0 +1,353 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newNewsSummary(db *gorm.DB, opts ...gen.DOOption) newsSummary {
+	_newsSummary := newsSummary{}
+
+	_newsSummary.newsSummaryDo.UseDB(db, opts...)
+	_newsSummary.newsSummaryDo.UseModel(&model.NewsSummary{})
+
+	tableName := _newsSummary.newsSummaryDo.TableName()
+	_newsSummary.ALL = field.NewAsterisk(tableName)
+	_newsSummary.ID = field.NewUint(tableName, "id")
+	_newsSummary.NewsId = field.NewUint(tableName, "news_id")
+	_newsSummary.Language = field.NewString(tableName, "language")
+	_newsSummary.Short = field.NewString(tableName, "short")
+	_newsSummary.Long = field.NewString(tableName, "long")
+	_newsSummary.CreatedAt = field.NewTime(tableName, "created_at")
+	_newsSummary.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_newsSummary.fillFieldMap()
+
+	return _newsSummary
+}
+
+type newsSummary struct {
+	newsSummaryDo newsSummaryDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	NewsId    field.Uint
+	Language  field.String
+	Short     field.String
+	Long      field.String
+	CreatedAt field.Time
+	UpdatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (n newsSummary) Table(newTableName string) *newsSummary {
+	n.newsSummaryDo.UseTable(newTableName)
+	return n.updateTableName(newTableName)
+}
+
+func (n newsSummary) As(alias string) *newsSummary {
+	n.newsSummaryDo.DO = *(n.newsSummaryDo.As(alias).(*gen.DO))
+	return n.updateTableName(alias)
+}
+
+func (n *newsSummary) updateTableName(table string) *newsSummary {
+	n.ALL = field.NewAsterisk(table)
+	n.ID = field.NewUint(table, "id")
+	n.NewsId = field.NewUint(table, "news_id")
+	n.Language = field.NewString(table, "language")
+	n.Short = field.NewString(table, "short")
+	n.Long = field.NewString(table, "long")
+	n.CreatedAt = field.NewTime(table, "created_at")
+	n.UpdatedAt = field.NewTime(table, "updated_at")
+
+	n.fillFieldMap()
+
+	return n
+}
+
+func (n *newsSummary) WithContext(ctx context.Context) *newsSummaryDo {
+	return n.newsSummaryDo.WithContext(ctx)
+}
+
+func (n newsSummary) TableName() string { return n.newsSummaryDo.TableName() }
+
+func (n newsSummary) Alias() string { return n.newsSummaryDo.Alias() }
+
+func (n newsSummary) Columns(cols ...field.Expr) gen.Columns { return n.newsSummaryDo.Columns(cols...) }
+
+func (n *newsSummary) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := n.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (n *newsSummary) fillFieldMap() {
+	n.fieldMap = make(map[string]field.Expr, 7)
+	n.fieldMap["id"] = n.ID
+	n.fieldMap["news_id"] = n.NewsId
+	n.fieldMap["language"] = n.Language
+	n.fieldMap["short"] = n.Short
+	n.fieldMap["long"] = n.Long
+	n.fieldMap["created_at"] = n.CreatedAt
+	n.fieldMap["updated_at"] = n.UpdatedAt
+}
+
+func (n newsSummary) clone(db *gorm.DB) newsSummary {
+	n.newsSummaryDo.ReplaceConnPool(db.Statement.ConnPool)
+	return n
+}
+
+func (n newsSummary) replaceDB(db *gorm.DB) newsSummary {
+	n.newsSummaryDo.ReplaceDB(db)
+	return n
+}
+
+type newsSummaryDo struct{ gen.DO }
+
+func (n newsSummaryDo) Debug() *newsSummaryDo {
+	return n.withDO(n.DO.Debug())
+}
+
+func (n newsSummaryDo) WithContext(ctx context.Context) *newsSummaryDo {
+	return n.withDO(n.DO.WithContext(ctx))
+}
+
+func (n newsSummaryDo) ReadDB() *newsSummaryDo {
+	return n.Clauses(dbresolver.Read)
+}
+
+func (n newsSummaryDo) WriteDB() *newsSummaryDo {
+	return n.Clauses(dbresolver.Write)
+}
+
+func (n newsSummaryDo) Session(config *gorm.Session) *newsSummaryDo {
+	return n.withDO(n.DO.Session(config))
+}
+
+func (n newsSummaryDo) Clauses(conds ...clause.Expression) *newsSummaryDo {
+	return n.withDO(n.DO.Clauses(conds...))
+}
+
+func (n newsSummaryDo) Returning(value interface{}, columns ...string) *newsSummaryDo {
+	return n.withDO(n.DO.Returning(value, columns...))
+}
+
+func (n newsSummaryDo) Not(conds ...gen.Condition) *newsSummaryDo {
+	return n.withDO(n.DO.Not(conds...))
+}
+
+func (n newsSummaryDo) Or(conds ...gen.Condition) *newsSummaryDo {
+	return n.withDO(n.DO.Or(conds...))
+}
+
+func (n newsSummaryDo) Select(conds ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Select(conds...))
+}
+
+func (n newsSummaryDo) Where(conds ...gen.Condition) *newsSummaryDo {
+	return n.withDO(n.DO.Where(conds...))
+}
+
+func (n newsSummaryDo) Order(conds ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Order(conds...))
+}
+
+func (n newsSummaryDo) Distinct(cols ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Distinct(cols...))
+}
+
+func (n newsSummaryDo) Omit(cols ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Omit(cols...))
+}
+
+func (n newsSummaryDo) Join(table schema.Tabler, on ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Join(table, on...))
+}
+
+func (n newsSummaryDo) LeftJoin(table schema.Tabler, on ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.LeftJoin(table, on...))
+}
+
+func (n newsSummaryDo) RightJoin(table schema.Tabler, on ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.RightJoin(table, on...))
+}
+
+func (n newsSummaryDo) Group(cols ...field.Expr) *newsSummaryDo {
+	return n.withDO(n.DO.Group(cols...))
+}
+
+func (n newsSummaryDo) Having(conds ...gen.Condition) *newsSummaryDo {
+	return n.withDO(n.DO.Having(conds...))
+}
+
+func (n newsSummaryDo) Limit(limit int) *newsSummaryDo {
+	return n.withDO(n.DO.Limit(limit))
+}
+
+func (n newsSummaryDo) Offset(offset int) *newsSummaryDo {
+	return n.withDO(n.DO.Offset(offset))
+}
+
+func (n newsSummaryDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *newsSummaryDo {
+	return n.withDO(n.DO.Scopes(funcs...))
+}
+
+func (n newsSummaryDo) Unscoped() *newsSummaryDo {
+	return n.withDO(n.DO.Unscoped())
+}
+
+func (n newsSummaryDo) Create(values ...*model.NewsSummary) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Create(values)
+}
+
+func (n newsSummaryDo) CreateInBatches(values []*model.NewsSummary, batchSize int) error {
+	return n.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (n newsSummaryDo) Save(values ...*model.NewsSummary) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return n.DO.Save(values)
+}
+
+func (n newsSummaryDo) First() (*model.NewsSummary, error) {
+	if result, err := n.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsSummary), nil
+	}
+}
+
+func (n newsSummaryDo) Take() (*model.NewsSummary, error) {
+	if result, err := n.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsSummary), nil
+	}
+}
+
+func (n newsSummaryDo) Last() (*model.NewsSummary, error) {
+	if result, err := n.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsSummary), nil
+	}
+}
+
+func (n newsSummaryDo) Find() ([]*model.NewsSummary, error) {
+	result, err := n.DO.Find()
+	return result.([]*model.NewsSummary), err
+}
+
+func (n newsSummaryDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.NewsSummary, err error) {
+	buf := make([]*model.NewsSummary, 0, batchSize)
+	err = n.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (n newsSummaryDo) FindInBatches(result *[]*model.NewsSummary, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return n.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (n newsSummaryDo) Attrs(attrs ...field.AssignExpr) *newsSummaryDo {
+	return n.withDO(n.DO.Attrs(attrs...))
+}
+
+func (n newsSummaryDo) Assign(attrs ...field.AssignExpr) *newsSummaryDo {
+	return n.withDO(n.DO.Assign(attrs...))
+}
+
+func (n newsSummaryDo) Joins(fields ...field.RelationField) *newsSummaryDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Joins(_f))
+	}
+	return &n
+}
+
+func (n newsSummaryDo) Preload(fields ...field.RelationField) *newsSummaryDo {
+	for _, _f := range fields {
+		n = *n.withDO(n.DO.Preload(_f))
+	}
+	return &n
+}
+
+func (n newsSummaryDo) FirstOrInit() (*model.NewsSummary, error) {
+	if result, err := n.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsSummary), nil
+	}
+}
+
+func (n newsSummaryDo) FirstOrCreate() (*model.NewsSummary, error) {
+	if result, err := n.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.NewsSummary), nil
+	}
+}
+
+func (n newsSummaryDo) FindByPage(offset int, limit int) (result []*model.NewsSummary, count int64, err error) {
+	result, err = n.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = n.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (n newsSummaryDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = n.Count()
+	if err != nil {
+		return
+	}
+
+	err = n.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (n newsSummaryDo) Scan(result interface{}) (err error) {
+	return n.DO.Scan(result)
+}
+
+func (n newsSummaryDo) Delete(models ...*model.NewsSummary) (result gen.ResultInfo, err error) {
+	return n.DO.Delete(models)
+}
+
+func (n *newsSummaryDo) withDO(do gen.Dao) *newsSummaryDo {
+	n.DO = *do.(*gen.DO)
+	return n
+}
@@ -0,0 +1,365 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newPodcastJob(db *gorm.DB, opts ...gen.DOOption) podcastJob {
+	_podcastJob := podcastJob{}
+
+	_podcastJob.podcastJobDo.UseDB(db, opts...)
+	_podcastJob.podcastJobDo.UseModel(&model.PodcastJob{})
+
+	tableName := _podcastJob.podcastJobDo.TableName()
+	_podcastJob.ALL = field.NewAsterisk(tableName)
+	_podcastJob.ID = field.NewUint(tableName, "id")
+	_podcastJob.Kind = field.NewString(tableName, "kind")
+	_podcastJob.BatchNo = field.NewString(tableName, "batch_no")
+	_podcastJob.Status = field.NewString(tableName, "status")
+	_podcastJob.Attempts = field.NewInt(tableName, "attempts")
+	_podcastJob.Worker = field.NewString(tableName, "worker")
+	_podcastJob.LeaseUntil = field.NewTime(tableName, "lease_until")
+	_podcastJob.Error = field.NewString(tableName, "error")
+	_podcastJob.CreatedAt = field.NewTime(tableName, "created_at")
+	_podcastJob.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_podcastJob.fillFieldMap()
+
+	return _podcastJob
+}
+
+type podcastJob struct {
+	podcastJobDo podcastJobDo
+
+	ALL        field.Asterisk
+	ID         field.Uint
+	Kind       field.String
+	BatchNo    field.String
+	Status     field.String
+	Attempts   field.Int
+	Worker     field.String
+	LeaseUntil field.Time
+	Error      field.String
+	CreatedAt  field.Time
+	UpdatedAt  field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (p podcastJob) Table(newTableName string) *podcastJob {
+	p.podcastJobDo.UseTable(newTableName)
+	return p.updateTableName(newTableName)
+}
+
+func (p podcastJob) As(alias string) *podcastJob {
+	p.podcastJobDo.DO = *(p.podcastJobDo.As(alias).(*gen.DO))
+	return p.updateTableName(alias)
+}
+
+func (p *podcastJob) updateTableName(table string) *podcastJob {
+	p.ALL = field.NewAsterisk(table)
+	p.ID = field.NewUint(table, "id")
+	p.Kind = field.NewString(table, "kind")
+	p.BatchNo = field.NewString(table, "batch_no")
+	p.Status = field.NewString(table, "status")
+	p.Attempts = field.NewInt(table, "attempts")
+	p.Worker = field.NewString(table, "worker")
+	p.LeaseUntil = field.NewTime(table, "lease_until")
+	p.Error = field.NewString(table, "error")
+	p.CreatedAt = field.NewTime(table, "created_at")
+	p.UpdatedAt = field.NewTime(table, "updated_at")
+
+	p.fillFieldMap()
+
+	return p
+}
+
+func (p *podcastJob) WithContext(ctx context.Context) *podcastJobDo {
+	return p.podcastJobDo.WithContext(ctx)
+}
+
+func (p podcastJob) TableName() string { return p.podcastJobDo.TableName() }
+
+func (p podcastJob) Alias() string { return p.podcastJobDo.Alias() }
+
+func (p podcastJob) Columns(cols ...field.Expr) gen.Columns { return p.podcastJobDo.Columns(cols...) }
+
+func (p *podcastJob) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := p.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (p *podcastJob) fillFieldMap() {
+	p.fieldMap = make(map[string]field.Expr, 10)
+	p.fieldMap["id"] = p.ID
+	p.fieldMap["kind"] = p.Kind
+	p.fieldMap["batch_no"] = p.BatchNo
+	p.fieldMap["status"] = p.Status
+	p.fieldMap["attempts"] = p.Attempts
+	p.fieldMap["worker"] = p.Worker
+	p.fieldMap["lease_until"] = p.LeaseUntil
+	p.fieldMap["error"] = p.Error
+	p.fieldMap["created_at"] = p.CreatedAt
+	p.fieldMap["updated_at"] = p.UpdatedAt
+}
+
+func (p podcastJob) clone(db *gorm.DB) podcastJob {
+	p.podcastJobDo.ReplaceConnPool(db.Statement.ConnPool)
+	return p
+}
+
+func (p podcastJob) replaceDB(db *gorm.DB) podcastJob {
+	p.podcastJobDo.ReplaceDB(db)
+	return p
+}
+
+type podcastJobDo struct{ gen.DO }
+
+func (p podcastJobDo) Debug() *podcastJobDo {
+	return p.withDO(p.DO.Debug())
+}
+
+func (p podcastJobDo) WithContext(ctx context.Context) *podcastJobDo {
+	return p.withDO(p.DO.WithContext(ctx))
+}
+
+func (p podcastJobDo) ReadDB() *podcastJobDo {
+	return p.Clauses(dbresolver.Read)
+}
+
+func (p podcastJobDo) WriteDB() *podcastJobDo {
+	return p.Clauses(dbresolver.Write)
+}
+
+func (p podcastJobDo) Session(config *gorm.Session) *podcastJobDo {
+	return p.withDO(p.DO.Session(config))
+}
+
+func (p podcastJobDo) Clauses(conds ...clause.Expression) *podcastJobDo {
+	return p.withDO(p.DO.Clauses(conds...))
+}
+
+func (p podcastJobDo) Returning(value interface{}, columns ...string) *podcastJobDo {
+	return p.withDO(p.DO.Returning(value, columns...))
+}
+
+func (p podcastJobDo) Not(conds ...gen.Condition) *podcastJobDo {
+	return p.withDO(p.DO.Not(conds...))
+}
+
+func (p podcastJobDo) Or(conds ...gen.Condition) *podcastJobDo {
+	return p.withDO(p.DO.Or(conds...))
+}
+
+func (p podcastJobDo) Select(conds ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Select(conds...))
+}
+
+func (p podcastJobDo) Where(conds ...gen.Condition) *podcastJobDo {
+	return p.withDO(p.DO.Where(conds...))
+}
+
+func (p podcastJobDo) Order(conds ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Order(conds...))
+}
+
+func (p podcastJobDo) Distinct(cols ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Distinct(cols...))
+}
+
+func (p podcastJobDo) Omit(cols ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Omit(cols...))
+}
+
+func (p podcastJobDo) Join(table schema.Tabler, on ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Join(table, on...))
+}
+
+func (p podcastJobDo) LeftJoin(table schema.Tabler, on ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.LeftJoin(table, on...))
+}
+
+func (p podcastJobDo) RightJoin(table schema.Tabler, on ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.RightJoin(table, on...))
+}
+
+func (p podcastJobDo) Group(cols ...field.Expr) *podcastJobDo {
+	return p.withDO(p.DO.Group(cols...))
+}
+
+func (p podcastJobDo) Having(conds ...gen.Condition) *podcastJobDo {
+	return p.withDO(p.DO.Having(conds...))
+}
+
+func (p podcastJobDo) Limit(limit int) *podcastJobDo {
+	return p.withDO(p.DO.Limit(limit))
+}
+
+func (p podcastJobDo) Offset(offset int) *podcastJobDo {
+	return p.withDO(p.DO.Offset(offset))
+}
+
+func (p podcastJobDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *podcastJobDo {
+	return p.withDO(p.DO.Scopes(funcs...))
+}
+
+func (p podcastJobDo) Unscoped() *podcastJobDo {
+	return p.withDO(p.DO.Unscoped())
+}
+
+func (p podcastJobDo) Create(values ...*model.PodcastJob) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return p.DO.Create(values)
+}
+
+func (p podcastJobDo) CreateInBatches(values []*model.PodcastJob, batchSize int) error {
+	return p.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (p podcastJobDo) Save(values ...*model.PodcastJob) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return p.DO.Save(values)
+}
+
+func (p podcastJobDo) First() (*model.PodcastJob, error) {
+	if result, err := p.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PodcastJob), nil
+	}
+}
+
+func (p podcastJobDo) Take() (*model.PodcastJob, error) {
+	if result, err := p.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PodcastJob), nil
+	}
+}
+
+func (p podcastJobDo) Last() (*model.PodcastJob, error) {
+	if result, err := p.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PodcastJob), nil
+	}
+}
+
+func (p podcastJobDo) Find() ([]*model.PodcastJob, error) {
+	result, err := p.DO.Find()
+	return result.([]*model.PodcastJob), err
+}
+
+func (p podcastJobDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.PodcastJob, err error) {
+	buf := make([]*model.PodcastJob, 0, batchSize)
+	err = p.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (p podcastJobDo) FindInBatches(result *[]*model.PodcastJob, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return p.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (p podcastJobDo) Attrs(attrs ...field.AssignExpr) *podcastJobDo {
+	return p.withDO(p.DO.Attrs(attrs...))
+}
+
+func (p podcastJobDo) Assign(attrs ...field.AssignExpr) *podcastJobDo {
+	return p.withDO(p.DO.Assign(attrs...))
+}
+
+func (p podcastJobDo) Joins(fields ...field.RelationField) *podcastJobDo {
+	for _, _f := range fields {
+		p = *p.withDO(p.DO.Joins(_f))
+	}
+	return &p
+}
+
+func (p podcastJobDo) Preload(fields ...field.RelationField) *podcastJobDo {
+	for _, _f := range fields {
+		p = *p.withDO(p.DO.Preload(_f))
+	}
+	return &p
+}
+
+func (p podcastJobDo) FirstOrInit() (*model.PodcastJob, error) {
+	if result, err := p.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PodcastJob), nil
+	}
+}
+
+func (p podcastJobDo) FirstOrCreate() (*model.PodcastJob, error) {
+	if result, err := p.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PodcastJob), nil
+	}
+}
+
+func (p podcastJobDo) FindByPage(offset int, limit int) (result []*model.PodcastJob, count int64, err error) {
+	result, err = p.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = p.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (p podcastJobDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = p.Count()
+	if err != nil {
+		return
+	}
+
+	err = p.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (p podcastJobDo) Scan(result interface{}) (err error) {
+	return p.DO.Scan(result)
+}
+
+func (p podcastJobDo) Delete(models ...*model.PodcastJob) (result gen.ResultInfo, err error) {
+	return p.DO.Delete(models)
+}
+
+func (p *podcastJobDo) withDO(do gen.Dao) *podcastJobDo {
+	p.DO = *do.(*gen.DO)
+	return p
+}
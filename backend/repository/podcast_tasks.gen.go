@@ -29,9 +29,11 @@ func newPodcastTask(db *gorm.DB, opts ...gen.DOOption) podcastTask {
 	_podcastTask.ALL = field.NewAsterisk(tableName)
 	_podcastTask.ID = field.NewUint(tableName, "id")
 	_podcastTask.BatchNo = field.NewString(tableName, "batch_no")
-	_podcastTask.NewsId = field.NewUint(tableName, "news_id")
 	_podcastTask.Stage = field.NewString(tableName, "stage")
+	_podcastTask.Title = field.NewString(tableName, "title")
+	_podcastTask.NewsId = field.NewUint(tableName, "news_id")
 	_podcastTask.Status = field.NewString(tableName, "status")
+	_podcastTask.Language = field.NewString(tableName, "language")
 	_podcastTask.Prompt = field.NewString(tableName, "prompt")
 	_podcastTask.Input = field.NewString(tableName, "input")
 	_podcastTask.Output = field.NewString(tableName, "output")
@@ -39,6 +41,11 @@ func newPodcastTask(db *gorm.DB, opts ...gen.DOOption) podcastTask {
 	_podcastTask.Result = field.NewString(tableName, "result")
 	_podcastTask.Audio = field.NewString(tableName, "audio")
 	_podcastTask.TaskAi = field.NewString(tableName, "task_ai")
+	_podcastTask.Extra = field.NewString(tableName, "extra")
+	_podcastTask.Approval = field.NewString(tableName, "approval")
+	_podcastTask.Classify = field.NewString(tableName, "classify")
+	_podcastTask.Attempts = field.NewInt(tableName, "attempts")
+	_podcastTask.Failures = field.NewString(tableName, "failures")
 	_podcastTask.CreatedAt = field.NewTime(tableName, "created_at")
 	_podcastTask.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -53,9 +60,11 @@ type podcastTask struct {
 	ALL       field.Asterisk
 	ID        field.Uint
 	BatchNo   field.String
-	NewsId    field.Uint
 	Stage     field.String
+	Title     field.String
+	NewsId    field.Uint
 	Status    field.String
+	Language  field.String
 	Prompt    field.String
 	Input     field.String
 	Output    field.String
@@ -63,6 +72,11 @@ type podcastTask struct {
 	Result    field.String
 	Audio     field.String
 	TaskAi    field.String
+	Extra     field.String
+	Approval  field.String
+	Classify  field.String
+	Attempts  field.Int
+	Failures  field.String
 	CreatedAt field.Time
 	UpdatedAt field.Time
 
@@ -83,9 +97,11 @@ func (p *podcastTask) updateTableName(table string) *podcastTask {
 	p.ALL = field.NewAsterisk(table)
 	p.ID = field.NewUint(table, "id")
 	p.BatchNo = field.NewString(table, "batch_no")
-	p.NewsId = field.NewUint(table, "news_id")
 	p.Stage = field.NewString(table, "stage")
+	p.Title = field.NewString(table, "title")
+	p.NewsId = field.NewUint(table, "news_id")
 	p.Status = field.NewString(table, "status")
+	p.Language = field.NewString(table, "language")
 	p.Prompt = field.NewString(table, "prompt")
 	p.Input = field.NewString(table, "input")
 	p.Output = field.NewString(table, "output")
@@ -93,6 +109,11 @@ func (p *podcastTask) updateTableName(table string) *podcastTask {
 	p.Result = field.NewString(table, "result")
 	p.Audio = field.NewString(table, "audio")
 	p.TaskAi = field.NewString(table, "task_ai")
+	p.Extra = field.NewString(table, "extra")
+	p.Approval = field.NewString(table, "approval")
+	p.Classify = field.NewString(table, "classify")
+	p.Attempts = field.NewInt(table, "attempts")
+	p.Failures = field.NewString(table, "failures")
 	p.CreatedAt = field.NewTime(table, "created_at")
 	p.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -121,12 +142,14 @@ func (p *podcastTask) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (p *podcastTask) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 14)
+	p.fieldMap = make(map[string]field.Expr, 21)
 	p.fieldMap["id"] = p.ID
 	p.fieldMap["batch_no"] = p.BatchNo
-	p.fieldMap["news_id"] = p.NewsId
 	p.fieldMap["stage"] = p.Stage
+	p.fieldMap["title"] = p.Title
+	p.fieldMap["news_id"] = p.NewsId
 	p.fieldMap["status"] = p.Status
+	p.fieldMap["language"] = p.Language
 	p.fieldMap["prompt"] = p.Prompt
 	p.fieldMap["input"] = p.Input
 	p.fieldMap["output"] = p.Output
@@ -134,6 +157,11 @@ func (p *podcastTask) fillFieldMap() {
 	p.fieldMap["result"] = p.Result
 	p.fieldMap["audio"] = p.Audio
 	p.fieldMap["task_ai"] = p.TaskAi
+	p.fieldMap["extra"] = p.Extra
+	p.fieldMap["approval"] = p.Approval
+	p.fieldMap["classify"] = p.Classify
+	p.fieldMap["attempts"] = p.Attempts
+	p.fieldMap["failures"] = p.Failures
 	p.fieldMap["created_at"] = p.CreatedAt
 	p.fieldMap["updated_at"] = p.UpdatedAt
 }
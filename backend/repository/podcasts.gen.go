@@ -28,12 +28,19 @@ func newPodcast(db *gorm.DB, opts ...gen.DOOption) podcast {
 	tableName := _podcast.podcastDo.TableName()
 	_podcast.ALL = field.NewAsterisk(tableName)
 	_podcast.ID = field.NewUint(tableName, "id")
+	_podcast.BatchNo = field.NewString(tableName, "batch_no")
 	_podcast.NewsId = field.NewUint(tableName, "news_id")
+	_podcast.NewsIds = field.NewString(tableName, "news_ids")
+	_podcast.Title = field.NewString(tableName, "title")
+	_podcast.Description = field.NewString(tableName, "description")
 	_podcast.Script = field.NewString(tableName, "script")
+	_podcast.Language = field.NewString(tableName, "language")
 	_podcast.Audio = field.NewString(tableName, "audio")
+	_podcast.Duration = field.NewInt(tableName, "duration")
 	_podcast.Style = field.NewString(tableName, "style")
 	_podcast.TtsAi = field.NewString(tableName, "tts_ai")
 	_podcast.CreatedAt = field.NewTime(tableName, "created_at")
+	_podcast.UpdatedAt = field.NewTime(tableName, "updated_at")
 
 	_podcast.fillFieldMap()
 
@@ -43,14 +50,21 @@ func newPodcast(db *gorm.DB, opts ...gen.DOOption) podcast {
 type podcast struct {
 	podcastDo podcastDo
 
-	ALL       field.Asterisk
-	ID        field.Uint
-	NewsId    field.Uint
-	Script    field.String
-	Audio     field.String
-	Style     field.String
-	TtsAi     field.String
-	CreatedAt field.Time
+	ALL         field.Asterisk
+	ID          field.Uint
+	BatchNo     field.String
+	NewsId      field.Uint
+	NewsIds     field.String
+	Title       field.String
+	Description field.String
+	Script      field.String
+	Language    field.String
+	Audio       field.String
+	Duration    field.Int
+	Style       field.String
+	TtsAi       field.String
+	CreatedAt   field.Time
+	UpdatedAt   field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -68,12 +82,19 @@ func (p podcast) As(alias string) *podcast {
 func (p *podcast) updateTableName(table string) *podcast {
 	p.ALL = field.NewAsterisk(table)
 	p.ID = field.NewUint(table, "id")
+	p.BatchNo = field.NewString(table, "batch_no")
 	p.NewsId = field.NewUint(table, "news_id")
+	p.NewsIds = field.NewString(table, "news_ids")
+	p.Title = field.NewString(table, "title")
+	p.Description = field.NewString(table, "description")
 	p.Script = field.NewString(table, "script")
+	p.Language = field.NewString(table, "language")
 	p.Audio = field.NewString(table, "audio")
+	p.Duration = field.NewInt(table, "duration")
 	p.Style = field.NewString(table, "style")
 	p.TtsAi = field.NewString(table, "tts_ai")
 	p.CreatedAt = field.NewTime(table, "created_at")
+	p.UpdatedAt = field.NewTime(table, "updated_at")
 
 	p.fillFieldMap()
 
@@ -98,14 +119,21 @@ func (p *podcast) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (p *podcast) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 7)
+	p.fieldMap = make(map[string]field.Expr, 14)
 	p.fieldMap["id"] = p.ID
+	p.fieldMap["batch_no"] = p.BatchNo
 	p.fieldMap["news_id"] = p.NewsId
+	p.fieldMap["news_ids"] = p.NewsIds
+	p.fieldMap["title"] = p.Title
+	p.fieldMap["description"] = p.Description
 	p.fieldMap["script"] = p.Script
+	p.fieldMap["language"] = p.Language
 	p.fieldMap["audio"] = p.Audio
+	p.fieldMap["duration"] = p.Duration
 	p.fieldMap["style"] = p.Style
 	p.fieldMap["tts_ai"] = p.TtsAi
 	p.fieldMap["created_at"] = p.CreatedAt
+	p.fieldMap["updated_at"] = p.UpdatedAt
 }
 
 func (p podcast) clone(db *gorm.DB) podcast {
@@ -0,0 +1,355 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+func newPromptTemplate(db *gorm.DB, opts ...gen.DOOption) promptTemplate {
+	_promptTemplate := promptTemplate{}
+
+	_promptTemplate.promptTemplateDo.UseDB(db, opts...)
+	_promptTemplate.promptTemplateDo.UseModel(&model.PromptTemplate{})
+
+	tableName := _promptTemplate.promptTemplateDo.TableName()
+	_promptTemplate.ALL = field.NewAsterisk(tableName)
+	_promptTemplate.ID = field.NewUint(tableName, "id")
+	_promptTemplate.Name = field.NewString(tableName, "name")
+	_promptTemplate.Language = field.NewString(tableName, "language")
+	_promptTemplate.Version = field.NewInt(tableName, "version")
+	_promptTemplate.Content = field.NewString(tableName, "content")
+	_promptTemplate.Note = field.NewString(tableName, "note")
+	_promptTemplate.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_promptTemplate.fillFieldMap()
+
+	return _promptTemplate
+}
+
+type promptTemplate struct {
+	promptTemplateDo promptTemplateDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	Name      field.String
+	Language  field.String
+	Version   field.Int
+	Content   field.String
+	Note      field.String
+	CreatedAt field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (p promptTemplate) Table(newTableName string) *promptTemplate {
+	p.promptTemplateDo.UseTable(newTableName)
+	return p.updateTableName(newTableName)
+}
+
+func (p promptTemplate) As(alias string) *promptTemplate {
+	p.promptTemplateDo.DO = *(p.promptTemplateDo.As(alias).(*gen.DO))
+	return p.updateTableName(alias)
+}
+
+func (p *promptTemplate) updateTableName(table string) *promptTemplate {
+	p.ALL = field.NewAsterisk(table)
+	p.ID = field.NewUint(table, "id")
+	p.Name = field.NewString(table, "name")
+	p.Language = field.NewString(table, "language")
+	p.Version = field.NewInt(table, "version")
+	p.Content = field.NewString(table, "content")
+	p.Note = field.NewString(table, "note")
+	p.CreatedAt = field.NewTime(table, "created_at")
+
+	p.fillFieldMap()
+
+	return p
+}
+
+func (p *promptTemplate) WithContext(ctx context.Context) *promptTemplateDo {
+	return p.promptTemplateDo.WithContext(ctx)
+}
+
+func (p promptTemplate) TableName() string { return p.promptTemplateDo.TableName() }
+
+func (p promptTemplate) Alias() string { return p.promptTemplateDo.Alias() }
+
+func (p promptTemplate) Columns(cols ...field.Expr) gen.Columns {
+	return p.promptTemplateDo.Columns(cols...)
+}
+
+func (p *promptTemplate) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := p.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (p *promptTemplate) fillFieldMap() {
+	p.fieldMap = make(map[string]field.Expr, 7)
+	p.fieldMap["id"] = p.ID
+	p.fieldMap["name"] = p.Name
+	p.fieldMap["language"] = p.Language
+	p.fieldMap["version"] = p.Version
+	p.fieldMap["content"] = p.Content
+	p.fieldMap["note"] = p.Note
+	p.fieldMap["created_at"] = p.CreatedAt
+}
+
+func (p promptTemplate) clone(db *gorm.DB) promptTemplate {
+	p.promptTemplateDo.ReplaceConnPool(db.Statement.ConnPool)
+	return p
+}
+
+func (p promptTemplate) replaceDB(db *gorm.DB) promptTemplate {
+	p.promptTemplateDo.ReplaceDB(db)
+	return p
+}
+
+type promptTemplateDo struct{ gen.DO }
+
+func (p promptTemplateDo) Debug() *promptTemplateDo {
+	return p.withDO(p.DO.Debug())
+}
+
+func (p promptTemplateDo) WithContext(ctx context.Context) *promptTemplateDo {
+	return p.withDO(p.DO.WithContext(ctx))
+}
+
+func (p promptTemplateDo) ReadDB() *promptTemplateDo {
+	return p.Clauses(dbresolver.Read)
+}
+
+func (p promptTemplateDo) WriteDB() *promptTemplateDo {
+	return p.Clauses(dbresolver.Write)
+}
+
+func (p promptTemplateDo) Session(config *gorm.Session) *promptTemplateDo {
+	return p.withDO(p.DO.Session(config))
+}
+
+func (p promptTemplateDo) Clauses(conds ...clause.Expression) *promptTemplateDo {
+	return p.withDO(p.DO.Clauses(conds...))
+}
+
+func (p promptTemplateDo) Returning(value interface{}, columns ...string) *promptTemplateDo {
+	return p.withDO(p.DO.Returning(value, columns...))
+}
+
+func (p promptTemplateDo) Not(conds ...gen.Condition) *promptTemplateDo {
+	return p.withDO(p.DO.Not(conds...))
+}
+
+func (p promptTemplateDo) Or(conds ...gen.Condition) *promptTemplateDo {
+	return p.withDO(p.DO.Or(conds...))
+}
+
+func (p promptTemplateDo) Select(conds ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Select(conds...))
+}
+
+func (p promptTemplateDo) Where(conds ...gen.Condition) *promptTemplateDo {
+	return p.withDO(p.DO.Where(conds...))
+}
+
+func (p promptTemplateDo) Order(conds ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Order(conds...))
+}
+
+func (p promptTemplateDo) Distinct(cols ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Distinct(cols...))
+}
+
+func (p promptTemplateDo) Omit(cols ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Omit(cols...))
+}
+
+func (p promptTemplateDo) Join(table schema.Tabler, on ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Join(table, on...))
+}
+
+func (p promptTemplateDo) LeftJoin(table schema.Tabler, on ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.LeftJoin(table, on...))
+}
+
+func (p promptTemplateDo) RightJoin(table schema.Tabler, on ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.RightJoin(table, on...))
+}
+
+func (p promptTemplateDo) Group(cols ...field.Expr) *promptTemplateDo {
+	return p.withDO(p.DO.Group(cols...))
+}
+
+func (p promptTemplateDo) Having(conds ...gen.Condition) *promptTemplateDo {
+	return p.withDO(p.DO.Having(conds...))
+}
+
+func (p promptTemplateDo) Limit(limit int) *promptTemplateDo {
+	return p.withDO(p.DO.Limit(limit))
+}
+
+func (p promptTemplateDo) Offset(offset int) *promptTemplateDo {
+	return p.withDO(p.DO.Offset(offset))
+}
+
+func (p promptTemplateDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *promptTemplateDo {
+	return p.withDO(p.DO.Scopes(funcs...))
+}
+
+func (p promptTemplateDo) Unscoped() *promptTemplateDo {
+	return p.withDO(p.DO.Unscoped())
+}
+
+func (p promptTemplateDo) Create(values ...*model.PromptTemplate) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return p.DO.Create(values)
+}
+
+func (p promptTemplateDo) CreateInBatches(values []*model.PromptTemplate, batchSize int) error {
+	return p.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (p promptTemplateDo) Save(values ...*model.PromptTemplate) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return p.DO.Save(values)
+}
+
+func (p promptTemplateDo) First() (*model.PromptTemplate, error) {
+	if result, err := p.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PromptTemplate), nil
+	}
+}
+
+func (p promptTemplateDo) Take() (*model.PromptTemplate, error) {
+	if result, err := p.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PromptTemplate), nil
+	}
+}
+
+func (p promptTemplateDo) Last() (*model.PromptTemplate, error) {
+	if result, err := p.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PromptTemplate), nil
+	}
+}
+
+func (p promptTemplateDo) Find() ([]*model.PromptTemplate, error) {
+	result, err := p.DO.Find()
+	return result.([]*model.PromptTemplate), err
+}
+
+func (p promptTemplateDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.PromptTemplate, err error) {
+	buf := make([]*model.PromptTemplate, 0, batchSize)
+	err = p.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (p promptTemplateDo) FindInBatches(result *[]*model.PromptTemplate, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return p.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (p promptTemplateDo) Attrs(attrs ...field.AssignExpr) *promptTemplateDo {
+	return p.withDO(p.DO.Attrs(attrs...))
+}
+
+func (p promptTemplateDo) Assign(attrs ...field.AssignExpr) *promptTemplateDo {
+	return p.withDO(p.DO.Assign(attrs...))
+}
+
+func (p promptTemplateDo) Joins(fields ...field.RelationField) *promptTemplateDo {
+	for _, _f := range fields {
+		p = *p.withDO(p.DO.Joins(_f))
+	}
+	return &p
+}
+
+func (p promptTemplateDo) Preload(fields ...field.RelationField) *promptTemplateDo {
+	for _, _f := range fields {
+		p = *p.withDO(p.DO.Preload(_f))
+	}
+	return &p
+}
+
+func (p promptTemplateDo) FirstOrInit() (*model.PromptTemplate, error) {
+	if result, err := p.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PromptTemplate), nil
+	}
+}
+
+func (p promptTemplateDo) FirstOrCreate() (*model.PromptTemplate, error) {
+	if result, err := p.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.PromptTemplate), nil
+	}
+}
+
+func (p promptTemplateDo) FindByPage(offset int, limit int) (result []*model.PromptTemplate, count int64, err error) {
+	result, err = p.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = p.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (p promptTemplateDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = p.Count()
+	if err != nil {
+		return
+	}
+
+	err = p.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (p promptTemplateDo) Scan(result interface{}) (err error) {
+	return p.DO.Scan(result)
+}
+
+func (p promptTemplateDo) Delete(models ...*model.PromptTemplate) (result gen.ResultInfo, err error) {
+	return p.DO.Delete(models)
+}
+
+func (p *promptTemplateDo) withDO(do gen.Dao) *promptTemplateDo {
+	p.DO = *do.(*gen.DO)
+	return p
+}
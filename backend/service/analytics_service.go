@@ -0,0 +1,208 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/textx"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// AnalyticsService represents the interface for news analytics.
+type AnalyticsService interface {
+	TermFrequencies(ctx context.Context, params *valueobject.NewsAnalyticsParams) ([]*valueobject.TermGroup, error)
+	TrendingTerms(ctx context.Context, params *valueobject.NewsAnalyticsParams) ([]*valueobject.TrendingTerm, error)
+	NewsTimeSeries(ctx context.Context, params *valueobject.NewsAnalyticsParams) ([]*valueobject.TimeSeries, error)
+}
+
+type analyticsService struct {
+}
+
+func NewAnalyticsService() AnalyticsService {
+	return &analyticsService{}
+}
+
+// TermFrequencies counts the terms and phrases of the news titles per day, source or topic.
+func (s *analyticsService) TermFrequencies(ctx context.Context, params *valueobject.NewsAnalyticsParams) (
+	[]*valueobject.TermGroup, error) {
+	news, err := s.loadNews(ctx, params, params.StartDate, params.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		groups   = make([]string, 0)
+		counters = make(map[string]valueobject.TermCounter)
+		totals   = make(map[string]int)
+	)
+
+	if params.Dimension == valueobject.AnalyticsByDay {
+		groups = params.Days()
+	}
+
+	for _, item := range news {
+		name := analyticsGroup(item, params.Dimension)
+
+		counter, ok := counters[name]
+		if !ok {
+			counter = make(valueobject.TermCounter)
+			counters[name] = counter
+
+			if params.Dimension != valueobject.AnalyticsByDay {
+				groups = append(groups, name)
+			}
+		}
+
+		counter.Add(textx.Keywords(item.Title))
+		totals[name]++
+	}
+
+	// the most covered sources and topics first
+	if params.Dimension != valueobject.AnalyticsByDay {
+		slices.SortFunc(groups, func(a, b string) int {
+			return cmp.Or(cmp.Compare(totals[b], totals[a]), cmp.Compare(a, b))
+		})
+		groups = groups[:min(len(groups), params.Limit)]
+	}
+
+	result := make([]*valueobject.TermGroup, len(groups))
+
+	for idx, name := range groups {
+		result[idx] = &valueobject.TermGroup{
+			Name:  name,
+			Total: totals[name],
+			Terms: counters[name].Top(params.Limit),
+		}
+
+		if result[idx].Terms == nil {
+			result[idx].Terms = make([]*valueobject.TermCount, 0)
+		}
+	}
+
+	return result, nil
+}
+
+// TrendingTerms finds the terms rising sharply in the window compared with the trailing baseline.
+func (s *analyticsService) TrendingTerms(ctx context.Context, params *valueobject.NewsAnalyticsParams) (
+	[]*valueobject.TrendingTerm, error) {
+	baselineStart, windowStart, windowEnd := params.TrendingRange()
+
+	news, err := s.loadNews(ctx, params, baselineStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		window   = make(valueobject.TermCounter)
+		baseline = make(valueobject.TermCounter)
+	)
+
+	for _, item := range news {
+		if item.PublishedAt.Before(windowStart) {
+			baseline.Add(textx.Keywords(item.Title))
+		} else {
+			window.Add(textx.Keywords(item.Title))
+		}
+	}
+
+	return valueobject.NewTrendingTerms(window, baseline, params.WindowDays, params.BaselineDays, params.Limit), nil
+}
+
+// NewsTimeSeries counts the news per day by source, topic or keyword.
+func (s *analyticsService) NewsTimeSeries(ctx context.Context, params *valueobject.NewsAnalyticsParams) (
+	[]*valueobject.TimeSeries, error) {
+	news, err := s.loadNews(ctx, params, params.StartDate, params.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		days   = params.Days()
+		series = make(map[string]*valueobject.TimeSeries)
+		result = make([]*valueobject.TimeSeries, 0)
+		add    = func(name, day string) {
+			item, ok := series[name]
+			if !ok {
+				item = valueobject.NewTimeSeries(name, days)
+				series[name] = item
+				result = append(result, item)
+			}
+
+			item.Add(day)
+		}
+	)
+
+	// keep the order of the configured keywords
+	if params.Dimension == valueobject.AnalyticsByKeyword {
+		for _, keyword := range params.Keywords {
+			series[keyword] = valueobject.NewTimeSeries(keyword, days)
+			result = append(result, series[keyword])
+		}
+	}
+
+	for _, item := range news {
+		day := valueobject.AnalyticsDay(item.PublishedAt)
+
+		if params.Dimension != valueobject.AnalyticsByKeyword {
+			add(analyticsGroup(item, params.Dimension), day)
+
+			continue
+		}
+
+		for _, keyword := range params.Keywords {
+			if _, ok := textx.MatchesKeyword(item.Title, []string{keyword}); ok {
+				add(keyword, day)
+			}
+		}
+	}
+
+	if params.Dimension != valueobject.AnalyticsByKeyword {
+		slices.SortFunc(result, func(a, b *valueobject.TimeSeries) int {
+			return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Name, b.Name))
+		})
+
+		result = result[:min(len(result), params.Limit)]
+	}
+
+	return result, nil
+}
+
+// loadNews loads the news published in the time range.
+func (s *analyticsService) loadNews(ctx context.Context, params *valueobject.NewsAnalyticsParams,
+	start, end time.Time) ([]*model.NewsDetail, error) {
+	var (
+		repo  = repository.Q.NewsDetail
+		query = repo.WithContext(ctx).Select(repo.ID, repo.Source, repo.Topic, repo.Title, repo.PublishedAt).
+			Where(repo.PublishedAt.Gte(start), repo.PublishedAt.Lt(end))
+	)
+
+	if params.Source != "" {
+		query = query.Where(repo.Source.Eq(params.Source))
+	}
+
+	if params.Topic != "" {
+		query = query.Where(repo.Topic.Eq(params.Topic))
+	}
+
+	data, err := query.Find()
+
+	return data, errors.WithStack(err)
+}
+
+// analyticsGroup returns the group name of the news by the dimension.
+func analyticsGroup(news *model.NewsDetail, dimension valueobject.AnalyticsDimension) string {
+	switch dimension {
+	case valueobject.AnalyticsBySource:
+		return news.Source
+	case valueobject.AnalyticsByTopic:
+		return news.Topic
+	default:
+		return valueobject.AnalyticsDay(news.PublishedAt)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/repository"
+)
+
+// ChatService represents the interface for chat session operations.
+type ChatService interface {
+	CreateSession(ctx context.Context, session *entity.ChatSession) error
+	GetSession(ctx context.Context, id uint) (*entity.ChatSession, error)
+	QuerySessions(ctx context.Context, page *httpx.Pagination) ([]*entity.ChatSession, int64, error)
+	DeleteSession(ctx context.Context, id uint) error
+	QueryMessages(ctx context.Context, sessionId uint, limit int) ([]*entity.ChatMessage, error)
+	AddMessages(ctx context.Context, sessionId uint, messages ...*entity.ChatMessage) error
+}
+
+type chatService struct {
+}
+
+func NewChatService() ChatService {
+	return &chatService{}
+}
+
+// CreateSession creates a chat session.
+func (s *chatService) CreateSession(ctx context.Context, session *entity.ChatSession) error {
+	data, err := session.ToModel()
+	if err != nil {
+		return err
+	}
+
+	if err := repository.Q.ChatSession.WithContext(ctx).Create(data); err != nil {
+		return errors.WithStack(err)
+	}
+
+	session.Id = data.ID
+	session.CreatedAt = data.CreatedAt
+	session.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// GetSession gets the chat session by id with all its messages.
+func (s *chatService) GetSession(ctx context.Context, id uint) (*entity.ChatSession, error) {
+	repo := repository.Q.ChatSession
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.ChatSessionNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	session, err := entity.NewChatSessionFromModel(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Messages, err = s.QueryMessages(ctx, id, 0); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// QuerySessions queries the chat sessions without messages, the latest active first.
+func (s *chatService) QuerySessions(ctx context.Context, page *httpx.Pagination) (
+	[]*entity.ChatSession, int64, error) {
+	repo := repository.Q.ChatSession
+
+	data, total, err := repo.WithContext(ctx).Order(repo.UpdatedAt.Desc(), repo.ID.Desc()).
+		FindByPage(page.GetOffset(), page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	sessions, err := gokit.SliceMapErr(data, entity.NewChatSessionFromModel)
+
+	return sessions, total, err
+}
+
+// DeleteSession deletes the chat session and its messages.
+func (s *chatService) DeleteSession(ctx context.Context, id uint) error {
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		if _, err := tx.ChatSession.WithContext(ctx).Where(tx.ChatSession.ID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+
+		_, err := tx.ChatMessage.WithContext(ctx).Where(tx.ChatMessage.SessionId.Eq(id)).Delete()
+
+		return err
+	})
+
+	return errors.WithStack(err)
+}
+
+// QueryMessages queries the latest messages of the chat session in chronological order, all if limit is 0.
+func (s *chatService) QueryMessages(ctx context.Context, sessionId uint, limit int) ([]*entity.ChatMessage, error) {
+	var (
+		repo  = repository.Q.ChatMessage
+		query = repo.WithContext(ctx).Where(repo.SessionId.Eq(sessionId)).Order(repo.ID.Desc())
+	)
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	data, err := query.Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	slices.Reverse(data)
+
+	return gokit.SliceMap(data, entity.NewChatMessageFromModel), nil
+}
+
+// AddMessages appends the messages to the chat session and marks the session as active.
+func (s *chatService) AddMessages(ctx context.Context, sessionId uint, messages ...*entity.ChatMessage) error {
+	data := gokit.SliceMap(messages, (*entity.ChatMessage).ToModel)
+
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		if err := tx.ChatMessage.WithContext(ctx).Create(data...); err != nil {
+			return err
+		}
+
+		_, err := tx.ChatSession.WithContext(ctx).Where(tx.ChatSession.ID.Eq(sessionId)).
+			UpdateColumnSimple(tx.ChatSession.UpdatedAt.Value(time.Now()))
+
+		return err
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for idx, item := range messages {
+		item.Id = data[idx].ID
+	}
+
+	return nil
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/gocolly/colly/v2"
 	"github.com/pkg/errors"
+	"gorm.io/gen"
 	"gorm.io/gorm"
 
+	"github.com/mjiee/gokit"
+
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
@@ -24,10 +29,16 @@ type CrawlingService interface {
 	QueryCrawlingRecords(ctx context.Context, params valueobject.QueryRecordParams) ([]*entity.CrawlingRecord, int64, error)
 	DeleteCrawlingRecord(ctx context.Context, id uint) error
 	HasProcessingTasks(ctx context.Context) (bool, error)
+	HasProcessingRecord(ctx context.Context, recordType valueobject.CrawlingRecordType) (bool, error)
 	PauseAllTasks(ctx context.Context) error
-	DeleteHistory(ctx context.Context, deadline time.Time) error
+	PlanHistory(ctx context.Context, policy *valueobject.RetentionPolicy, dryRun bool) (
+		*valueobject.RetentionReport, error)
+	DeleteHistory(ctx context.Context, report *valueobject.RetentionReport) error
 }
 
+// retentionBatchSize is the number of rows deleted in one transaction.
+const retentionBatchSize = 200
+
 type crawlingService struct {
 	collector *colly.Collector
 }
@@ -143,24 +154,42 @@ func (s *crawlingService) DeleteCrawlingRecord(ctx context.Context, id uint) err
 			return errors.WithStack(err)
 		}
 
-		if _, err := tx.NewsDetail.WithContext(ctx).Where(
+		var newsIds []uint
+
+		if err := tx.NewsDetail.WithContext(ctx).Where(
 			tx.NewsDetail.RecordId.Eq(id),
 			tx.NewsDetail.Favorited.Is(false),
-		).Delete(); err != nil {
+		).Pluck(tx.NewsDetail.ID, &newsIds); err != nil {
 			return errors.WithStack(err)
 		}
 
-		return nil
+		return errors.WithStack(deleteNewsWithDependents(ctx, tx, newsIds...))
 	})
 
 	return errors.WithStack(err)
 }
 
-// HasProcessingTasks check if there are any processing tasks
+// HasProcessingTasks check if there are any processing crawling tasks
 func (s *crawlingService) HasProcessingTasks(ctx context.Context) (bool, error) {
 	repo := repository.Q.CrawlingRecord
 
-	count, err := repo.WithContext(ctx).Where(repo.Status.Eq(string(valueobject.ProcessingCrawlingRecord))).Count()
+	count, err := repo.WithContext(ctx).Where(
+		repo.Status.Eq(string(valueobject.ProcessingCrawlingRecord)),
+		repo.RecordType.In(gokit.SliceMap(valueobject.CrawlingRecordTypes, valueobject.CrawlingRecordType.String)...),
+	).Count()
+
+	return count > 0, errors.WithStack(err)
+}
+
+// HasProcessingRecord check if there is a processing record of the type
+func (s *crawlingService) HasProcessingRecord(ctx context.Context, recordType valueobject.CrawlingRecordType) (
+	bool, error) {
+	repo := repository.Q.CrawlingRecord
+
+	count, err := repo.WithContext(ctx).Where(
+		repo.Status.Eq(string(valueobject.ProcessingCrawlingRecord)),
+		repo.RecordType.Eq(recordType.String()),
+	).Count()
 
 	return count > 0, errors.WithStack(err)
 }
@@ -176,18 +205,240 @@ func (s *crawlingService) PauseAllTasks(ctx context.Context) error {
 	return errors.WithStack(err)
 }
 
-// DeleteHistory delete history
-func (s *crawlingService) DeleteHistory(ctx context.Context, deadline time.Time) error {
-	repo := repository.Q.CrawlingRecord
+// PlanHistory finds the crawling records and news to be deleted by the retention policy
+func (s *crawlingService) PlanHistory(ctx context.Context, policy *valueobject.RetentionPolicy, dryRun bool) (
+	*valueobject.RetentionReport, error) {
+	var (
+		now      = time.Now()
+		report   = valueobject.NewRetentionReport(dryRun)
+		repo     = repository.Q.CrawlingRecord
+		newsRepo = repository.Q.NewsDetail
+	)
+
+	exemptions, err := s.newsExemptions(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := func(extra ...gen.Condition) []gen.Condition {
+		return append(slices.Clone(exemptions), extra...)
+	}
+
+	// the sources with their own limits are left out of the global limits
+	var ageSources, countSources []string
+
+	for _, rule := range policy.Sources {
+		if rule.MaxAgeDays > 0 {
+			ageSources = append(ageSources, rule.Source)
+		}
+
+		if rule.MaxNews > 0 {
+			countSources = append(countSources, rule.Source)
+		}
+	}
+
+	excluded := func(sources []string) []gen.Condition {
+		if len(sources) == 0 {
+			return nil
+		}
+
+		return []gen.Condition{newsRepo.Source.NotIn(sources...)}
+	}
+
+	// expired crawling records
+	for recordType := range policy.RecordMaxAgeDays {
+		deadline := policy.RecordDeadline(recordType, now)
+		if deadline.IsZero() {
+			continue
+		}
+
+		var ids []uint
+
+		if err := repo.WithContext(ctx).Where(
+			repo.RecordType.Eq(string(recordType)),
+			repo.Status.Neq(valueobject.ProcessingCrawlingRecord.String()),
+			repo.CreatedAt.Lte(deadline),
+		).Pluck(repo.ID, &ids); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		report.RecordIds = append(report.RecordIds, ids...)
+	}
+
+	if len(report.RecordIds) > 0 {
+		err := s.planNews(ctx, report, conds(append(excluded(ageSources),
+			newsRepo.RecordId.In(report.RecordIds...))...), 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// per-source rules
+	for _, rule := range policy.Sources {
+		if deadline := rule.Deadline(now); !deadline.IsZero() {
+			err := s.planNews(ctx, report, conds(newsRepo.Source.Eq(rule.Source), newsRepo.CreatedAt.Lte(deadline)), 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if rule.MaxNews > 0 {
+			if err := s.planNews(ctx, report, conds(newsRepo.Source.Eq(rule.Source)), rule.MaxNews); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// maximum number of news
+	if policy.MaxNews > 0 {
+		if err := s.planNews(ctx, report, conds(excluded(countSources)...), policy.MaxNews); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// newsExemptions returns the conditions that exclude the exempted news. The news referenced by the chat sessions,
+// the digests and the published podcasts are always exempted, they are not deleted with their dependents.
+func (s *crawlingService) newsExemptions(ctx context.Context, policy *valueobject.RetentionPolicy) (
+	[]gen.Condition, error) {
+	var (
+		conds    = make([]gen.Condition, 0, 2)
+		newsRepo = repository.Q.NewsDetail
+	)
+
+	if policy.KeepFavorited {
+		conds = append(conds, newsRepo.Favorited.Is(false))
+	}
+
+	newsIds, err := s.referencedNews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.KeepPodcast {
+		var (
+			taskNewsIds []uint
+			taskRepo    = repository.Q.PodcastTask
+		)
+
+		if err := taskRepo.WithContext(ctx).Distinct(taskRepo.NewsId).Where(taskRepo.NewsId.Gt(0)).
+			Pluck(taskRepo.NewsId, &taskNewsIds); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		newsIds = append(newsIds, taskNewsIds...)
+	}
+
+	if len(newsIds) > 0 {
+		conds = append(conds, newsRepo.ID.NotIn(newsIds...))
+	}
+
+	return conds, nil
+}
+
+// referencedNews returns the ids of the news referenced by the chat sessions, the digests and the podcasts
+func (s *crawlingService) referencedNews(ctx context.Context) ([]uint, error) {
+	var (
+		newsIds     = make([]uint, 0)
+		chatRepo    = repository.Q.ChatSession
+		digestRepo  = repository.Q.NewsDigest
+		podcastRepo = repository.Q.Podcast
+	)
+
+	sessions, err := chatRepo.WithContext(ctx).Select(chatRepo.ID, chatRepo.NewsIds).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, item := range sessions {
+		session, err := entity.NewChatSessionFromModel(item)
+		if err != nil {
+			return nil, err
+		}
+
+		newsIds = append(newsIds, session.NewsIds...)
+	}
+
+	digests, err := digestRepo.WithContext(ctx).Select(digestRepo.ID, digestRepo.Sections).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, item := range digests {
+		digest, err := entity.NewNewsDigestFromModel(item)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, section := range digest.Sections {
+			for _, article := range section.Items {
+				newsIds = append(newsIds, article.NewsId)
+			}
+		}
+	}
 
-	data, err := repo.WithContext(ctx).Where(repo.CreatedAt.Lte(deadline)).Find()
+	podcasts, err := podcastRepo.WithContext(ctx).Select(podcastRepo.ID, podcastRepo.NewsIds).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, item := range podcasts {
+		podcast, err := entity.NewPodcastFromModel(item)
+		if err != nil {
+			return nil, err
+		}
+
+		newsIds = append(newsIds, podcast.NewsIds...)
+	}
+
+	return newsIds, nil
+}
+
+// planNews adds the news matching the conditions to the report, keeping the newest ones if keep is positive
+func (s *crawlingService) planNews(ctx context.Context, report *valueobject.RetentionReport,
+	conds []gen.Condition, keep int) error {
+	var (
+		newsRepo = repository.Q.NewsDetail
+		query    = newsRepo.WithContext(ctx).Select(newsRepo.ID, newsRepo.Source).Where(conds...)
+	)
+
+	if keep > 0 {
+		query = query.Order(newsRepo.ID.Desc()).Offset(keep).Limit(math.MaxInt32)
+	}
+
+	news, err := query.Find()
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	for _, record := range data {
-		if err := s.DeleteCrawlingRecord(ctx, record.ID); err != nil {
+	for _, item := range news {
+		report.AddNews(item.ID, item.Source)
+	}
+
+	return nil
+}
+
+// DeleteHistory deletes the planned news and crawling records in batches
+func (s *crawlingService) DeleteHistory(ctx context.Context, report *valueobject.RetentionReport) error {
+	for ids := range slices.Chunk(report.NewsIds, retentionBatchSize) {
+		err := repository.Q.Transaction(func(tx *repository.Query) error {
+			return deleteNewsWithDependents(ctx, tx, ids...)
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for ids := range slices.Chunk(report.RecordIds, retentionBatchSize) {
+		err := repository.Q.Transaction(func(tx *repository.Query) error {
+			_, err := tx.CrawlingRecord.WithContext(ctx).Where(tx.CrawlingRecord.ID.In(ids...)).Delete()
+
 			return err
+		})
+		if err != nil {
+			return errors.WithStack(err)
 		}
 	}
 
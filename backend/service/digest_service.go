@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/httpx"
+	"github.com/mjiee/world-news/backend/repository"
+)
+
+// DigestService represents the interface for news digest operations.
+type DigestService interface {
+	CreateDigest(ctx context.Context, digest *entity.NewsDigest) error
+	SaveDigest(ctx context.Context, digest *entity.NewsDigest) error
+	GetDigest(ctx context.Context, id uint) (*entity.NewsDigest, error)
+	GetDigestByDate(ctx context.Context, date string) (*entity.NewsDigest, error)
+	QueryDigests(ctx context.Context, page *httpx.Pagination) ([]*entity.NewsDigest, int64, error)
+	DeleteDigest(ctx context.Context, id uint) error
+}
+
+type digestService struct {
+}
+
+func NewDigestService() DigestService {
+	return &digestService{}
+}
+
+// CreateDigest creates a news digest.
+func (s *digestService) CreateDigest(ctx context.Context, digest *entity.NewsDigest) error {
+	data, err := digest.ToModel()
+	if err != nil {
+		return err
+	}
+
+	if err := repository.Q.NewsDigest.WithContext(ctx).Create(data); err != nil {
+		return errors.WithStack(err)
+	}
+
+	digest.Id = data.ID
+
+	return nil
+}
+
+// SaveDigest saves all fields of the news digest, including the cleared ones.
+func (s *digestService) SaveDigest(ctx context.Context, digest *entity.NewsDigest) error {
+	data, err := digest.ToModel()
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(repository.Q.NewsDigest.WithContext(ctx).Save(data))
+}
+
+// GetDigest gets the news digest by id.
+func (s *digestService) GetDigest(ctx context.Context, id uint) (*entity.NewsDigest, error) {
+	repo := repository.Q.NewsDigest
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NewsDigestNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return entity.NewNewsDigestFromModel(data)
+}
+
+// GetDigestByDate gets the news digest of the day.
+func (s *digestService) GetDigestByDate(ctx context.Context, date string) (*entity.NewsDigest, error) {
+	repo := repository.Q.NewsDigest
+
+	data, err := repo.WithContext(ctx).Where(repo.Date.Eq(date)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NewsDigestNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return entity.NewNewsDigestFromModel(data)
+}
+
+// QueryDigests queries the news digests, the latest day first.
+func (s *digestService) QueryDigests(ctx context.Context, page *httpx.Pagination) (
+	[]*entity.NewsDigest, int64, error) {
+	repo := repository.Q.NewsDigest
+
+	data, total, err := repo.WithContext(ctx).Order(repo.Date.Desc()).FindByPage(page.GetOffset(), page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	digests := make([]*entity.NewsDigest, len(data))
+
+	for i, v := range data {
+		digests[i], err = entity.NewNewsDigestFromModel(v)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return digests, total, nil
+}
+
+// DeleteDigest deletes the news digest.
+func (s *digestService) DeleteDigest(ctx context.Context, id uint) error {
+	repo := repository.Q.NewsDigest
+
+	_, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).Delete()
+
+	return errors.WithStack(err)
+}
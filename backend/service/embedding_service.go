@@ -0,0 +1,196 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gorm.io/gen"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/vectorx"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// embeddingSearchBatchSize is the number of vectors loaded in one batch of the semantic search.
+const embeddingSearchBatchSize = 500
+
+// EmbeddingService represents the interface for news embedding operations.
+type EmbeddingService interface {
+	CountPendingNews(ctx context.Context, embeddingModel string) (int64, error)
+	QueryPendingNews(ctx context.Context, embeddingModel string, afterId uint, limit int) ([]*entity.NewsDetail,
+		error)
+	SaveEmbeddings(ctx context.Context, embeddings ...*entity.NewsEmbedding) error
+	SearchNews(ctx context.Context, embeddingModel string, vector []float64, params *valueobject.SearchNewsParams) (
+		[]*entity.NewsMatch, error)
+}
+
+type embeddingService struct {
+}
+
+func NewEmbeddingService() EmbeddingService {
+	return &embeddingService{}
+}
+
+// pendingNewsCondition filters the news without an embedding of the model.
+func (s *embeddingService) pendingNewsCondition(ctx context.Context, embeddingModel string) gen.Condition {
+	var (
+		repo          = repository.Q.NewsDetail
+		embeddingRepo = repository.Q.NewsEmbedding
+	)
+
+	return repo.Columns(repo.ID).NotIn(
+		embeddingRepo.WithContext(ctx).Select(embeddingRepo.NewsId).Where(embeddingRepo.Model.Eq(embeddingModel)),
+	)
+}
+
+// CountPendingNews counts the news without an embedding of the model.
+func (s *embeddingService) CountPendingNews(ctx context.Context, embeddingModel string) (int64, error) {
+	count, err := repository.Q.NewsDetail.WithContext(ctx).Where(s.pendingNewsCondition(ctx, embeddingModel)).Count()
+
+	return count, errors.WithStack(err)
+}
+
+// QueryPendingNews queries the news without an embedding of the model, ordered by id.
+func (s *embeddingService) QueryPendingNews(ctx context.Context, embeddingModel string, afterId uint, limit int) (
+	[]*entity.NewsDetail, error) {
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).Where(s.pendingNewsCondition(ctx, embeddingModel), repo.ID.Gt(afterId)).
+		Order(repo.ID).Limit(limit).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMapErr(data, entity.NewNewsDetailFromModel)
+}
+
+// SaveEmbeddings saves the embeddings, replacing the previous embeddings of the news.
+func (s *embeddingService) SaveEmbeddings(ctx context.Context, embeddings ...*entity.NewsEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	var (
+		data    = gokit.SliceMap(embeddings, (*entity.NewsEmbedding).ToModel)
+		newsIds = gokit.SliceMap(embeddings, func(item *entity.NewsEmbedding) uint { return item.NewsId })
+	)
+
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		repo := tx.NewsEmbedding
+
+		if _, err := repo.WithContext(ctx).Where(repo.NewsId.In(newsIds...)).Delete(); err != nil {
+			return err
+		}
+
+		return repo.WithContext(ctx).Create(data...)
+	})
+
+	return errors.WithStack(err)
+}
+
+// SearchNews finds the news closest to the vector by brute-force cosine similarity.
+func (s *embeddingService) SearchNews(ctx context.Context, embeddingModel string, vector []float64,
+	params *valueobject.SearchNewsParams) ([]*entity.NewsMatch, error) {
+	var (
+		repo     = repository.Q.NewsEmbedding
+		newsRepo = repository.Q.NewsDetail
+		query    = repo.WithContext(ctx).Select(repo.ID, repo.NewsId, repo.Vector).Where(repo.Model.Eq(embeddingModel))
+		scores   = make([]*entity.NewsMatch, 0, params.Limit+1)
+		batch    []*model.NewsEmbedding
+	)
+
+	if params.Source != "" || params.Topic != "" {
+		newsQuery := newsRepo.WithContext(ctx).Select(newsRepo.ID)
+
+		if params.Source != "" {
+			newsQuery = newsQuery.Where(newsRepo.Source.Eq(params.Source))
+		}
+
+		if params.Topic != "" {
+			newsQuery = newsQuery.Where(newsRepo.Topic.Eq(params.Topic))
+		}
+
+		query = query.Where(repo.Columns(repo.NewsId).In(newsQuery))
+	}
+
+	err := query.FindInBatches(&batch, embeddingSearchBatchSize, func(tx gen.Dao, _ int) error {
+		for _, item := range batch {
+			current, err := vectorx.Decode(item.Vector)
+			if err != nil {
+				logx.WithContext(ctx).Error("SearchNews.Decode", err)
+
+				continue
+			}
+
+			score := vectorx.Cosine(vector, current)
+			if score < params.MinScore {
+				continue
+			}
+
+			// keep the top scores in descending order
+			idx, _ := slices.BinarySearchFunc(scores, score, func(item *entity.NewsMatch, target float64) int {
+				return cmp.Compare(target, item.Score)
+			})
+
+			if idx >= params.Limit {
+				continue
+			}
+
+			match := &entity.NewsMatch{News: &entity.NewsDetail{Id: item.NewsId}, Score: score}
+			scores = slices.Insert(scores, idx, match)[:min(len(scores)+1, params.Limit)]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return s.loadMatchedNews(ctx, scores)
+}
+
+// loadMatchedNews loads the news of the matches, keeping the order of the scores.
+func (s *embeddingService) loadMatchedNews(ctx context.Context, matches []*entity.NewsMatch) (
+	[]*entity.NewsMatch, error) {
+	if len(matches) == 0 {
+		return matches, nil
+	}
+
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).
+		Where(repo.ID.In(gokit.SliceMap(matches, func(item *entity.NewsMatch) uint { return item.News.Id })...)).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	news := make(map[uint]*model.NewsDetail, len(data))
+
+	for _, item := range data {
+		news[item.ID] = item
+	}
+
+	result := make([]*entity.NewsMatch, 0, len(matches))
+
+	for _, item := range matches {
+		m, ok := news[item.News.Id]
+		if !ok {
+			continue
+		}
+
+		if item.News, err = entity.NewNewsDetailFromModel(m); err != nil {
+			return nil, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/ner"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// EntityService represents the interface for named entity operations.
+type EntityService interface {
+	CountPendingNews(ctx context.Context) (int64, error)
+	QueryPendingNews(ctx context.Context, afterId uint, limit int) ([]*entity.NewsDetail, error)
+	SaveNewsEntities(ctx context.Context, newsId uint, entities []*ner.Entity) error
+	QueryEntities(ctx context.Context, params *valueobject.QueryEntityParams) ([]*entity.NamedEntity, int64, error)
+	QueryEntityNews(ctx context.Context, params *valueobject.QueryEntityParams) ([]*entity.NewsDetail, int64, error)
+	QueryCooccurringEntities(ctx context.Context, params *valueobject.QueryEntityParams) ([]*entity.NamedEntity,
+		error)
+	CountSharedEntities(ctx context.Context, newsId uint, limit int) (map[uint]int, error)
+}
+
+type entityService struct {
+}
+
+func NewEntityService() EntityService {
+	return &entityService{}
+}
+
+// namedEntityRow is the named entity with the number of mentions.
+type namedEntityRow struct {
+	ID       uint
+	Type     string
+	Name     string
+	Mentions int64
+}
+
+// sharedEntityRow is the number of entities shared by a news.
+type sharedEntityRow struct {
+	NewsId uint
+	Shared int
+}
+
+// toEntity converts the row to a NamedEntity entity.
+func (r *namedEntityRow) toEntity() *entity.NamedEntity {
+	return &entity.NamedEntity{Id: r.ID, Type: ner.EntityType(r.Type), Name: r.Name, Mentions: r.Mentions}
+}
+
+// CountPendingNews counts the news whose entities are not extracted.
+func (s *entityService) CountPendingNews(ctx context.Context) (int64, error) {
+	repo := repository.Q.NewsDetail
+
+	count, err := repo.WithContext(ctx).Where(repo.EntityExtracted.Is(false)).Count()
+
+	return count, errors.WithStack(err)
+}
+
+// QueryPendingNews queries the news whose entities are not extracted, ordered by id.
+func (s *entityService) QueryPendingNews(ctx context.Context, afterId uint, limit int) (
+	[]*entity.NewsDetail, error) {
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).Where(repo.EntityExtracted.Is(false), repo.ID.Gt(afterId)).
+		Order(repo.ID).Limit(limit).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMapErr(data, entity.NewNewsDetailFromModel)
+}
+
+// SaveNewsEntities replaces the entities mentioned by the news and marks the news as extracted.
+func (s *entityService) SaveNewsEntities(ctx context.Context, newsId uint, entities []*ner.Entity) error {
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		mentions := make([]*model.NewsEntityMention, 0, len(entities))
+
+		for _, item := range ner.Merge(entities) {
+			entityId, err := s.saveEntity(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+
+			mentions = append(mentions, &model.NewsEntityMention{
+				NewsId:    newsId,
+				EntityId:  entityId,
+				Count:     item.Count,
+				CreatedAt: time.Now(),
+			})
+		}
+
+		mentionRepo := tx.NewsEntityMention
+
+		if _, err := mentionRepo.WithContext(ctx).Where(mentionRepo.NewsId.Eq(newsId)).Delete(); err != nil {
+			return err
+		}
+
+		if err := mentionRepo.WithContext(ctx).CreateInBatches(mentions, 100); err != nil {
+			return err
+		}
+
+		_, err := tx.NewsDetail.WithContext(ctx).Where(tx.NewsDetail.ID.Eq(newsId)).
+			UpdateColumnSimple(tx.NewsDetail.EntityExtracted.Value(true))
+
+		return err
+	})
+
+	return errors.WithStack(err)
+}
+
+// saveEntity finds or creates the named entity, returning its id.
+func (s *entityService) saveEntity(ctx context.Context, tx *repository.Query, item *ner.Entity) (uint, error) {
+	var (
+		repo = tx.NamedEntity
+		data = entity.NewNamedEntityModel(item)
+	)
+
+	existing, err := repo.WithContext(ctx).Where(repo.Type.Eq(data.Type), repo.Key.Eq(data.Key)).First()
+	if err == nil {
+		return existing.ID, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	if err := repo.WithContext(ctx).Create(data); err != nil {
+		return 0, err
+	}
+
+	return data.ID, nil
+}
+
+// QueryEntities queries the named entities by name and type, the most mentioned first.
+func (s *entityService) QueryEntities(ctx context.Context, params *valueobject.QueryEntityParams) (
+	[]*entity.NamedEntity, int64, error) {
+	var (
+		repo        = repository.Q.NamedEntity
+		mentionRepo = repository.Q.NewsEntityMention
+		conds       = make([]gen.Condition, 0, 2)
+		rows        []*namedEntityRow
+	)
+
+	if params.Name != "" {
+		conds = append(conds, repo.Key.Like("%"+ner.Key(params.Name)+"%"))
+	}
+
+	if params.Type != "" {
+		conds = append(conds, repo.Type.Eq(string(params.Type)))
+	}
+
+	total, err := repo.WithContext(ctx).Where(conds...).Count()
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	err = repo.WithContext(ctx).Select(repo.ID, repo.Type, repo.Name, mentionRepo.ID.Count().As("mentions")).
+		LeftJoin(mentionRepo, mentionRepo.EntityId.EqCol(repo.ID)).Where(conds...).
+		Group(repo.ID, repo.Type, repo.Name).Order(mentionRepo.ID.Count().Desc(), repo.ID).
+		Offset(params.Page.GetOffset()).Limit(params.Page.GetLimit()).Scan(&rows)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(rows, (*namedEntityRow).toEntity), total, nil
+}
+
+// QueryEntityNews queries the news mentioning the named entity.
+func (s *entityService) QueryEntityNews(ctx context.Context, params *valueobject.QueryEntityParams) (
+	[]*entity.NewsDetail, int64, error) {
+	entityIds, err := s.findEntityIds(ctx, params)
+	if err != nil || len(entityIds) == 0 {
+		return []*entity.NewsDetail{}, 0, err
+	}
+
+	var (
+		repo        = repository.Q.NewsDetail
+		mentionRepo = repository.Q.NewsEntityMention
+	)
+
+	data, total, err := repo.WithContext(ctx).Where(repo.Columns(repo.ID).In(
+		mentionRepo.WithContext(ctx).Select(mentionRepo.NewsId).Where(mentionRepo.EntityId.In(entityIds...)),
+	)).Order(repo.ID.Desc()).FindByPage(params.Page.GetOffset(), params.Page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	news, err := gokit.SliceMapErr(data, entity.NewNewsDetailFromModel)
+
+	return news, total, err
+}
+
+// QueryCooccurringEntities queries the entities mentioned together with the named entity, the most frequent first.
+func (s *entityService) QueryCooccurringEntities(ctx context.Context, params *valueobject.QueryEntityParams) (
+	[]*entity.NamedEntity, error) {
+	entityIds, err := s.findEntityIds(ctx, params)
+	if err != nil || len(entityIds) == 0 {
+		return []*entity.NamedEntity{}, err
+	}
+
+	var (
+		repo        = repository.Q.NamedEntity
+		mentionRepo = repository.Q.NewsEntityMention
+		rows        []*namedEntityRow
+		newsIds     = repository.Q.NewsEntityMention.As("news_ids")
+	)
+
+	err = repo.WithContext(ctx).Select(repo.ID, repo.Type, repo.Name, mentionRepo.NewsId.Count().As("mentions")).
+		Join(mentionRepo, mentionRepo.EntityId.EqCol(repo.ID)).
+		Where(repo.ID.NotIn(entityIds...), mentionRepo.Columns(mentionRepo.NewsId).In(
+			newsIds.WithContext(ctx).Select(newsIds.NewsId).Where(newsIds.EntityId.In(entityIds...)),
+		)).
+		Group(repo.ID, repo.Type, repo.Name).Order(mentionRepo.NewsId.Count().Desc(), repo.ID).
+		Limit(params.Page.GetLimit()).Scan(&rows)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(rows, (*namedEntityRow).toEntity), nil
+}
+
+// findEntityIds finds the ids of the entities with the exact name.
+func (s *entityService) findEntityIds(ctx context.Context, params *valueobject.QueryEntityParams) ([]uint, error) {
+	var (
+		repo  = repository.Q.NamedEntity
+		query = repo.WithContext(ctx).Where(repo.Key.Eq(ner.Key(params.Name)))
+		ids   []uint
+	)
+
+	if params.Type != "" {
+		query = query.Where(repo.Type.Eq(string(params.Type)))
+	}
+
+	err := query.Pluck(repo.ID, &ids)
+
+	return ids, errors.WithStack(err)
+}
+
+// CountSharedEntities counts the entities shared with the other news, keeping the news sharing the most.
+func (s *entityService) CountSharedEntities(ctx context.Context, newsId uint, limit int) (map[uint]int, error) {
+	var (
+		repo     = repository.Q.NewsEntityMention
+		entities = repository.Q.NewsEntityMention.As("entities")
+		rows     []*sharedEntityRow
+	)
+
+	err := repo.WithContext(ctx).Select(repo.NewsId, repo.EntityId.Count().As("shared")).
+		Where(repo.NewsId.Neq(newsId), repo.Columns(repo.EntityId).In(
+			entities.WithContext(ctx).Select(entities.EntityId).Where(entities.NewsId.Eq(newsId)),
+		)).
+		Group(repo.NewsId).Order(repo.EntityId.Count().Desc(), repo.NewsId.Desc()).Limit(limit).Scan(&rows)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make(map[uint]int, len(rows))
+
+	for _, item := range rows {
+		result[item.NewsId] = item.Shared
+	}
+
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// NewsAnalysisService represents the interface for news analysis operations.
+type NewsAnalysisService interface {
+	CreateAnalysis(ctx context.Context, analysis *entity.NewsAnalysis) error
+	GetAnalysis(ctx context.Context, id uint) (*entity.NewsAnalysis, error)
+	QueryAnalyses(ctx context.Context, params *valueobject.QueryAnalysisParams) ([]*entity.NewsAnalysis, int64, error)
+	DeleteAnalysis(ctx context.Context, id uint) error
+}
+
+type newsAnalysisService struct {
+}
+
+func NewNewsAnalysisService() NewsAnalysisService {
+	return &newsAnalysisService{}
+}
+
+// CreateAnalysis creates the news analysis and links it to the analyzed news.
+func (s *newsAnalysisService) CreateAnalysis(ctx context.Context, analysis *entity.NewsAnalysis) error {
+	data, err := analysis.ToModel()
+	if err != nil {
+		return err
+	}
+
+	err = repository.Q.Transaction(func(tx *repository.Query) error {
+		if err := tx.NewsAnalysis.WithContext(ctx).Create(data); err != nil {
+			return err
+		}
+
+		links := gokit.SliceMap(analysis.NewsIds, func(newsId uint) *model.NewsAnalysisLink {
+			return &model.NewsAnalysisLink{AnalysisId: data.ID, NewsId: newsId, CreatedAt: time.Now()}
+		})
+
+		return tx.NewsAnalysisLink.WithContext(ctx).Create(links...)
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	analysis.Id = data.ID
+	analysis.CreatedAt = data.CreatedAt
+	analysis.UpdatedAt = data.UpdatedAt
+
+	return nil
+}
+
+// GetAnalysis gets the news analysis by id.
+func (s *newsAnalysisService) GetAnalysis(ctx context.Context, id uint) (*entity.NewsAnalysis, error) {
+	repo := repository.Q.NewsAnalysis
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NewsAnalysisNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return entity.NewNewsAnalysisFromModel(data)
+}
+
+// QueryAnalyses queries the news analyses, optionally of a news, the latest first.
+func (s *newsAnalysisService) QueryAnalyses(ctx context.Context, params *valueobject.QueryAnalysisParams) (
+	[]*entity.NewsAnalysis, int64, error) {
+	var (
+		repo     = repository.Q.NewsAnalysis
+		linkRepo = repository.Q.NewsAnalysisLink
+		query    = repo.WithContext(ctx)
+	)
+
+	if params.NewsId != 0 {
+		query = query.Where(repo.Columns(repo.ID).In(
+			linkRepo.WithContext(ctx).Select(linkRepo.AnalysisId).Where(linkRepo.NewsId.Eq(params.NewsId)),
+		))
+	}
+
+	if params.Type != "" {
+		query = query.Where(repo.Type.Eq(string(params.Type)))
+	}
+
+	data, total, err := query.Order(repo.ID.Desc()).FindByPage(params.Page.GetOffset(), params.Page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	analyses, err := gokit.SliceMapErr(data, entity.NewNewsAnalysisFromModel)
+
+	return analyses, total, err
+}
+
+// DeleteAnalysis deletes the news analysis and its news links.
+func (s *newsAnalysisService) DeleteAnalysis(ctx context.Context, id uint) error {
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		if _, err := tx.NewsAnalysis.WithContext(ctx).Where(tx.NewsAnalysis.ID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+
+		_, err := tx.NewsAnalysisLink.WithContext(ctx).Where(tx.NewsAnalysisLink.AnalysisId.Eq(id)).Delete()
+
+		return err
+	})
+
+	return errors.WithStack(err)
+}
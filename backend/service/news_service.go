@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/gocolly/colly/v2"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 
+	"github.com/mjiee/gokit"
+
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
@@ -20,8 +24,16 @@ type NewsService interface {
 	CreateNews(ctx context.Context, news ...*entity.NewsDetail) error
 	QueryNews(ctx context.Context, params *valueobject.QueryNewsParams) ([]*entity.NewsDetail, int64, error)
 	GetNewsDetail(ctx context.Context, id uint) (*entity.NewsDetail, error)
+	GetNewsByIds(ctx context.Context, ids ...uint) ([]*entity.NewsDetail, error)
 	DeleteNews(ctx context.Context, id uint) error
 	UpdateNewsFavorite(ctx context.Context, id uint, favorited bool) error
+	RefreshNewsDetail(ctx context.Context, id uint) (*entity.NewsDetail, error)
+	RetryFailedScrapes(ctx context.Context, limit int) (int, error)
+	QueryNewsRevisions(ctx context.Context, newsId uint) ([]*entity.NewsRevision, error)
+	DiffNewsRevisions(ctx context.Context, newsId uint, from, to int) (*entity.NewsRevisionDiff, error)
+	GetNewsSummary(ctx context.Context, newsId uint, language string) (*entity.NewsSummary, error)
+	SaveNewsSummary(ctx context.Context, summary *entity.NewsSummary) error
+	QueryRelatedCandidates(ctx context.Context, news *entity.NewsDetail, ids []uint) ([]*entity.NewsDetail, error)
 }
 
 type newsService struct {
@@ -97,83 +109,439 @@ func (s *newsService) QueryNews(ctx context.Context, params *valueobject.QueryNe
 		}
 	}
 
+	if err := s.attachSummaries(ctx, news, params.SummaryLanguage); err != nil {
+		return nil, 0, err
+	}
+
 	return news, total, nil
 }
 
-// GetNewsDetail retrieves the news detail based on the provided ID.
-func (s *newsService) GetNewsDetail(ctx context.Context, id uint) (*entity.NewsDetail, error) {
-	repo := repository.Q.NewsDetail
+// QueryRelatedCandidates queries the news that may relate to the news,
+// the given news and the news published within the related window, excluding the news itself.
+func (s *newsService) QueryRelatedCandidates(ctx context.Context, news *entity.NewsDetail, ids []uint) (
+	[]*entity.NewsDetail, error) {
+	var (
+		repo   = repository.Q.NewsDetail
+		result = make([]*model.NewsDetail, 0, valueobject.MaxRelatedCandidates)
+	)
 
-	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errorx.NewsNotFound
+	if len(ids) > 0 {
+		data, err := repo.WithContext(ctx).Where(repo.ID.In(ids...), repo.ID.Neq(news.Id)).Find()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		result = append(result, data...)
+	}
+
+	if limit := valueobject.MaxRelatedCandidates - len(result); limit > 0 {
+		data, err := repo.WithContext(ctx).Where(
+			repo.ID.Neq(news.Id),
+			repo.PublishedAt.Between(news.PublishedAt.Add(-valueobject.RelatedNewsWindow),
+				news.PublishedAt.Add(valueobject.RelatedNewsWindow)),
+		).Order(repo.ID.Desc()).Limit(limit).Find()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for _, item := range data {
+			if !slices.Contains(ids, item.ID) {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return gokit.SliceMapErr(result, entity.NewNewsDetailFromModel)
+}
+
+// attachSummaries attaches the cached summaries to the news, preferring the language.
+func (s *newsService) attachSummaries(ctx context.Context, news []*entity.NewsDetail, language string) error {
+	if len(news) == 0 {
+		return nil
+	}
+
+	repo := repository.Q.NewsSummary
+
+	data, err := repo.WithContext(ctx).
+		Where(repo.NewsId.In(gokit.SliceMap(news, func(item *entity.NewsDetail) uint { return item.Id })...)).
+		Order(repo.UpdatedAt.Desc()).Find()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	summaries := make(map[uint]*entity.NewsSummary, len(data))
+
+	for _, item := range data {
+		current, ok := summaries[item.NewsId]
+		if ok && (current.Language == language || item.Language != language) {
+			continue
+		}
+
+		summaries[item.NewsId], err = entity.NewNewsSummaryFromModel(item)
+		if err != nil {
+			return err
+		}
 	}
 
+	for _, item := range news {
+		item.Summary = summaries[item.Id]
+	}
+
+	return nil
+}
+
+// GetNewsSummary gets the cached summary of the news in the language.
+func (s *newsService) GetNewsSummary(ctx context.Context, newsId uint, language string) (*entity.NewsSummary, error) {
+	repo := repository.Q.NewsSummary
+
+	data, err := repo.WithContext(ctx).Where(repo.NewsId.Eq(newsId), repo.Language.Eq(language)).First()
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NewsSummaryNotFound
+		}
+
 		return nil, errors.WithStack(err)
 	}
 
-	news, err := entity.NewNewsDetailFromModel(data)
+	return entity.NewNewsSummaryFromModel(data)
+}
+
+// SaveNewsSummary saves the summary of the news, replacing the previous one in the same language.
+func (s *newsService) SaveNewsSummary(ctx context.Context, summary *entity.NewsSummary) error {
+	data, err := summary.ToModel()
+	if err != nil {
+		return err
+	}
+
+	repo := repository.Q.NewsSummary
+
+	current, err := repo.WithContext(ctx).Where(repo.NewsId.Eq(data.NewsId), repo.Language.Eq(data.Language)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.WithStack(err)
+	}
+
+	if current != nil {
+		data.ID, data.CreatedAt = current.ID, current.CreatedAt
+	}
+
+	if err := repo.WithContext(ctx).Save(data); err != nil {
+		return errors.WithStack(err)
+	}
+
+	summary.Id = data.ID
+
+	return nil
+}
+
+// GetNewsDetail retrieves the news detail based on the provided ID, scraping the detail page on the first read.
+// A failed scrape is recorded for RetryFailedScrapes, the news keeps the summary of the list page and is marked
+// as failing to the caller.
+func (s *newsService) GetNewsDetail(ctx context.Context, id uint) (*entity.NewsDetail, error) {
+	news, err := s.getNews(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if news.Scraped {
+	if news.Scraped || !news.CanScrape(time.Now()) {
 		return news, nil
 	}
 
-	return s.crawlingNewsDetail(ctx, news)
+	if err := s.scrapeNewsDetail(ctx, news); err != nil {
+		logx.WithContext(ctx).Error("GetNewsDetail.scrapeNewsDetail", err)
+	}
+
+	return news, nil
 }
 
-// DeleteNews deletes the news detail based on the provided ID.
-func (s *newsService) DeleteNews(ctx context.Context, id uint) error {
-	err := repository.Q.Transaction(func(tx *repository.Query) error {
-		if _, err := tx.NewsDetail.WithContext(ctx).Where(tx.NewsDetail.ID.Eq(id)).Delete(); err != nil {
-			return err
+// GetNewsByIds retrieves the stored news details in the order of the ids without scraping, the missing news
+// are skipped.
+func (s *newsService) GetNewsByIds(ctx context.Context, ids ...uint) ([]*entity.NewsDetail, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	slices.SortFunc(data, func(a, b *model.NewsDetail) int {
+		return slices.Index(ids, a.ID) - slices.Index(ids, b.ID)
+	})
+
+	return gokit.SliceMapErr(data, entity.NewNewsDetailFromModel)
+}
+
+// RefreshNewsDetail scrapes the news detail page again, recording a revision if the content changed.
+func (s *newsService) RefreshNewsDetail(ctx context.Context, id uint) (*entity.NewsDetail, error) {
+	news, err := s.getNews(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.scrapeNewsDetail(ctx, news); err != nil {
+		logx.WithContext(ctx).Error("RefreshNewsDetail.scrapeNewsDetail", err)
+
+		return nil, errorx.ScrapeNewsFailed
+	}
+
+	return news, nil
+}
+
+// RetryFailedScrapes retries the failed scrapes whose backoff has expired, returning the number of successes.
+func (s *newsService) RetryFailedScrapes(ctx context.Context, limit int) (int, error) {
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).Where(
+		repo.Scraped.Is(false),
+		repo.ScrapeAttempts.Gt(0),
+		repo.ScrapeAttempts.Lt(valueobject.MaxScrapeAttempts),
+		repo.NextScrapeAt.Lte(time.Now()),
+	).Order(repo.NextScrapeAt).Limit(limit).Find()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	succeeded := 0
+
+	for _, item := range data {
+		news, err := entity.NewNewsDetailFromModel(item)
+		if err != nil {
+			return succeeded, err
 		}
 
-		if _, err := tx.PodcastTask.WithContext(ctx).Where(tx.PodcastTask.NewsId.Eq(id)).Delete(); err != nil {
-			return err
+		if err := s.scrapeNewsDetail(ctx, news); err != nil {
+			logx.WithContext(ctx).Error("RetryFailedScrapes.scrapeNewsDetail", err)
+
+			continue
 		}
 
-		return nil
+		succeeded++
+	}
+
+	return succeeded, nil
+}
+
+// getNews retrieves the stored news detail without scraping.
+func (s *newsService) getNews(ctx context.Context, id uint) (*entity.NewsDetail, error) {
+	repo := repository.Q.NewsDetail
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorx.NewsNotFound
+	}
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return entity.NewNewsDetailFromModel(data)
+}
+
+// DeleteNews deletes the news detail based on the provided ID.
+func (s *newsService) DeleteNews(ctx context.Context, id uint) error {
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		return deleteNewsWithDependents(ctx, tx, id)
 	})
 
 	return errors.WithStack(err)
 }
 
-// crawlingNewsDetail crawls the news detail.
-func (s *newsService) crawlingNewsDetail(ctx context.Context, news *entity.NewsDetail) (*entity.NewsDetail, error) {
-	s.collector.OnHTML(valueobject.Html, func(e *colly.HTMLElement) {
+// deleteNewsWithDependents deletes the news details together with their podcast tasks, revisions, entity mentions,
+// summaries, embeddings and analysis links. All delete paths of the news go through it.
+func deleteNewsWithDependents(ctx context.Context, tx *repository.Query, ids ...uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := tx.NewsDetail.WithContext(ctx).Where(tx.NewsDetail.ID.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	if _, err := tx.PodcastTask.WithContext(ctx).Where(tx.PodcastTask.NewsId.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewsRevision.WithContext(ctx).Where(tx.NewsRevision.NewsId.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	mention := tx.NewsEntityMention
+
+	if _, err := mention.WithContext(ctx).Where(mention.NewsId.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewsSummary.WithContext(ctx).Where(tx.NewsSummary.NewsId.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewsEmbedding.WithContext(ctx).Where(tx.NewsEmbedding.NewsId.In(ids...)).Delete(); err != nil {
+		return err
+	}
+
+	_, err := tx.NewsAnalysisLink.WithContext(ctx).Where(tx.NewsAnalysisLink.NewsId.In(ids...)).Delete()
+
+	return err
+}
+
+// scrapeNewsDetail scrapes the news detail page, scheduling a retry if it fails.
+func (s *newsService) scrapeNewsDetail(ctx context.Context, news *entity.NewsDetail) error {
+	var (
+		collector = s.collector.Clone()
+		page      = &entity.NewsDetail{Link: news.Link, Author: news.Author, Images: news.Images,
+			PublishedAt: news.PublishedAt}
+	)
+
+	collector.OnHTML(valueobject.Html, func(e *colly.HTMLElement) {
 		doc := e.DOM
 
 		for _, selector := range valueobject.ExcludeSelectors {
 			doc.Find(selector).Remove()
 		}
 
-		news.ExtractContents(doc)
+		page.ExtractTitle(doc)
+
+		if page.Title == "" {
+			page.Title = news.Title
+		}
+
+		page.ExtractContents(doc)
 	})
 
-	if err := s.collector.Visit(news.Link); err != nil {
-		logx.Error("crawlingNewsDetail:"+news.Link, err)
+	err := collector.Visit(news.Link)
+	if err == nil && len(page.Contents) == 0 {
+		err = errors.Errorf("no contents found: %s", news.Link)
+	}
 
-		return news, nil
+	if err != nil {
+		news.ScrapeFailed(time.Now())
+
+		if updateErr := s.updateScrapeState(ctx, news); updateErr != nil {
+			return updateErr
+		}
+
+		return errors.WithMessage(err, news.Link)
 	}
 
-	// update news
-	news.Scraped = true
+	return s.saveScrapedNews(ctx, news, page)
+}
+
+// saveScrapedNews saves the scraped contents, recording a revision if the page changed.
+func (s *newsService) saveScrapedNews(ctx context.Context, news, page *entity.NewsDetail) error {
+	latest, err := s.latestNewsRevision(ctx, news.Id)
+	if err != nil {
+		return err
+	}
+
+	// the first revision keeps the title of the list page, later headline edits are applied
+	if latest != nil && latest.Title != page.Title {
+		news.Title = page.Title
+	}
+
+	news.ScrapeSucceeded(page)
 
 	data, err := news.ToModel()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	revision := entity.NewNewsRevision(news.Id, page, latest)
+
+	err = repository.Q.Transaction(func(tx *repository.Query) error {
+		repo := tx.NewsDetail
+
+		_, err := repo.WithContext(ctx).Where(repo.ID.Eq(news.Id)).Select(repo.Title, repo.Author, repo.Contents,
+			repo.Images, repo.Scraped, repo.ScrapeAttempts, repo.NextScrapeAt).Updates(data)
+		if err != nil {
+			return err
+		}
+
+		if !revision.Changed(latest) {
+			return nil
+		}
+
+		m, err := revision.ToModel()
+		if err != nil {
+			return err
+		}
+
+		return tx.NewsRevision.WithContext(ctx).Create(m)
+	})
+
+	return errors.WithStack(err)
+}
+
+// updateScrapeState updates the retry state of the failed scrape.
+func (s *newsService) updateScrapeState(ctx context.Context, news *entity.NewsDetail) error {
+	data, err := news.ToModel()
+	if err != nil {
+		return err
+	}
+
+	repo := repository.Q.NewsDetail
+
+	_, err = repo.WithContext(ctx).Where(repo.ID.Eq(news.Id)).Select(repo.ScrapeAttempts, repo.NextScrapeAt).
+		Updates(data)
+
+	return errors.WithStack(err)
+}
+
+// latestNewsRevision retrieves the latest revision of the news, nil if there is none.
+func (s *newsService) latestNewsRevision(ctx context.Context, newsId uint) (*entity.NewsRevision, error) {
+	repo := repository.Q.NewsRevision
+
+	data, err := repo.WithContext(ctx).Where(repo.NewsId.Eq(newsId)).Order(repo.Revision.Desc()).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
 	}
 
-	_, err = repository.Q.NewsDetail.WithContext(ctx).Updates(data)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	return news, nil
+	return entity.NewNewsRevisionFromModel(data)
+}
+
+// QueryNewsRevisions queries the revisions of the news, the latest first.
+func (s *newsService) QueryNewsRevisions(ctx context.Context, newsId uint) ([]*entity.NewsRevision, error) {
+	repo := repository.Q.NewsRevision
+
+	data, err := repo.WithContext(ctx).Where(repo.NewsId.Eq(newsId)).Order(repo.Revision.Desc()).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMapErr(data, entity.NewNewsRevisionFromModel)
+}
+
+// DiffNewsRevisions compares two revisions of the news.
+func (s *newsService) DiffNewsRevisions(ctx context.Context, newsId uint, from, to int) (
+	*entity.NewsRevisionDiff, error) {
+	repo := repository.Q.NewsRevision
+
+	data, err := repo.WithContext(ctx).Where(repo.NewsId.Eq(newsId), repo.Revision.In(from, to)).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	revisions, err := gokit.SliceMapErr(data, entity.NewNewsRevisionFromModel)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fromRevision = gokit.SliceFind(revisions, func(r *entity.NewsRevision) bool { return r.Revision == from })
+		toRevision   = gokit.SliceFind(revisions, func(r *entity.NewsRevision) bool { return r.Revision == to })
+	)
+
+	if fromRevision == nil || toRevision == nil {
+		return nil, errorx.NewsRevisionNotFound
+	}
+
+	return entity.NewNewsRevisionDiff(fromRevision, toRevision), nil
 }
 
 // UpdateNewsFavorite updates the favorite status of the news detail.
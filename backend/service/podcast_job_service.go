@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository"
+	"github.com/mjiee/world-news/backend/repository/model"
+)
+
+// PodcastJobService represents the interface for the podcast job queue.
+type PodcastJobService interface {
+	EnqueueJob(ctx context.Context, job *entity.PodcastJob) error
+	ClaimJob(ctx context.Context, worker string) (*entity.PodcastJob, error)
+	RenewJob(ctx context.Context, job *entity.PodcastJob) error
+	FinishJob(ctx context.Context, job *entity.PodcastJob) error
+	SaveJob(ctx context.Context, job *entity.PodcastJob) error
+	GetStaleJobs(ctx context.Context) ([]*entity.PodcastJob, error)
+	GetWaitingJobs(ctx context.Context, kind valueobject.JobKind) ([]*entity.PodcastJob, error)
+	QueueWaitingJob(ctx context.Context, job *entity.PodcastJob) error
+	HasActiveJob(ctx context.Context, batchNo string) (bool, error)
+	CancelJobs(ctx context.Context, batchNo string) error
+	QueryJobs(ctx context.Context, params *valueobject.QueryJobParams) ([]*entity.PodcastJob, int64, error)
+}
+
+type podcastJobService struct {
+}
+
+func NewPodcastJobService() PodcastJobService {
+	return &podcastJobService{}
+}
+
+// EnqueueJob adds the job to the queue.
+func (s *podcastJobService) EnqueueJob(ctx context.Context, job *entity.PodcastJob) error {
+	return s.SaveJob(ctx, job)
+}
+
+// SaveJob saves the job.
+func (s *podcastJobService) SaveJob(ctx context.Context, job *entity.PodcastJob) error {
+	data := job.ToModel()
+	data.UpdatedAt = time.Now()
+
+	if err := repository.Q.PodcastJob.WithContext(ctx).Save(data); err != nil {
+		return errors.WithStack(err)
+	}
+
+	job.Id = data.ID
+
+	return nil
+}
+
+// ClaimJob leases the oldest queued job to the worker, nil if the queue is empty.
+// The job is claimed only if it is still queued, so that the workers sharing the database never run the same job.
+func (s *podcastJobService) ClaimJob(ctx context.Context, worker string) (*entity.PodcastJob, error) {
+	repo := repository.Q.PodcastJob
+
+	for {
+		data, err := repo.WithContext(ctx).Where(repo.Status.Eq(string(valueobject.JobStatusQueued))).
+			Order(repo.ID).First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		job := entity.NewPodcastJobFromModel(data)
+		job.Status = valueobject.JobStatusRunning
+		job.Worker = worker
+		job.Attempts++
+		job.LeaseUntil = time.Now().Add(valueobject.JobLease)
+		job.UpdatedAt = time.Now()
+
+		result, err := repo.WithContext(ctx).
+			Where(repo.ID.Eq(job.Id), repo.Status.Eq(string(valueobject.JobStatusQueued))).
+			Select(repo.Status, repo.Worker, repo.Attempts, repo.LeaseUntil, repo.UpdatedAt).Updates(job.ToModel())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		// claimed by another worker
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		return job, nil
+	}
+}
+
+// RenewJob extends the lease of the running job, fails if the job is no longer owned by its worker.
+func (s *podcastJobService) RenewJob(ctx context.Context, job *entity.PodcastJob) error {
+	repo := repository.Q.PodcastJob
+
+	job.LeaseUntil = time.Now().Add(valueobject.JobLease)
+
+	result, err := repo.WithContext(ctx).Where(s.ownedBy(job)...).Select(repo.LeaseUntil).Updates(job.ToModel())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if result.RowsAffected == 0 {
+		return errorx.JobLeaseLost
+	}
+
+	return nil
+}
+
+// FinishJob saves the result of the job run by its worker.
+func (s *podcastJobService) FinishJob(ctx context.Context, job *entity.PodcastJob) error {
+	repo := repository.Q.PodcastJob
+
+	job.UpdatedAt = time.Now()
+
+	_, err := repo.WithContext(ctx).Where(s.ownedBy(job)...).Select(repo.Status, repo.Error, repo.UpdatedAt).
+		Updates(job.ToModel())
+
+	return errors.WithStack(err)
+}
+
+// ownedBy the conditions of the job running by its worker
+func (s *podcastJobService) ownedBy(job *entity.PodcastJob) []gen.Condition {
+	repo := repository.Q.PodcastJob
+
+	return []gen.Condition{repo.ID.Eq(job.Id), repo.Worker.Eq(job.Worker),
+		repo.Status.Eq(string(valueobject.JobStatusRunning))}
+}
+
+// GetStaleJobs gets the running jobs whose worker stopped sending heartbeats.
+func (s *podcastJobService) GetStaleJobs(ctx context.Context) ([]*entity.PodcastJob, error) {
+	repo := repository.Q.PodcastJob
+
+	data, err := repo.WithContext(ctx).Where(repo.Status.Eq(string(valueobject.JobStatusRunning)),
+		repo.LeaseUntil.Lt(time.Now())).Order(repo.ID).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(data, entity.NewPodcastJobFromModel), nil
+}
+
+// GetWaitingJobs gets the jobs of the kind waiting for the jobs they depend on.
+func (s *podcastJobService) GetWaitingJobs(ctx context.Context, kind valueobject.JobKind) (
+	[]*entity.PodcastJob, error) {
+	repo := repository.Q.PodcastJob
+
+	data, err := repo.WithContext(ctx).Where(repo.Kind.Eq(string(kind)),
+		repo.Status.Eq(string(valueobject.JobStatusWaiting))).Order(repo.ID).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(data, entity.NewPodcastJobFromModel), nil
+}
+
+// QueueWaitingJob puts the waiting job to the queue, unless it is no longer waiting, e.g. cancelled.
+func (s *podcastJobService) QueueWaitingJob(ctx context.Context, job *entity.PodcastJob) error {
+	repo := repository.Q.PodcastJob
+
+	job.Status = valueobject.JobStatusQueued
+	job.UpdatedAt = time.Now()
+
+	_, err := repo.WithContext(ctx).
+		Where(repo.ID.Eq(job.Id), repo.Status.Eq(string(valueobject.JobStatusWaiting))).
+		Select(repo.Status, repo.UpdatedAt).Updates(job.ToModel())
+
+	return errors.WithStack(err)
+}
+
+// HasActiveJob checks whether the task has a waiting, queued or running job.
+func (s *podcastJobService) HasActiveJob(ctx context.Context, batchNo string) (bool, error) {
+	repo := repository.Q.PodcastJob
+
+	count, err := repo.WithContext(ctx).Where(repo.BatchNo.Eq(batchNo), repo.Status.In(
+		string(valueobject.JobStatusWaiting), string(valueobject.JobStatusQueued),
+		string(valueobject.JobStatusRunning))).Count()
+
+	return count > 0, errors.WithStack(err)
+}
+
+// CancelJobs cancels the waiting and the queued jobs of the task.
+func (s *podcastJobService) CancelJobs(ctx context.Context, batchNo string) error {
+	repo := repository.Q.PodcastJob
+
+	_, err := repo.WithContext(ctx).
+		Where(repo.BatchNo.Eq(batchNo), repo.Status.In(string(valueobject.JobStatusWaiting),
+			string(valueobject.JobStatusQueued))).
+		Select(repo.Status, repo.UpdatedAt).
+		Updates(&model.PodcastJob{Status: string(valueobject.JobStatusCancelled), UpdatedAt: time.Now()})
+
+	return errors.WithStack(err)
+}
+
+// QueryJobs queries the jobs, the latest first.
+func (s *podcastJobService) QueryJobs(ctx context.Context, params *valueobject.QueryJobParams) (
+	[]*entity.PodcastJob, int64, error) {
+	var (
+		repo  = repository.Q.PodcastJob
+		query = repo.WithContext(ctx)
+	)
+
+	if params.BatchNo != "" {
+		query = query.Where(repo.BatchNo.Eq(params.BatchNo))
+	}
+
+	if params.Status != "" {
+		query = query.Where(repo.Status.Eq(string(params.Status)))
+	}
+
+	data, total, err := query.Order(repo.ID.Desc()).FindByPage(params.Page.GetOffset(), params.Page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(data, entity.NewPodcastJobFromModel), total, nil
+}
@@ -1,7 +1,31 @@
 package service
 
-// PodcastService represents the podcast service.
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/audio"
+	"github.com/mjiee/world-news/backend/pkg/config"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/pathx"
+	"github.com/mjiee/world-news/backend/repository"
+)
+
+// PodcastService represents the published podcast service.
 type PodcastService interface {
+	CreatePodcast(ctx context.Context, podcast *entity.Podcast) error
+	SavePodcast(ctx context.Context, podcast *entity.Podcast) error
+	GetPodcast(ctx context.Context, id uint) (*entity.Podcast, error)
+	HasPodcast(ctx context.Context, batchNo string) (bool, error)
+	QueryPodcasts(ctx context.Context, params *valueobject.QueryPodcastParams) ([]*entity.Podcast, int64, error)
+	DeletePodcast(ctx context.Context, id uint) error
 }
 
 type podcastService struct {
@@ -10,3 +34,118 @@ type podcastService struct {
 func NewPodcastService() PodcastService {
 	return &podcastService{}
 }
+
+// CreatePodcast creates the podcast with a copy of the task audio, the audio is kept once the task is deleted.
+func (s *podcastService) CreatePodcast(ctx context.Context, podcast *entity.Podcast) error {
+	podcastPath, err := pathx.GetAppBasePath(config.AppName, pathx.PodcastDir)
+	if err != nil {
+		return err
+	}
+
+	audioFile := podcast.Audio.Url
+
+	in, err := os.ReadFile(audioFile)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	podcast.Audio.Url = filepath.Join(podcastPath, fmt.Sprintf("%s%s", podcast.BatchNo, filepath.Ext(audioFile)))
+
+	if err := audio.SaveAudio(in, podcast.Audio.Url); err != nil {
+		return err
+	}
+
+	data := podcast.ToModel()
+
+	if err := repository.Q.Podcast.WithContext(ctx).Create(data); err != nil {
+		_ = os.Remove(podcast.Audio.Url)
+
+		return errors.WithStack(err)
+	}
+
+	podcast.Id, podcast.CreatedAt, podcast.UpdatedAt = data.ID, data.CreatedAt, data.UpdatedAt
+
+	return nil
+}
+
+// SavePodcast saves all fields of the podcast.
+func (s *podcastService) SavePodcast(ctx context.Context, podcast *entity.Podcast) error {
+	return errors.WithStack(repository.Q.Podcast.WithContext(ctx).Save(podcast.ToModel()))
+}
+
+// GetPodcast gets the podcast by id.
+func (s *podcastService) GetPodcast(ctx context.Context, id uint) (*entity.Podcast, error) {
+	repo := repository.Q.Podcast
+
+	data, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.PodcastNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return entity.NewPodcastFromModel(data)
+}
+
+// HasPodcast checks whether the task has been published.
+func (s *podcastService) HasPodcast(ctx context.Context, batchNo string) (bool, error) {
+	repo := repository.Q.Podcast
+
+	count, err := repo.WithContext(ctx).Where(repo.BatchNo.Eq(batchNo)).Count()
+
+	return count > 0, errors.WithStack(err)
+}
+
+// QueryPodcasts queries the published podcasts, the latest first.
+func (s *podcastService) QueryPodcasts(ctx context.Context, params *valueobject.QueryPodcastParams) (
+	[]*entity.Podcast, int64, error) {
+	var (
+		repo  = repository.Q.Podcast
+		query = repo.WithContext(ctx)
+	)
+
+	if params.Language != "" {
+		query = query.Where(repo.Language.Eq(params.Language))
+	}
+
+	if params.Style != "" {
+		query = query.Where(repo.Style.Eq(params.Style))
+	}
+
+	data, total, err := query.Order(repo.ID.Desc()).FindByPage(params.Page.GetOffset(), params.Page.GetLimit())
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+
+	podcasts := make([]*entity.Podcast, len(data))
+
+	for i, v := range data {
+		if podcasts[i], err = entity.NewPodcastFromModel(v); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return podcasts, total, nil
+}
+
+// DeletePodcast unpublishes the podcast, removing its audio file.
+func (s *podcastService) DeletePodcast(ctx context.Context, id uint) error {
+	podcast, err := s.GetPodcast(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	repo := repository.Q.Podcast
+
+	if _, err := repo.WithContext(ctx).Where(repo.ID.Eq(id)).Delete(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if podcast.Audio != nil && podcast.Audio.Url != "" {
+		_ = os.Remove(podcast.Audio.Url)
+	}
+
+	return nil
+}
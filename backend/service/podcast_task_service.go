@@ -6,8 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 
 	"github.com/mjiee/gokit"
 
@@ -33,6 +35,8 @@ type PodcastTaskService interface {
 	GetTaskByStageId(ctx context.Context, stageId uint) (*entity.PodcastTask, error)
 	QueryTasks(ctx context.Context, params *valueobject.QueryPodcastTaskParams) ([]*entity.PodcastTask, int64, error)
 	HasProcessingTasks(ctx context.Context, newsId uint) (bool, error)
+	GetProcessingBatchNos(ctx context.Context, updatedBefore time.Time) ([]string, error)
+	HasTaskTitle(ctx context.Context, title string) (bool, error)
 	NewsHasTask(ctx context.Context, newsId uint) (bool, error)
 	DownloadAudio(ctx context.Context, stageId uint, fileName string) error
 	DeleteTaskStage(ctx context.Context, stageId uint) error
@@ -116,6 +120,44 @@ func (s *podcastTaskService) HasProcessingTasks(ctx context.Context, newsId uint
 	return count > 0, errors.WithStack(err)
 }
 
+// HasTaskTitle checks whether a task of the title exists
+func (s *podcastTaskService) HasTaskTitle(ctx context.Context, title string) (bool, error) {
+	repo := repository.Q.PodcastTask
+
+	count, err := repo.WithContext(ctx).Where(repo.Title.Eq(title)).Count()
+
+	return count > 0, errors.WithStack(err)
+}
+
+// GetProcessingBatchNos get the batch nos of the tasks with processing stages not updated since the time.
+// The tasks paused for review, or ended by a failed, rejected or cancelled stage, are left out, their later stages
+// stay processing until the task is resumed.
+func (s *podcastTaskService) GetProcessingBatchNos(ctx context.Context, updatedBefore time.Time) ([]string, error) {
+	var (
+		batchNos      []string
+		pausedBatches []string
+		repo          = repository.Q.PodcastTask
+	)
+
+	err := repo.WithContext(ctx).Distinct(repo.BatchNo).
+		Where(repo.Status.Eq(string(valueobject.StageStatusProcessing)), repo.UpdatedAt.Lt(updatedBefore)).
+		Pluck(repo.BatchNo, &batchNos)
+	if err != nil || len(batchNos) == 0 {
+		return batchNos, errors.WithStack(err)
+	}
+
+	err = repo.WithContext(ctx).Distinct(repo.BatchNo).Where(repo.BatchNo.In(batchNos...),
+		repo.Status.In(string(valueobject.StageStatusReview), string(valueobject.StageStatusFailed),
+			string(valueobject.StageStatusCancelled))).Pluck(repo.BatchNo, &pausedBatches)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceFilter(batchNos, func(batchNo string) bool {
+		return !slices.Contains(pausedBatches, batchNo)
+	}), nil
+}
+
 // NewsHasTask check whether the news has task
 func (s *podcastTaskService) NewsHasTask(ctx context.Context, newsId uint) (bool, error) {
 	repo := repository.Q.PodcastTask
@@ -131,6 +173,10 @@ func (s *podcastTaskService) GetTaskByStageId(ctx context.Context, stageId uint)
 
 	stage, err := repo.WithContext(ctx).Where(repo.ID.Eq(stageId)).First()
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.PodcastTaskNotFound
+		}
+
 		return nil, errors.WithStack(err)
 	}
 
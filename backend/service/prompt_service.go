@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/mjiee/gokit"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/repository"
+)
+
+// PromptService represents the interface for prompt template operations.
+type PromptService interface {
+	SavePrompt(ctx context.Context, prompt *entity.PromptTemplate) error
+	GetPrompt(ctx context.Context, name valueobject.PromptName, language string) (*entity.PromptTemplate, error)
+	GetPrompts(ctx context.Context, language string, names ...valueobject.PromptName) (
+		map[valueobject.PromptName]*entity.PromptTemplate, error)
+	QueryPrompts(ctx context.Context) ([]*entity.PromptTemplate, error)
+	QueryPromptVersions(ctx context.Context, name valueobject.PromptName, language string) (
+		[]*entity.PromptTemplate, error)
+	RollbackPrompt(ctx context.Context, name valueobject.PromptName, language string, version int) (
+		*entity.PromptTemplate, error)
+	DeletePrompt(ctx context.Context, name valueobject.PromptName, language string) error
+}
+
+type promptService struct {
+}
+
+func NewPromptService() PromptService {
+	return &promptService{}
+}
+
+// SavePrompt saves the prompt template as the next version of its variant.
+func (s *promptService) SavePrompt(ctx context.Context, prompt *entity.PromptTemplate) error {
+	err := repository.Q.Transaction(func(tx *repository.Query) error {
+		repo := tx.PromptTemplate
+
+		latest, err := repo.WithContext(ctx).
+			Where(repo.Name.Eq(string(prompt.Name)), repo.Language.Eq(prompt.Language)).
+			Order(repo.Version.Desc()).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		prompt.Id, prompt.Version = 0, 1
+
+		if latest != nil {
+			prompt.Version = latest.Version + 1
+		}
+
+		data := prompt.ToModel()
+
+		if err := repo.WithContext(ctx).Create(data); err != nil {
+			return err
+		}
+
+		prompt.Id = data.ID
+		prompt.CreatedAt = data.CreatedAt
+
+		return nil
+	})
+
+	return errors.WithStack(err)
+}
+
+// GetPrompt gets the latest version of the prompt template in the language, falling back to the default variant.
+func (s *promptService) GetPrompt(ctx context.Context, name valueobject.PromptName, language string) (
+	*entity.PromptTemplate, error) {
+	prompts, err := s.GetPrompts(ctx, language, name)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, ok := prompts[name]
+	if !ok {
+		return nil, errorx.PromptTemplateNotFound
+	}
+
+	return prompt, nil
+}
+
+// GetPrompts gets the latest versions of the prompt templates in the language, falling back to the default variants.
+// The names without a template are absent from the result.
+func (s *promptService) GetPrompts(ctx context.Context, language string, names ...valueobject.PromptName) (
+	map[valueobject.PromptName]*entity.PromptTemplate, error) {
+	repo := repository.Q.PromptTemplate
+
+	data, err := repo.WithContext(ctx).
+		Where(repo.Name.In(gokit.SliceMap(names, func(name valueobject.PromptName) string { return string(name) })...),
+			repo.Language.In(language, "")).
+		Order(repo.Version.Desc()).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make(map[valueobject.PromptName]*entity.PromptTemplate, len(names))
+
+	for _, item := range data {
+		prompt := entity.NewPromptTemplateFromModel(item)
+
+		// the language variant takes precedence over the default variant
+		current, ok := result[prompt.Name]
+		if !ok || (current.Language == "" && prompt.Language != "") {
+			result[prompt.Name] = prompt
+		}
+	}
+
+	return result, nil
+}
+
+// QueryPrompts queries the latest version of every prompt template variant.
+func (s *promptService) QueryPrompts(ctx context.Context) ([]*entity.PromptTemplate, error) {
+	repo := repository.Q.PromptTemplate
+
+	data, err := repo.WithContext(ctx).Order(repo.Name, repo.Language, repo.Version.Desc()).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]*entity.PromptTemplate, 0)
+
+	for _, item := range data {
+		if n := len(result); n > 0 && string(result[n-1].Name) == item.Name && result[n-1].Language == item.Language {
+			continue
+		}
+
+		result = append(result, entity.NewPromptTemplateFromModel(item))
+	}
+
+	return result, nil
+}
+
+// QueryPromptVersions queries the version history of the prompt template variant, the latest first.
+func (s *promptService) QueryPromptVersions(ctx context.Context, name valueobject.PromptName, language string) (
+	[]*entity.PromptTemplate, error) {
+	repo := repository.Q.PromptTemplate
+
+	data, err := repo.WithContext(ctx).Where(repo.Name.Eq(string(name)), repo.Language.Eq(language)).
+		Order(repo.Version.Desc()).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return gokit.SliceMap(data, entity.NewPromptTemplateFromModel), nil
+}
+
+// RollbackPrompt restores a previous version of the prompt template variant by saving it as the latest version.
+func (s *promptService) RollbackPrompt(ctx context.Context, name valueobject.PromptName, language string,
+	version int) (*entity.PromptTemplate, error) {
+	repo := repository.Q.PromptTemplate
+
+	data, err := repo.WithContext(ctx).
+		Where(repo.Name.Eq(string(name)), repo.Language.Eq(language), repo.Version.Eq(version)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.PromptTemplateNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	prompt := entity.NewPromptTemplateFromModel(data)
+	prompt.Note = fmt.Sprintf("rollback to version %d", version)
+
+	if err := s.SavePrompt(ctx, prompt); err != nil {
+		return nil, err
+	}
+
+	return prompt, nil
+}
+
+// DeletePrompt deletes all versions of the prompt template variant.
+func (s *promptService) DeletePrompt(ctx context.Context, name valueobject.PromptName, language string) error {
+	repo := repository.Q.PromptTemplate
+
+	_, err := repo.WithContext(ctx).Where(repo.Name.Eq(string(name)), repo.Language.Eq(language)).Delete()
+
+	return errors.WithStack(err)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/mjiee/world-news/backend/entity"
 	"github.com/mjiee/world-news/backend/entity/valueobject"
 	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/locale"
 	"github.com/mjiee/world-news/backend/pkg/openai"
 	"github.com/mjiee/world-news/backend/pkg/ttsai"
 	"github.com/mjiee/world-news/backend/pkg/urlx"
@@ -28,6 +29,14 @@ type SystemConfigService interface {
 	UpdateNewsWebsiteWeight(ctx context.Context, website string, step int) error
 	SaveNewsWebsites(ctx context.Context, newsWebsites []*valueobject.NewsWebsite) error
 	GetPodcastConfig(ctx context.Context) (*openai.Config, *ttsai.Config, *valueobject.PodcastScriptPrompt, error)
+	GetRetentionPolicy(ctx context.Context) (*valueobject.RetentionPolicy, error)
+	GetAiPricing(ctx context.Context) (*valueobject.AiPricing, error)
+	GetModelRouting(ctx context.Context) (*valueobject.ModelRouting, error)
+	GetPodcastReview(ctx context.Context) (*valueobject.PodcastReview, error)
+	GetJobQueueConfig(ctx context.Context) (*valueobject.JobQueueConfig, error)
+	GetDailyShowConfig(ctx context.Context) (*valueobject.DailyShowConfig, error)
+	GetNewsTopics(ctx context.Context) ([]string, error)
+	GetLanguage(ctx context.Context) (string, error)
 }
 
 type systemConfigService struct {
@@ -224,3 +233,137 @@ func (s *systemConfigService) GetPodcastConfig(ctx context.Context) (
 
 	return textAi, ttsAi, prompt, nil
 }
+
+// GetRetentionPolicy get the retention policy, falling back to the default policy.
+func (s *systemConfigService) GetRetentionPolicy(ctx context.Context) (*valueobject.RetentionPolicy, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.RetentionPolicyKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return valueobject.NewDefaultRetentionPolicy(), nil
+	}
+
+	return entity.UnmarshalValue[valueobject.RetentionPolicy](config, errorx.SystemConfigNotFound)
+}
+
+// GetAiPricing get the ai model prices and the monthly budget, no price and no budget by default.
+func (s *systemConfigService) GetAiPricing(ctx context.Context) (*valueobject.AiPricing, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.AiPricingKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return &valueobject.AiPricing{}, nil
+	}
+
+	return entity.UnmarshalValue[valueobject.AiPricing](config, errorx.SystemConfigNotFound)
+}
+
+// GetModelRouting get the text ai model profiles of the podcast task stages, no routing by default.
+func (s *systemConfigService) GetModelRouting(ctx context.Context) (*valueobject.ModelRouting, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.ModelRoutingKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return &valueobject.ModelRouting{}, nil
+	}
+
+	return entity.UnmarshalValue[valueobject.ModelRouting](config, errorx.SystemConfigNotFound)
+}
+
+// GetPodcastReview get the human review config of the podcast tasks, never paused by default.
+func (s *systemConfigService) GetPodcastReview(ctx context.Context) (*valueobject.PodcastReview, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.PodcastReviewKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return &valueobject.PodcastReview{}, nil
+	}
+
+	return entity.UnmarshalValue[valueobject.PodcastReview](config, errorx.SystemConfigNotFound)
+}
+
+// GetJobQueueConfig get the config of the podcast job queue.
+func (s *systemConfigService) GetJobQueueConfig(ctx context.Context) (*valueobject.JobQueueConfig, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.JobQueueKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return valueobject.NewDefaultJobQueueConfig(), nil
+	}
+
+	data, err := entity.UnmarshalValue[valueobject.JobQueueConfig](config, errorx.SystemConfigNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Normalize(), nil
+}
+
+// GetDailyShowConfig get the config of the daily show.
+func (s *systemConfigService) GetDailyShowConfig(ctx context.Context) (*valueobject.DailyShowConfig, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.DailyShowKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Id == 0 {
+		return valueobject.NewDefaultDailyShowConfig(), nil
+	}
+
+	data, err := entity.UnmarshalValue[valueobject.DailyShowConfig](config, errorx.SystemConfigNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Normalize(), nil
+}
+
+// GetNewsTopics get the news topic keywords.
+func (s *systemConfigService) GetNewsTopics(ctx context.Context) ([]string, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.NewsTopicKey.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var newsTopics []string
+
+	if config.Id == 0 {
+		return newsTopics, nil
+	}
+
+	if err := config.UnmarshalValue(&newsTopics); err != nil {
+		return nil, errorx.InternalError.SetErr(errors.New("invalid news topic config"))
+	}
+
+	return newsTopics, nil
+}
+
+// GetLanguage gets the language of the app, english by default.
+func (s *systemConfigService) GetLanguage(ctx context.Context) (string, error) {
+	config, err := s.GetSystemConfig(ctx, valueobject.LanguageKey.String())
+	if err != nil {
+		return "", err
+	}
+
+	lang := locale.En
+
+	if config.Id == 0 {
+		return lang, nil
+	}
+
+	if err := config.UnmarshalValue(&lang); err != nil {
+		return "", errorx.InternalError.SetErr(errors.New("invalid language config"))
+	}
+
+	return lang, nil
+}
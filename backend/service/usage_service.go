@@ -0,0 +1,117 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/repository"
+)
+
+// UsageService represents the interface for ai usage operations.
+type UsageService interface {
+	CreateUsage(ctx context.Context, usage *entity.AiUsage) error
+	QueryUsageStats(ctx context.Context, params *valueobject.QueryUsageParams) ([]*valueobject.UsageStat, error)
+	SumCost(ctx context.Context, start, end time.Time) (float64, error)
+}
+
+type usageService struct {
+}
+
+func NewUsageService() UsageService {
+	return &usageService{}
+}
+
+// CreateUsage creates an ai usage record.
+func (s *usageService) CreateUsage(ctx context.Context, usage *entity.AiUsage) error {
+	data := usage.ToModel()
+
+	if err := repository.Q.AiUsage.WithContext(ctx).Create(data); err != nil {
+		return errors.WithStack(err)
+	}
+
+	usage.Id = data.ID
+
+	return nil
+}
+
+// QueryUsageStats aggregates the ai usage of the date range by day, model or feature.
+func (s *usageService) QueryUsageStats(ctx context.Context, params *valueobject.QueryUsageParams) (
+	[]*valueobject.UsageStat, error) {
+	var (
+		repo  = repository.Q.AiUsage
+		query = repo.WithContext(ctx).
+			Where(repo.CreatedAt.Gte(params.StartDate), repo.CreatedAt.Lt(params.EndDate.AddDate(0, 0, 1)))
+	)
+
+	if params.Feature != "" {
+		query = query.Where(repo.Feature.Eq(string(params.Feature)))
+	}
+
+	if params.BatchNo != "" {
+		query = query.Where(repo.BatchNo.Eq(params.BatchNo))
+	}
+
+	data, err := query.Order(repo.ID).Find()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var (
+		stats = make([]*valueobject.UsageStat, 0)
+		keys  = make(map[string]*valueobject.UsageStat)
+	)
+
+	for _, item := range data {
+		usage := entity.NewAiUsageFromModel(item)
+		key := usage.StatKey(params.Dimension)
+
+		stat, ok := keys[key]
+		if !ok {
+			stat = &valueobject.UsageStat{Key: key}
+			keys[key] = stat
+			stats = append(stats, stat)
+		}
+
+		stat.Calls++
+		stat.PromptTokens += usage.PromptTokens
+		stat.CompletionTokens += usage.CompletionTokens
+		stat.TotalTokens += usage.TotalTokens
+		stat.Characters += usage.Characters
+		stat.Cost += usage.Cost
+	}
+
+	// the days in order, the most expensive models and features first
+	slices.SortFunc(stats, func(a, b *valueobject.UsageStat) int {
+		if params.Dimension == valueobject.UsageByDay {
+			return cmp.Compare(a.Key, b.Key)
+		}
+
+		return cmp.Or(cmp.Compare(b.Cost, a.Cost), cmp.Compare(b.TotalTokens, a.TotalTokens),
+			cmp.Compare(a.Key, b.Key))
+	})
+
+	return stats, nil
+}
+
+// SumCost sums the cost of the ai usage created in [start, end).
+func (s *usageService) SumCost(ctx context.Context, start, end time.Time) (float64, error) {
+	var (
+		repo   = repository.Q.AiUsage
+		result struct{ Cost *float64 } // null without usage
+	)
+
+	err := repo.WithContext(ctx).Select(repo.Cost.Sum().As("cost")).
+		Where(repo.CreatedAt.Gte(start), repo.CreatedAt.Lt(end)).Scan(&result)
+
+	if err != nil || result.Cost == nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return *result.Cost, nil
+}
@@ -0,0 +1,8 @@
+//go:build !web
+
+package task
+
+// platformJobs returns the jobs only run in the desktop app.
+func (s *scheduler) platformJobs() []*job {
+	return nil
+}
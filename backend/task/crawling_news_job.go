@@ -4,27 +4,34 @@ package task
 
 import (
 	"context"
-	"time"
+
+	"github.com/go-co-op/gocron/v2"
 
 	"github.com/mjiee/world-news/backend/command"
 	"github.com/mjiee/world-news/backend/pkg/logx"
 	"github.com/mjiee/world-news/backend/pkg/tracex"
 )
 
-const expiredRecordDuration = 30 * 24 * time.Hour
+// platformJobs returns the jobs only run in the web service.
+func (s *scheduler) platformJobs() []*job {
+	return []*job{
+		{
+			name:       "crawlingNewsJob",
+			definition: gocron.DailyJob(1, gocron.NewAtTimes(gocron.NewAtTime(12, 0, 0))),
+			task:       s.crawlingNewsJob,
+		},
+	}
+}
 
 // crawlingNewsJob executes the news crawling job by creating and running a crawling command.
 func (s *scheduler) crawlingNewsJob() {
 	var (
 		ctx = tracex.InjectTraceInContext(context.Background())
-		cmd = command.NewCrawlingNewsCommand(ctx, "", nil, nil, s.crawlingSvc, s.newsSvc, s.systemConfigSvc)
+		cmd = command.NewCrawlingNewsCommand(ctx, "", nil, nil, s.crawlingSvc, s.newsSvc, s.systemConfigSvc,
+			s.entitySvc)
 	)
 
 	if err := cmd.Execute(ctx); err != nil {
 		logx.Error("crawlingNewsJob", err)
 	}
-
-	if err := s.crawlingSvc.DeleteHistory(ctx, time.Now().Add(-expiredRecordDuration)); err != nil {
-		logx.Error("crawlingNewsJob", err)
-	}
 }
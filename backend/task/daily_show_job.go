@@ -0,0 +1,46 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/command"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+)
+
+// dailyShowInterval is the interval of checking the scheduled daily show.
+const dailyShowInterval = time.Hour
+
+// dailyShowJob returns the job that creates the daily show of the top news once a day.
+func (s *scheduler) dailyShowJob() *job {
+	return &job{
+		name:       "dailyShowJob",
+		definition: gocron.DurationJob(dailyShowInterval),
+		task:       s.createDailyShow,
+		options: []gocron.JobOption{
+			gocron.WithStartAt(gocron.WithStartImmediately()),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		},
+	}
+}
+
+// createDailyShow creates the scheduled daily show once the show hour is reached.
+func (s *scheduler) createDailyShow() {
+	var (
+		ctx = tracex.InjectTraceInContext(context.Background())
+		cmd = command.NewDailyShowCommand(ctx, true, s.crawlingSvc, s.newsSvc, s.systemConfigSvc, s.taskSvc,
+			s.promptSvc, s.usageSvc, s.jobSvc)
+	)
+
+	_, err := cmd.Execute(ctx)
+	if err == nil || errors.Is(err, errorx.NewsNotFound) || errors.Is(err, errorx.CrawlingRecordNotFound) {
+		return
+	}
+
+	logx.Error("dailyShowJob", err)
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/command"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+)
+
+// digestInterval is the interval of checking the scheduled news digest.
+const digestInterval = time.Hour
+
+// digestJob returns the job that generates the news digest of the previous day every morning.
+func (s *scheduler) digestJob() *job {
+	return &job{
+		name:       "digestJob",
+		definition: gocron.DurationJob(digestInterval),
+		task:       s.generateDigest,
+		options: []gocron.JobOption{
+			gocron.WithStartAt(gocron.WithStartImmediately()),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		},
+	}
+}
+
+// generateDigest generates the scheduled news digest once the digest hour is reached.
+func (s *scheduler) generateDigest() {
+	var (
+		ctx = tracex.InjectTraceInContext(context.Background())
+		cmd = command.NewGenerateDigestCommand(ctx, "", true, s.newsSvc, s.digestSvc, s.systemConfigSvc)
+	)
+
+	_, err := cmd.Execute(ctx)
+	if err == nil || errors.Is(err, errorx.NewsNotFound) {
+		return
+	}
+
+	logx.Error("digestJob", err)
+}
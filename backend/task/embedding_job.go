@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"github.com/mjiee/world-news/backend/command"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+)
+
+// embeddingInterval is the interval of the news embedding backfill job.
+const embeddingInterval = time.Hour
+
+// embeddingJob returns the job that embeds the news crawled since the last run.
+func (s *scheduler) embeddingJob() *job {
+	return &job{
+		name:       "embeddingJob",
+		definition: gocron.DurationJob(embeddingInterval),
+		task:       s.embedNews,
+		options:    []gocron.JobOption{gocron.WithSingletonMode(gocron.LimitModeReschedule)},
+	}
+}
+
+// embedNews starts the embedding backfill if the embedding ai is configured.
+func (s *scheduler) embedNews() {
+	var (
+		ctx = tracex.InjectTraceInContext(context.Background())
+		cmd = command.NewEmbedNewsCommand(ctx, true, s.crawlingSvc, s.embeddingSvc, s.systemConfigSvc)
+	)
+
+	if err := cmd.Execute(ctx); err != nil {
+		logx.Error("embeddingJob", err)
+	}
+}
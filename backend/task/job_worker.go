@@ -0,0 +1,201 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mjiee/world-news/backend/command"
+	"github.com/mjiee/world-news/backend/entity"
+	"github.com/mjiee/world-news/backend/entity/valueobject"
+	"github.com/mjiee/world-news/backend/pkg/errorx"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+	"github.com/mjiee/world-news/backend/service"
+)
+
+// jobPollInterval is the interval of polling the queued podcast jobs.
+const jobPollInterval = time.Second
+
+// JobWorker runs the queued podcast jobs with a pool of workers.
+// A running job renews its lease with heartbeats, the jobs whose lease expired are recovered by the pool.
+type JobWorker struct {
+	name   string
+	cancel context.CancelFunc
+
+	systemConfigSvc service.SystemConfigService
+	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	jobSvc          service.PodcastJobService
+}
+
+// NewJobWorker recovers the jobs interrupted by the last run and starts the workers of the job queue.
+func NewJobWorker(
+	systemConfigSvc service.SystemConfigService,
+	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	jobSvc service.PodcastJobService,
+) (*JobWorker, error) {
+	hostname, _ := os.Hostname()
+
+	w := &JobWorker{
+		name:            fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		systemConfigSvc: systemConfigSvc,
+		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		jobSvc:          jobSvc,
+	}
+
+	ctx := tracex.InjectTraceInContext(context.Background())
+
+	config, err := systemConfigSvc.GetJobQueueConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := command.NewRecoverJobsCommand(true, systemConfigSvc, taskSvc, jobSvc).Execute(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	for range config.Concurrency {
+		go w.work(ctx)
+	}
+
+	go w.recoverJobs(ctx)
+
+	logx.Info("JobWorker", w.name)
+
+	return w, nil
+}
+
+// Shutdown stops claiming jobs. The running jobs keep their lease until the app exits,
+// they are resumed after the lease expires on the next start.
+func (w *JobWorker) Shutdown() {
+	w.cancel()
+}
+
+// work claims and runs the queued jobs one by one.
+func (w *JobWorker) work(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		job, err := w.jobSvc.ClaimJob(ctx, w.name)
+		if err != nil {
+			logx.Error("JobWorker.ClaimJob", err)
+
+			continue
+		}
+
+		if job != nil {
+			w.run(job)
+		}
+	}
+}
+
+// run runs the job, renewing its lease until it finishes. The job is cancelled once its lease is lost.
+func (w *JobWorker) run(job *entity.PodcastJob) {
+	var (
+		ctx, cancel = context.WithCancel(tracex.InjectTraceInContext(context.Background()))
+		done        = make(chan struct{})
+	)
+	defer cancel()
+
+	go w.heartbeat(ctx, cancel, *job, done)
+
+	err := command.NewRunJobCommand(job, w.systemConfigSvc, w.taskSvc, w.promptSvc, w.jobSvc).Execute(ctx)
+	close(done)
+
+	// the job is recovered by the pool, its result is left to the worker running it again
+	if ctx.Err() != nil {
+		logx.WithContext(ctx).Error("JobWorker.run", errorx.JobLeaseLost.SetErr(err))
+
+		return
+	}
+
+	if err != nil {
+		logx.WithContext(ctx).Error("JobWorker.run", err)
+	}
+
+	job.Finish(err)
+
+	if err := w.jobSvc.FinishJob(ctx, job); err != nil {
+		logx.WithContext(ctx).Error("JobWorker.FinishJob", err)
+	}
+
+	// the daily show waiting for the article is queued once its last article ends
+	if job.Kind == valueobject.JobKindExecuteTask {
+		if err := command.NewQueueDailyShowsCommand(w.taskSvc, w.jobSvc).Execute(ctx); err != nil {
+			logx.WithContext(ctx).Error("JobWorker.QueueDailyShows", err)
+		}
+	}
+}
+
+// heartbeat renews the lease of the running job until it is done, it cancels the job once the lease is lost,
+// i.e. the job is owned by another worker or the lease expired without a renewal.
+func (w *JobWorker) heartbeat(ctx context.Context, cancel context.CancelFunc, job entity.PodcastJob,
+	done <-chan struct{}) {
+	var (
+		ticker     = time.NewTicker(valueobject.JobLease / 3)
+		leaseUntil = job.LeaseUntil
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		err := w.jobSvc.RenewJob(ctx, &job)
+		if err == nil {
+			leaseUntil = job.LeaseUntil
+
+			continue
+		}
+
+		logx.WithContext(ctx).Error("JobWorker.RenewJob", err)
+
+		if errors.Is(err, errorx.JobLeaseLost) || time.Now().After(leaseUntil) {
+			cancel()
+
+			return
+		}
+	}
+}
+
+// recoverJobs recovers the jobs whose lease expired, e.g. the jobs left running by a restart within the lease,
+// and queues the daily shows whose articles ended without a worker, e.g. cancelled or failed by the recovery.
+func (w *JobWorker) recoverJobs(ctx context.Context) {
+	ticker := time.NewTicker(valueobject.JobLease)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cmd := command.NewRecoverJobsCommand(false, w.systemConfigSvc, w.taskSvc, w.jobSvc)
+		if err := cmd.Execute(ctx); err != nil {
+			logx.Error("JobWorker.recoverJobs", err)
+		}
+
+		if err := command.NewQueueDailyShowsCommand(w.taskSvc, w.jobSvc).Execute(ctx); err != nil {
+			logx.Error("JobWorker.QueueDailyShows", err)
+		}
+	}
+}
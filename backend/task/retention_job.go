@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"github.com/mjiee/world-news/backend/command"
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+)
+
+// retentionInterval is the interval of the retention job.
+const retentionInterval = 24 * time.Hour
+
+// retentionJob returns the job that deletes historical data by the retention policy.
+// The first run is a day after the start, data are never deleted at startup.
+func (s *scheduler) retentionJob() *job {
+	return &job{
+		name:       "retentionJob",
+		definition: gocron.DurationJob(retentionInterval),
+		task:       s.applyRetention,
+	}
+}
+
+// applyRetention deletes the historical news and crawling records.
+func (s *scheduler) applyRetention() {
+	var (
+		ctx = tracex.InjectTraceInContext(context.Background())
+		cmd = command.NewApplyRetentionCommand(false, true, s.crawlingSvc, s.systemConfigSvc)
+	)
+
+	if _, err := cmd.Execute(ctx); err != nil {
+		logx.Error("retentionJob", err)
+	}
+}
@@ -1,5 +1,3 @@
-//go:build web
-
 package task
 
 import (
@@ -15,6 +13,21 @@ type scheduler struct {
 	crawlingSvc     service.CrawlingService
 	newsSvc         service.NewsService
 	systemConfigSvc service.SystemConfigService
+	entitySvc       service.EntityService
+	digestSvc       service.DigestService
+	embeddingSvc    service.EmbeddingService
+	taskSvc         service.PodcastTaskService
+	promptSvc       service.PromptService
+	usageSvc        service.UsageService
+	jobSvc          service.PodcastJobService
+}
+
+// job represents a scheduled job.
+type job struct {
+	name       string
+	definition gocron.JobDefinition
+	task       func()
+	options    []gocron.JobOption
 }
 
 // NewScheduler creates and starts a new job scheduler instance.
@@ -22,32 +35,49 @@ func NewScheduler(
 	crawlingSvc service.CrawlingService,
 	newsSvc service.NewsService,
 	systemConfigSvc service.SystemConfigService,
-) error {
+	entitySvc service.EntityService,
+	digestSvc service.DigestService,
+	embeddingSvc service.EmbeddingService,
+	taskSvc service.PodcastTaskService,
+	promptSvc service.PromptService,
+	usageSvc service.UsageService,
+	jobSvc service.PodcastJobService,
+) (gocron.Scheduler, error) {
 	svc := &scheduler{
 		crawlingSvc:     crawlingSvc,
 		newsSvc:         newsSvc,
 		systemConfigSvc: systemConfigSvc,
+		entitySvc:       entitySvc,
+		digestSvc:       digestSvc,
+		embeddingSvc:    embeddingSvc,
+		taskSvc:         taskSvc,
+		promptSvc:       promptSvc,
+		usageSvc:        usageSvc,
+		jobSvc:          jobSvc,
 	}
 
 	s, err := gocron.NewScheduler()
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 
-	job, err := s.NewJob(
-		gocron.DailyJob(
-			1,
-			gocron.NewAtTimes(gocron.NewAtTime(12, 0, 0)),
-		),
-		gocron.NewTask(svc.crawlingNewsJob),
-	)
-	if err != nil {
-		return errors.WithStack(err)
-	}
+	for _, item := range svc.jobs() {
+		job, err := s.NewJob(item.definition, gocron.NewTask(item.task),
+			append(item.options, gocron.WithName(item.name))...)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
 
-	logx.Info("newCrawlingNewsJob", job.ID())
+		logx.Info(item.name, job.ID())
+	}
 
 	s.Start()
 
-	return nil
+	return s, nil
+}
+
+// jobs returns the jobs of the scheduler.
+func (s *scheduler) jobs() []*job {
+	return append(s.platformJobs(), s.retentionJob(), s.scrapeRetryJob(), s.digestJob(), s.embeddingJob(),
+		s.dailyShowJob())
 }
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"github.com/mjiee/world-news/backend/pkg/logx"
+	"github.com/mjiee/world-news/backend/pkg/tracex"
+)
+
+const (
+	// scrapeRetryInterval is the interval of the scrape retry job.
+	scrapeRetryInterval = 10 * time.Minute
+
+	// scrapeRetryLimit is the maximum number of news retried in one run.
+	scrapeRetryLimit = 50
+)
+
+// scrapeRetryJob returns the job that retries the failed news detail scrapes.
+func (s *scheduler) scrapeRetryJob() *job {
+	return &job{
+		name:       "scrapeRetryJob",
+		definition: gocron.DurationJob(scrapeRetryInterval),
+		task:       s.retryFailedScrapes,
+		options:    []gocron.JobOption{gocron.WithSingletonMode(gocron.LimitModeReschedule)},
+	}
+}
+
+// retryFailedScrapes retries the failed news detail scrapes.
+func (s *scheduler) retryFailedScrapes() {
+	ctx := tracex.InjectTraceInContext(context.Background())
+
+	count, err := s.newsSvc.RetryFailedScrapes(ctx, scrapeRetryLimit)
+	if err != nil {
+		logx.Error("scrapeRetryJob", err)
+
+		return
+	}
+
+	if count > 0 {
+		logx.WithContext(ctx).Info("scrapeRetryJob", count)
+	}
+}
@@ -88,6 +88,7 @@ func ApiRouter(r *gin.RouterGroup, webAdapter *adapter.WebAadapter) {
 	r.POST("/system/config", webAdapter.GetSystemConfig)
 	r.POST("/system/config/save", webAdapter.SaveSystemConfig)
 	r.POST("/system/website/weight", webAdapter.SaveWebsiteWeight)
+	r.POST("/system/retention", webAdapter.ApplyRetention)
 	r.POST("/crawling/website", webAdapter.CrawlingWebsite)
 	r.POST("/crawling/news", webAdapter.CrawlingNews)
 	r.POST("/crawling/processing/task", webAdapter.HasCrawlingTasks)
@@ -97,11 +98,63 @@ func ApiRouter(r *gin.RouterGroup, webAdapter *adapter.WebAadapter) {
 	r.POST("/crawling/record/status", webAdapter.UpdateCrawlingRecordStatus)
 	r.POST("/news/query", webAdapter.QueryNews)
 	r.POST("/news/detail", webAdapter.GetNewsDetail)
+	r.POST("/news/related", webAdapter.GetRelatedNews)
+	r.POST("/news/refresh", webAdapter.RefreshNewsDetail)
+	r.POST("/news/revision/query", webAdapter.QueryNewsRevisions)
+	r.POST("/news/revision/diff", webAdapter.DiffNewsRevisions)
 	r.POST("/news/delete", webAdapter.DeleteNews)
 	r.POST("/news/critique", webAdapter.CritiqueNews)
+	r.POST("/news/critique/stream", webAdapter.CritiqueNewsStream)
+	r.POST("/news/compare", webAdapter.CompareNews)
+	r.POST("/news/analysis/query", webAdapter.QueryNewsAnalyses)
+	r.POST("/news/analysis/get", webAdapter.GetNewsAnalysis)
+	r.POST("/news/analysis/delete", webAdapter.DeleteNewsAnalysis)
 	r.POST("/news/translate", webAdapter.TranslateNews)
+	r.POST("/news/summarize", webAdapter.SummarizeNews)
+	r.POST("/news/summarize/batch", webAdapter.SummarizeNewsBatch)
+	r.POST("/news/search", webAdapter.SearchNews)
+	r.POST("/news/embed", webAdapter.EmbedNews)
 	r.POST("/news/favorite", webAdapter.SaveNewsFavorite)
+	r.POST("/news/export", webAdapter.ExportNews)
+	r.POST("/analytics/terms", webAdapter.QueryTermFrequencies)
+	r.POST("/analytics/trending", webAdapter.QueryTrendingTerms)
+	r.POST("/analytics/series", webAdapter.QueryNewsTimeSeries)
+	r.POST("/entity/extract", webAdapter.ExtractEntities)
+	r.POST("/entity/query", webAdapter.QueryEntities)
+	r.POST("/entity/news", webAdapter.QueryEntityNews)
+	r.POST("/entity/cooccurring", webAdapter.QueryCooccurringEntities)
+	r.POST("/digest/generate", webAdapter.GenerateDigest)
+	r.POST("/digest/query", webAdapter.QueryDigests)
+	r.POST("/digest/get", webAdapter.GetDigest)
+	r.POST("/digest/delete", webAdapter.DeleteDigest)
+	r.POST("/digest/export", webAdapter.ExportDigest)
+	r.POST("/chat/create", webAdapter.CreateChat)
+	r.POST("/chat/ask", webAdapter.AskChat)
+	r.POST("/chat/ask/stream", webAdapter.AskChatStream)
+	r.POST("/chat/query", webAdapter.QueryChats)
+	r.POST("/chat/get", webAdapter.GetChat)
+	r.POST("/chat/delete", webAdapter.DeleteChat)
 	r.POST("/task/create", webAdapter.CreateTask)
+	r.POST("/task/dailyShow", webAdapter.CreateDailyShow)
 	r.POST("/task/query", webAdapter.QueryTasks)
 	r.POST("/task/detail", webAdapter.GetTask)
+	r.POST("/task/stream", webAdapter.TaskStream)
+	r.POST("/task/stage/review", webAdapter.ReviewTaskStage)
+	r.POST("/task/cancel", webAdapter.CancelTask)
+	r.POST("/task/stage/retry", webAdapter.RetryTaskStage)
+	r.POST("/job/query", webAdapter.QueryJobs)
+	r.POST("/podcast/publish", webAdapter.PublishPodcast)
+	r.POST("/podcast/query", webAdapter.QueryPodcasts)
+	r.POST("/podcast/get", webAdapter.GetPodcast)
+	r.POST("/podcast/update", webAdapter.UpdatePodcast)
+	r.POST("/podcast/unpublish", webAdapter.UnpublishPodcast)
+	r.POST("/stream/cancel", webAdapter.CancelStream)
+	r.POST("/prompt/save", webAdapter.SavePrompt)
+	r.POST("/prompt/query", webAdapter.QueryPrompts)
+	r.POST("/prompt/versions", webAdapter.QueryPromptVersions)
+	r.POST("/prompt/rollback", webAdapter.RollbackPrompt)
+	r.POST("/prompt/delete", webAdapter.DeletePrompt)
+	r.POST("/prompt/preview", webAdapter.PreviewPrompt)
+	r.POST("/usage/stats", webAdapter.QueryUsageStats)
+	r.POST("/usage/budget", webAdapter.GetAiBudget)
 }